@@ -9,10 +9,19 @@ import (
 
 type (
 	Config struct {
-		App        App        `yaml:"app"`
-		HTTP       HTTP       `yaml:"http"`
-		Postgres   Postgres   `yaml:"postgres"`
-		Log        Log        `yaml:"logger"`
+		App          App          `yaml:"app"`
+		HTTP         HTTP         `yaml:"http"`
+		Postgres     Postgres     `yaml:"postgres"`
+		Log          Log          `yaml:"logger"`
+		Reviewers    Reviewers    `yaml:"reviewers"`
+		MergePolicy  MergePolicy  `yaml:"merge_policy"`
+		StaleReviews StaleReviews `yaml:"stale_reviews"`
+		Webhooks     Webhooks     `yaml:"webhooks"`
+		Outbox       Outbox       `yaml:"outbox"`
+		Auth         Auth         `yaml:"auth"`
+		Idempotency  Idempotency  `yaml:"idempotency"`
+		BulkImport   BulkImport   `yaml:"bulk_import"`
+		Stats        Stats        `yaml:"stats"`
 	}
 
 	App struct {
@@ -31,6 +40,82 @@ type (
 	Log struct {
 		Level string `env-required:"true" yaml:"level" env:"LOG_LEVEL"`
 	}
+
+	Reviewers struct {
+		Strategy       string              `yaml:"strategy" env:"REVIEWERS_STRATEGY" env-default:"random"`
+		TeamStrategies map[string]string   `yaml:"team_strategies"`
+		BuddyTeams     map[string][]string `yaml:"buddy_teams"`
+	}
+
+	MergePolicy struct {
+		MinApprovals            int  `yaml:"min_approvals" env:"MERGE_MIN_APPROVALS" env-default:"0"`
+		BlockOnChangesRequested bool `yaml:"block_on_changes_requested" env:"MERGE_BLOCK_ON_CHANGES_REQUESTED" env-default:"false"`
+		RequireTeamApproval     bool `yaml:"require_team_approval" env:"MERGE_REQUIRE_TEAM_APPROVAL" env-default:"false"`
+	}
+
+	StaleReviews struct {
+		Enabled    bool          `yaml:"enabled" env:"STALE_REVIEWS_ENABLED" env-default:"false"`
+		Interval   time.Duration `yaml:"interval" env:"STALE_REVIEWS_INTERVAL" env-default:"10m"`
+		Threshold  time.Duration `yaml:"threshold" env:"STALE_REVIEWS_THRESHOLD" env-default:"48h"`
+		BatchSize  int           `yaml:"batch_size" env:"STALE_REVIEWS_BATCH_SIZE" env-default:"50"`
+		RetryAfter time.Duration `yaml:"retry_after" env:"STALE_REVIEWS_RETRY_AFTER" env-default:"1h"`
+	}
+
+	Webhooks struct {
+		Enabled         bool          `yaml:"enabled" env:"WEBHOOKS_ENABLED" env-default:"false"`
+		Interval        time.Duration `yaml:"interval" env:"WEBHOOKS_INTERVAL" env-default:"5s"`
+		BatchSize       int           `yaml:"batch_size" env:"WEBHOOKS_BATCH_SIZE" env-default:"20"`
+		MaxAttempts     int           `yaml:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS" env-default:"8"`
+		Timeout         time.Duration `yaml:"timeout" env:"WEBHOOKS_TIMEOUT" env-default:"5s"`
+		Retention       time.Duration `yaml:"retention" env:"WEBHOOKS_RETENTION" env-default:"168h"`
+		CleanupInterval time.Duration `yaml:"cleanup_interval" env:"WEBHOOKS_CLEANUP_INTERVAL" env-default:"1h"`
+	}
+
+	Outbox struct {
+		Enabled         bool          `yaml:"enabled" env:"OUTBOX_ENABLED" env-default:"false"`
+		Interval        time.Duration `yaml:"interval" env:"OUTBOX_INTERVAL" env-default:"1s"`
+		BatchSize       int           `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE" env-default:"100"`
+		Sinks           []string      `yaml:"sinks" env:"OUTBOX_SINKS" env-separator:"," env-default:"log"`
+		HTTPURL         string        `yaml:"http_url" env:"OUTBOX_HTTP_URL"`
+		HTTPTimeout     time.Duration `yaml:"http_timeout" env:"OUTBOX_HTTP_TIMEOUT" env-default:"5s"`
+		FilePath        string        `yaml:"file_path" env:"OUTBOX_FILE_PATH" env-default:"outbox.ndjson"`
+		MaxAttempts     int           `yaml:"max_attempts" env:"OUTBOX_MAX_ATTEMPTS" env-default:"20"`
+		Retention       time.Duration `yaml:"retention" env:"OUTBOX_RETENTION" env-default:"168h"`
+		CleanupInterval time.Duration `yaml:"cleanup_interval" env:"OUTBOX_CLEANUP_INTERVAL" env-default:"1h"`
+	}
+
+	Auth struct {
+		Enabled       bool        `yaml:"enabled" env:"AUTH_ENABLED" env-default:"false"`
+		JWTSecret     string      `yaml:"jwt_secret" env:"AUTH_JWT_SECRET"`
+		JWTAllowNoExp bool        `yaml:"jwt_allow_no_exp" env:"AUTH_JWT_ALLOW_NO_EXP" env-default:"false"`
+		Tokens        []AuthToken `yaml:"tokens"`
+	}
+
+	Idempotency struct {
+		Enabled         bool          `yaml:"enabled" env:"IDEMPOTENCY_ENABLED" env-default:"true"`
+		TTL             time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL" env-default:"24h"`
+		LockTimeout     time.Duration `yaml:"lock_timeout" env:"IDEMPOTENCY_LOCK_TIMEOUT" env-default:"1m"`
+		CleanupInterval time.Duration `yaml:"cleanup_interval" env:"IDEMPOTENCY_CLEANUP_INTERVAL" env-default:"1h"`
+		MaxBodyBytes    int64         `yaml:"max_body_bytes" env:"IDEMPOTENCY_MAX_BODY_BYTES" env-default:"4194304"`
+	}
+
+	BulkImport struct {
+		BatchSize    int   `yaml:"batch_size" env:"BULK_IMPORT_BATCH_SIZE" env-default:"50"`
+		MaxItems     int   `yaml:"max_items" env:"BULK_IMPORT_MAX_ITEMS" env-default:"1000"`
+		MaxBodyBytes int64 `yaml:"max_body_bytes" env:"BULK_IMPORT_MAX_BODY_BYTES" env-default:"4194304"`
+	}
+
+	Stats struct {
+		ReviewSLAWindow time.Duration `yaml:"review_sla_window" env:"STATS_REVIEW_SLA_WINDOW" env-default:"720h"`
+	}
+
+	// Static API token of a user
+	AuthToken struct {
+		Token   string `yaml:"token"`
+		Subject string `yaml:"subject"`
+		Role    string `yaml:"role"`
+		Team    string `yaml:"team"`
+	}
 )
 
 func New(configPath string) (*Config, error) {
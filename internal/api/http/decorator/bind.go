@@ -5,10 +5,15 @@ import (
 	"net/http"
 
 	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/pkg/actor"
+	"github.com/4udiwe/avito-pr-service/pkg/auth"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
 )
 
+// Header with the name of whoever performs the request, recorded in the audit trail
+const ActorHeader = "X-Actor"
+
 type handler[T any] interface {
 	Handle(c echo.Context, in T) error
 }
@@ -36,9 +41,21 @@ func (d *bindAndValidateDecorator[T]) Handle(c echo.Context) error {
 		return d.handleError(err, err.Error())
 	}
 
+	SetActor(c)
+
 	return d.inner.Handle(c, in)
 }
 
+// SetActor stores the X-Actor header as the actor of the request.
+// Authenticated caller is the actor, the header is trusted only without authentication
+func SetActor(c echo.Context) {
+	if _, ok := auth.FromContext(c.Request().Context()); !ok {
+		if name := c.Request().Header.Get(ActorHeader); name != "" {
+			c.SetRequest(c.Request().WithContext(actor.WithActor(c.Request().Context(), name)))
+		}
+	}
+}
+
 func (d *bindAndValidateDecorator[T]) handleError(err error, defaultMsg string) *echo.HTTPError {
 	var httpErr *echo.HTTPError
 	if errors.As(err, &httpErr) {
@@ -0,0 +1,11 @@
+package get_code_owners
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type CodeOwnerService interface {
+	GetCodeOwners(ctx context.Context) ([]entity.CodeOwner, error)
+}
@@ -0,0 +1,57 @@
+package get_code_owners
+
+import (
+	"net/http"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s CodeOwnerService
+}
+
+func New(codeOwnerService CodeOwnerService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: codeOwnerService})
+}
+
+type Request struct{}
+
+type CodeOwner struct {
+	ID       uuid.UUID `json:"id"`
+	Pattern  string    `json:"pattern"`
+	UserID   *string   `json:"user_id,omitempty"`
+	TeamName *string   `json:"team_name,omitempty"`
+}
+
+type Response struct {
+	CodeOwners []CodeOwner `json:"code_owners"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	owners, err := h.s.GetCodeOwners(ctx.Request().Context())
+
+	if err != nil {
+		var errResponse dto.ErrorResponse
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+
+	response := Response{
+		CodeOwners: lo.Map(owners, func(e entity.CodeOwner, _ int) CodeOwner {
+			return CodeOwner{
+				ID:       e.ID,
+				Pattern:  e.Pattern,
+				UserID:   lo.EmptyableToPtr(e.UserID),
+				TeamName: lo.EmptyableToPtr(e.TeamName),
+			}
+		}),
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
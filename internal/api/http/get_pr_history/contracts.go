@@ -0,0 +1,11 @@
+package get_pr_history
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type PRService interface {
+	GetHistory(ctx context.Context, prID string) ([]entity.AssignmentEvent, error)
+}
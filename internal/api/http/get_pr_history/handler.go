@@ -0,0 +1,77 @@
+package get_pr_history
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	service "github.com/4udiwe/avito-pr-service/internal/service/pr"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s PRService
+}
+
+func New(prService PRService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: prService})
+}
+
+type Request struct {
+	PullRequestID string `query:"pull_request_id" validate:"required"`
+}
+
+type Event struct {
+	ID                 uuid.UUID `json:"id"`
+	Type               string    `json:"type"`
+	ReviewerID         *string   `json:"reviewer_id,omitempty"`
+	PreviousReviewerID *string   `json:"previous_reviewer_id,omitempty"`
+	Actor              string    `json:"actor"`
+	Reason             string    `json:"reason"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+type Response struct {
+	PullRequestID string  `json:"pull_request_id"`
+	Events        []Event `json:"events"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	events, err := h.s.GetHistory(ctx.Request().Context(), in.PullRequestID)
+
+	if err != nil {
+		var errResponse dto.ErrorResponse
+
+		if errors.Is(err, service.ErrPRNotFound) {
+			errResponse.Error.Code = dto.NOTFOUND
+			errResponse.Error.Message = "resource not found"
+			return echo.NewHTTPError(http.StatusNotFound, errResponse)
+		}
+
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+
+	response := Response{
+		PullRequestID: in.PullRequestID,
+		Events: lo.Map(events, func(e entity.AssignmentEvent, _ int) Event {
+			return Event{
+				ID:                 e.ID,
+				Type:               string(e.Type),
+				ReviewerID:         lo.EmptyableToPtr(e.ReviewerID),
+				PreviousReviewerID: lo.EmptyableToPtr(e.PreviousReviewerID),
+				Actor:              e.Actor,
+				Reason:             e.Reason,
+				CreatedAt:          e.CreatedAt,
+			}
+		}),
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
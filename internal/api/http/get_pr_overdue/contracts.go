@@ -0,0 +1,11 @@
+package get_pr_overdue
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type PRService interface {
+	GetOverdueReviews(ctx context.Context) ([]entity.OverdueReview, error)
+}
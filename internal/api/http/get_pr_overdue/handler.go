@@ -0,0 +1,70 @@
+package get_pr_overdue
+
+import (
+	"net/http"
+	"time"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s PRService
+}
+
+func New(prService PRService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: prService})
+}
+
+type Request struct{}
+
+type OverdueReview struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	TeamName        string    `json:"team_name"`
+	ReviewerID      string    `json:"reviewer_id"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	ReviewSLAHours  int       `json:"review_sla_hours"`
+	OverdueHours    float64   `json:"overdue_hours"`
+}
+
+type Response struct {
+	Reviews []OverdueReview `json:"reviews"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	reviews, err := h.s.GetOverdueReviews(ctx.Request().Context())
+
+	if err != nil {
+		var errResponse dto.ErrorResponse
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+
+	response := Response{
+		Reviews: lo.Map(reviews, func(r entity.OverdueReview, _ int) OverdueReview {
+			return OverdueReview{
+				PullRequestID:   r.PRID,
+				PullRequestName: r.Title,
+				AuthorID:        r.AuthorID,
+				TeamName:        r.TeamName,
+				ReviewerID:      r.ReviewerID,
+				AssignedAt:      r.AssignedAt,
+				ReviewSLAHours:  int(r.ReviewSLA.Hours()),
+				OverdueHours:    roundHours(r.Overdue),
+			}
+		}),
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// Hours with one decimal place
+func roundHours(d time.Duration) float64 {
+	return float64(d.Round(6*time.Minute)) / float64(time.Hour)
+}
@@ -36,9 +36,19 @@ type PullRequest struct {
 	MergedAt          *time.Time            `json:"mergedAt"`
 	PullRequestId     string                `json:"pull_request_id"`
 	PullRequestName   string                `json:"pull_request_name"`
+	Description       string                `json:"description"`
 	Status            dto.PullRequestStatus `json:"status"`
 	// custom field
-	NeedMoreReviewers bool `json:"need_more_reviewers"`
+	NeedMoreReviewers bool     `json:"need_more_reviewers"`
+	Reviews           []Review `json:"reviews"`
+}
+
+// Latest decision of a reviewer
+type Review struct {
+	ReviewerID  string    `json:"reviewer_id"`
+	State       string    `json:"state"`
+	Comment     string    `json:"comment"`
+	SubmittedAt time.Time `json:"submitted_at"`
 }
 
 type GetAllPRsResponse struct {
@@ -79,8 +89,17 @@ func (h *handler) Handle(ctx echo.Context, in GetAllPRsRequest) error {
 				MergedAt:          e.MergedAt,
 				PullRequestId:     e.ID,
 				PullRequestName:   e.Title,
+				Description:       e.Description,
 				Status:            dto.PullRequestStatus(e.Status.Name),
 				NeedMoreReviewers: e.NeedMoreReviewers,
+				Reviews: lo.Map(e.Reviews, func(r entity.Review, _ int) Review {
+					return Review{
+						ReviewerID:  r.ReviewerID,
+						State:       string(r.State),
+						Comment:     r.Comment,
+						SubmittedAt: r.CreatedAt,
+					}
+				}),
 			}
 		}),
 		Page:       in.Page,
@@ -49,12 +49,16 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 	response := Response{
 		UserID: in.UserID,
 		PRs: lo.Map(PRs, func(e entity.PullRequest, _ int) dto.PullRequestShort {
-			return dto.PullRequestShort{
+			short := dto.PullRequestShort{
 				AuthorId:        e.AuthorID,
 				Status:          dto.PullRequestShortStatus(e.Status.Name),
 				PullRequestId:   e.ID,
 				PullRequestName: e.Title,
 			}
+			if review, ok := lo.Find(e.Reviews, func(r entity.Review) bool { return r.ReviewerID == in.UserID }); ok {
+				short.ReviewState = lo.ToPtr(dto.PullRequestShortReviewState(review.State))
+			}
+			return short
 		}),
 	}
 
@@ -0,0 +1,11 @@
+package get_user_unavailability
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type UserService interface {
+	GetUnavailability(ctx context.Context, userID string) ([]entity.Unavailability, error)
+}
@@ -0,0 +1,71 @@
+package get_user_unavailability
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	service "github.com/4udiwe/avito-pr-service/internal/service/user"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s UserService
+}
+
+func New(userService UserService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: userService})
+}
+
+type Request struct {
+	UserID string `query:"user_id" validate:"required"`
+}
+
+type Period struct {
+	ID       uuid.UUID `json:"id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+type Response struct {
+	UserID  string   `json:"user_id"`
+	Periods []Period `json:"periods"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	periods, err := h.s.GetUnavailability(ctx.Request().Context(), in.UserID)
+
+	if err != nil {
+		var errResponse dto.ErrorResponse
+
+		if errors.Is(err, service.ErrUserNotFound) {
+			errResponse.Error.Code = dto.NOTFOUND
+			errResponse.Error.Message = "resource not found"
+			return echo.NewHTTPError(http.StatusNotFound, errResponse)
+		}
+
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+
+	response := Response{
+		UserID: in.UserID,
+		Periods: lo.Map(periods, func(e entity.Unavailability, _ int) Period {
+			return Period{
+				ID:       e.ID,
+				StartsAt: e.StartsAt,
+				EndsAt:   e.EndsAt,
+				Reason:   e.Reason,
+			}
+		}),
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,11 @@
+package get_webhook_dead_letters
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type WebhookService interface {
+	GetDeadLetters(ctx context.Context) ([]entity.WebhookDelivery, error)
+}
@@ -0,0 +1,69 @@
+package get_webhook_dead_letters
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s WebhookService
+}
+
+func New(webhookService WebhookService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: webhookService})
+}
+
+type Request struct{}
+
+type Delivery struct {
+	ID        uuid.UUID       `json:"id"`
+	WebhookID uuid.UUID       `json:"webhook_id"`
+	URL       string          `json:"url"`
+	EventID   uuid.UUID       `json:"event_id"`
+	EventType string          `json:"event_type"`
+	Payload   json.RawMessage `json:"payload"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"last_error"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type Response struct {
+	Deliveries []Delivery `json:"deliveries"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	deliveries, err := h.s.GetDeadLetters(ctx.Request().Context())
+
+	if err != nil {
+		var errResponse dto.ErrorResponse
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+
+	response := Response{
+		Deliveries: lo.Map(deliveries, func(e entity.WebhookDelivery, _ int) Delivery {
+			return Delivery{
+				ID:        e.ID,
+				WebhookID: e.SubscriptionID,
+				URL:       e.URL,
+				EventID:   e.EventID,
+				EventType: string(e.EventType),
+				Payload:   e.Payload,
+				Attempts:  e.Attempts,
+				LastError: e.LastError,
+				CreatedAt: e.CreatedAt,
+			}
+		}),
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,11 @@
+package get_webhooks
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type WebhookService interface {
+	GetSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error)
+}
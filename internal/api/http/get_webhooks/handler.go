@@ -0,0 +1,58 @@
+package get_webhooks
+
+import (
+	"net/http"
+	"time"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s WebhookService
+}
+
+func New(webhookService WebhookService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: webhookService})
+}
+
+type Request struct{}
+
+type Webhook struct {
+	ID         uuid.UUID `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type Response struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	subscriptions, err := h.s.GetSubscriptions(ctx.Request().Context())
+
+	if err != nil {
+		var errResponse dto.ErrorResponse
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+
+	response := Response{
+		Webhooks: lo.Map(subscriptions, func(e entity.WebhookSubscription, _ int) Webhook {
+			return Webhook{
+				ID:         e.ID,
+				URL:        e.URL,
+				EventTypes: lo.Map(e.EventTypes, func(t entity.WebhookEventType, _ int) string { return string(t) }),
+				CreatedAt:  e.CreatedAt,
+			}
+		}),
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/pkg/actor"
+	"github.com/4udiwe/avito-pr-service/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// Authenticator resolves bearer token to the caller
+type Authenticator interface {
+	Authenticate(token string) (auth.Principal, error)
+}
+
+// Auth requires "Authorization: Bearer <token>" on every request except skipped paths.
+// Authenticated caller is stored in the request context and becomes the actor of the changes
+func Auth(a Authenticator, skipPaths ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			for _, path := range skipPaths {
+				if c.Path() == path {
+					return next(c)
+				}
+			}
+
+			token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+			if !ok {
+				return unauthorized("missing bearer token")
+			}
+
+			principal, err := a.Authenticate(strings.TrimSpace(token))
+			if err != nil {
+				logrus.Warnf("HTTP %s %s: authentication failed: %v", c.Request().Method, c.Path(), err)
+				return unauthorized(err.Error())
+			}
+
+			ctx := auth.WithPrincipal(c.Request().Context(), principal)
+			ctx = actor.WithActor(ctx, principal.Subject)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// RequireRole lets through only callers with one of the roles. Without authentication
+// there is no caller in the context and nothing to check
+func RequireRole(roles ...auth.Role) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, ok := auth.FromContext(c.Request().Context())
+			if ok && !principal.HasRole(roles...) {
+				logrus.Warnf("HTTP %s %s: role %s of %s is not allowed", c.Request().Method, c.Path(), principal.Role, principal.Subject)
+
+				var errResponse dto.ErrorResponse
+				errResponse.Error.Code = dto.FORBIDDEN
+				errResponse.Error.Message = "not allowed for role " + string(principal.Role)
+				return echo.NewHTTPError(http.StatusForbidden, errResponse)
+			}
+			return next(c)
+		}
+	}
+}
+
+func unauthorized(message string) *echo.HTTPError {
+	var errResponse dto.ErrorResponse
+	errResponse.Error.Code = dto.UNAUTHORIZED
+	errResponse.Error.Message = message
+	return echo.NewHTTPError(http.StatusUnauthorized, errResponse)
+}
@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/service/idempotency"
+	"github.com/4udiwe/avito-pr-service/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// IdempotencyService stores responses of requests sent with an idempotency key
+type IdempotencyService interface {
+	Begin(ctx context.Context, key, scope, requestHash string) (entity.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, key, scope string, token uuid.UUID, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, key, scope string, token uuid.UUID) error
+}
+
+// Idempotency makes POST requests with the Idempotency-Key header safe to retry: the first response
+// is stored and replayed for duplicates, the same key with a different body is rejected.
+// Keys are scoped by route and, with authentication, by caller. Body is read into memory to be hashed,
+// so requests with a key and a body larger than maxBodyBytes are rejected
+func Idempotency(s IdempotencyService, maxBodyBytes int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderIdempotencyKey)
+			if c.Request().Method != http.MethodPost || key == "" {
+				return next(c)
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body, maxBodyBytes))
+			if err != nil {
+				return bodyError(err)
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := c.Request().Context()
+			scope := idempotencyScope(c)
+
+			record, replay, err := s.Begin(ctx, key, scope, requestHash(c, body))
+			if err != nil {
+				return idempotencyError(err)
+			}
+			if replay {
+				c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+				return c.Blob(record.StatusCode, record.ContentType, record.Body)
+			}
+
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+
+			if err := next(c); err != nil {
+				// Error response has to be written now to be stored
+				c.Error(err)
+			}
+
+			// Response is already sent, failing to store it only means that a retry is executed again
+			if c.Response().Status >= http.StatusInternalServerError {
+				_ = s.Release(context.WithoutCancel(ctx), key, scope, record.Token)
+				return nil
+			}
+			if err := s.Complete(
+				context.WithoutCancel(ctx),
+				key,
+				scope,
+				record.Token,
+				c.Response().Status,
+				c.Response().Header().Get(echo.HeaderContentType),
+				recorder.body.Bytes(),
+			); err != nil {
+				logrus.Warnf("HTTP %s %s: response for idempotency key %s not stored: %v", c.Request().Method, c.Path(), key, err)
+				_ = s.Release(context.WithoutCancel(ctx), key, scope, record.Token)
+			}
+			return nil
+		}
+	}
+}
+
+func idempotencyScope(c echo.Context) string {
+	scope := c.Request().Method + " " + c.Path()
+	if principal, ok := auth.FromContext(c.Request().Context()); ok {
+		scope += " " + principal.Subject
+	}
+	return scope
+}
+
+// Formatting of JSON body doesn't make a request different
+func requestHash(c echo.Context, body []byte) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, body); err == nil {
+		body = compact.Bytes()
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(c.Request().Method + " " + c.Request().URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func idempotencyError(err error) error {
+	var errResponse dto.ErrorResponse
+	errResponse.Error.Message = err.Error()
+
+	switch {
+	case errors.Is(err, idempotency.ErrInvalidKey):
+		errResponse.Error.Code = dto.INVALIDINPUT
+		return echo.NewHTTPError(http.StatusBadRequest, errResponse)
+	case errors.Is(err, idempotency.ErrKeyReused):
+		errResponse.Error.Code = dto.IDEMPOTENCYKEYREUSED
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, errResponse)
+	case errors.Is(err, idempotency.ErrRequestInProgress):
+		errResponse.Error.Code = dto.IDEMPOTENCYKEYINUSE
+		return echo.NewHTTPError(http.StatusConflict, errResponse)
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+}
+
+func bodyError(err error) error {
+	var errResponse dto.ErrorResponse
+	errResponse.Error.Code = dto.INVALIDINPUT
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		errResponse.Error.Message = fmt.Sprintf("request body is larger than %d bytes", tooLarge.Limit)
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, errResponse)
+	}
+
+	errResponse.Error.Message = "cannot read request body"
+	return echo.NewHTTPError(http.StatusBadRequest, errResponse)
+}
+
+// Copies written response body so that it can be stored
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,289 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/api/http/middleware"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/4udiwe/avito-pr-service/internal/service/idempotency"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// In-memory idempotency_key table. With takeOver in-progress records are treated as older than lock timeout
+type memoryRepo struct {
+	mu       sync.Mutex
+	records  map[string]entity.IdempotencyRecord
+	takeOver bool
+}
+
+func newMemoryRepo() *memoryRepo {
+	return &memoryRepo{records: map[string]entity.IdempotencyRecord{}}
+}
+
+func (r *memoryRepo) Reserve(_ context.Context, key, scope, requestHash string, _, _ time.Duration) (uuid.UUID, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if record, ok := r.records[scope+key]; ok && (record.Completed() || !r.takeOver) {
+		return uuid.Nil, false, nil
+	}
+	token := uuid.New()
+	r.records[scope+key] = entity.IdempotencyRecord{Key: key, Scope: scope, RequestHash: requestHash, Token: token}
+	return token, true, nil
+}
+
+func (r *memoryRepo) Get(_ context.Context, key, scope string) (entity.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	record, ok := r.records[scope+key]
+	if !ok {
+		return entity.IdempotencyRecord{}, repository.ErrIdempotencyKeyNotFound
+	}
+	return record, nil
+}
+
+func (r *memoryRepo) Complete(_ context.Context, key, scope string, token uuid.UUID, statusCode int, contentType string, body []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	record, ok := r.records[scope+key]
+	if !ok || record.Token != token {
+		return repository.ErrIdempotencyKeyNotFound
+	}
+	record.StatusCode, record.ContentType, record.Body = statusCode, contentType, body
+	r.records[scope+key] = record
+	return nil
+}
+
+func (r *memoryRepo) Delete(_ context.Context, key, scope string, token uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.records[scope+key].Token == token {
+		delete(r.records, scope+key)
+	}
+	return nil
+}
+
+func (r *memoryRepo) DeleteExpired(context.Context) (int, error) {
+	return 0, nil
+}
+
+type request struct {
+	key  string
+	body string
+}
+
+type response struct {
+	status   int
+	body     string
+	code     dto.ErrorResponseErrorCode
+	replayed bool
+}
+
+func TestIdempotency(t *testing.T) {
+	tests := []struct {
+		name            string
+		handlerStatuses []int
+		requests        []request
+		expected        []response
+		expectedCalls   int
+	}{
+		{
+			name:            "retry is replayed",
+			handlerStatuses: []int{http.StatusCreated},
+			requests: []request{
+				{key: "k1", body: `{"team_name":"backend"}`},
+				{key: "k1", body: `{ "team_name": "backend" }`},
+			},
+			expected: []response{
+				{status: http.StatusCreated, body: `{"call":1}`},
+				{status: http.StatusCreated, body: `{"call":1}`, replayed: true},
+			},
+			expectedCalls: 1,
+		},
+		{
+			name:            "same key with a different body",
+			handlerStatuses: []int{http.StatusCreated},
+			requests: []request{
+				{key: "k1", body: `{"team_name":"backend"}`},
+				{key: "k1", body: `{"team_name":"frontend"}`},
+			},
+			expected: []response{
+				{status: http.StatusCreated, body: `{"call":1}`},
+				{status: http.StatusUnprocessableEntity, code: dto.IDEMPOTENCYKEYREUSED},
+			},
+			expectedCalls: 1,
+		},
+		{
+			name:            "server error releases the key",
+			handlerStatuses: []int{http.StatusInternalServerError, http.StatusCreated},
+			requests: []request{
+				{key: "k1", body: `{"team_name":"backend"}`},
+				{key: "k1", body: `{"team_name":"backend"}`},
+				{key: "k1", body: `{"team_name":"backend"}`},
+			},
+			expected: []response{
+				{status: http.StatusInternalServerError, body: `{"call":1}`},
+				{status: http.StatusCreated, body: `{"call":2}`},
+				{status: http.StatusCreated, body: `{"call":2}`, replayed: true},
+			},
+			expectedCalls: 2,
+		},
+		{
+			name:            "client error is replayed",
+			handlerStatuses: []int{http.StatusBadRequest},
+			requests: []request{
+				{key: "k1", body: `{}`},
+				{key: "k1", body: `{}`},
+			},
+			expected: []response{
+				{status: http.StatusBadRequest, body: `{"call":1}`},
+				{status: http.StatusBadRequest, body: `{"call":1}`, replayed: true},
+			},
+			expectedCalls: 1,
+		},
+		{
+			name:            "request without key is not stored",
+			handlerStatuses: []int{http.StatusCreated, http.StatusCreated},
+			requests: []request{
+				{body: `{"team_name":"backend"}`},
+				{body: `{"team_name":"backend"}`},
+			},
+			expected: []response{
+				{status: http.StatusCreated, body: `{"call":1}`},
+				{status: http.StatusCreated, body: `{"call":2}`},
+			},
+			expectedCalls: 2,
+		},
+		{
+			name: "too long key",
+			requests: []request{
+				{key: strings.Repeat("k", 256), body: `{}`},
+			},
+			expected: []response{
+				{status: http.StatusBadRequest, code: dto.INVALIDINPUT},
+			},
+			expectedCalls: 0,
+		},
+		{
+			name: "too large body",
+			requests: []request{
+				{key: "k1", body: `{"team_name":"` + strings.Repeat("a", 64) + `"}`},
+			},
+			expected: []response{
+				{status: http.StatusRequestEntityTooLarge, code: dto.INVALIDINPUT},
+			},
+			expectedCalls: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			e := echo.New()
+			e.Use(middleware.Idempotency(idempotency.New(newMemoryRepo(), time.Hour, time.Minute), 64))
+			e.POST("/team/add", func(c echo.Context) error {
+				status := tt.handlerStatuses[calls]
+				calls++
+				return c.JSON(status, map[string]int{"call": calls})
+			})
+
+			for i, req := range tt.requests {
+				w := serve(e, req)
+
+				expected := tt.expected[i]
+				assert.Equal(t, expected.status, w.Code, "request %d", i)
+				assert.Equal(t, expected.replayed, w.Header().Get(middleware.HeaderIdempotentReplayed) == "true", "request %d", i)
+				if expected.code != "" {
+					var errResponse dto.ErrorResponse
+					require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResponse))
+					assert.Equal(t, expected.code, errResponse.Error.Code, "request %d", i)
+					assert.NotEmpty(t, errResponse.Error.Message, "request %d", i)
+				} else {
+					assert.JSONEq(t, expected.body, w.Body.String(), "request %d", i)
+				}
+			}
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestIdempotency_RequestInProgress(t *testing.T) {
+	req := request{key: "k1", body: `{"team_name":"backend"}`}
+
+	e := echo.New()
+	e.Use(middleware.Idempotency(idempotency.New(newMemoryRepo(), time.Hour, time.Minute), 1024))
+
+	// Retry arrives while the first request is still being handled
+	var duplicate *httptest.ResponseRecorder
+	e.POST("/team/add", func(c echo.Context) error {
+		duplicate = serve(e, req)
+		return c.JSON(http.StatusCreated, map[string]string{"team_name": "backend"})
+	})
+
+	first := serve(e, req)
+
+	assert.Equal(t, http.StatusCreated, first.Code)
+	require.NotNil(t, duplicate)
+	assert.Equal(t, http.StatusConflict, duplicate.Code)
+
+	var errResponse dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(duplicate.Body.Bytes(), &errResponse))
+	assert.Equal(t, dto.IDEMPOTENCYKEYINUSE, errResponse.Error.Code)
+}
+
+func TestIdempotency_ReservationTakenOver(t *testing.T) {
+	req := request{key: "k1", body: `{"team_name":"backend"}`}
+
+	repo := newMemoryRepo()
+	repo.takeOver = true
+
+	e := echo.New()
+	e.Use(middleware.Idempotency(idempotency.New(repo, time.Hour, time.Minute), 1024))
+
+	// Retry arrives after the lock timeout while the first request is still being handled
+	calls := 0
+	var retry *httptest.ResponseRecorder
+	e.POST("/team/add", func(c echo.Context) error {
+		calls++
+		call := calls
+		if call == 1 {
+			retry = serve(e, req)
+		}
+		return c.JSON(http.StatusCreated, map[string]int{"call": call})
+	})
+
+	first := serve(e, req)
+	repo.takeOver = false
+	replayed := serve(e, req)
+
+	assert.Equal(t, 2, calls)
+	assert.JSONEq(t, `{"call":1}`, first.Body.String())
+	require.NotNil(t, retry)
+	assert.JSONEq(t, `{"call":2}`, retry.Body.String())
+
+	// The first request finished last, but it no longer held the key
+	assert.Equal(t, "true", replayed.Header().Get(middleware.HeaderIdempotentReplayed))
+	assert.JSONEq(t, `{"call":2}`, replayed.Body.String())
+}
+
+func serve(e *echo.Echo, req request) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/team/add", strings.NewReader(req.body))
+	r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if req.key != "" {
+		r.Header.Set(middleware.HeaderIdempotencyKey, req.key)
+	}
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, r)
+	return w
+}
@@ -0,0 +1,11 @@
+package patch_pr
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type PRService interface {
+	UpdatePR(ctx context.Context, prID string, title, description *string) (entity.PullRequest, error)
+}
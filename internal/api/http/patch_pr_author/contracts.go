@@ -0,0 +1,11 @@
+package patch_pr_author
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type PRService interface {
+	TransferAuthor(ctx context.Context, prID, newAuthorID string) (entity.PullRequest, error)
+}
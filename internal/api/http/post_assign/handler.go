@@ -35,7 +35,17 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 			errResponse.Error.Message = "resource not found"
 			return echo.NewHTTPError(http.StatusNotFound, errResponse)
 		}
-		if errors.Is(err, service.ErrPRAlreadyHas2Reviewers) {
+		if errors.Is(err, service.ErrPRMerged) {
+			errResponse.Error.Code = dto.PRMERGED
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
+		}
+		if errors.Is(err, service.ErrInvalidPRState) {
+			errResponse.Error.Code = dto.INVALIDSTATE
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
+		}
+		if errors.Is(err, service.ErrPRHasEnoughReviewers) {
 			errResponse.Error.Code = dto.NOTASSIGNED
 			errResponse.Error.Message = err.Error()
 			return echo.NewHTTPError(http.StatusNotFound, errResponse)
@@ -0,0 +1,11 @@
+package post_code_owner
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type CodeOwnerService interface {
+	AddCodeOwner(ctx context.Context, pattern, userID, teamName string) (entity.CodeOwner, error)
+}
@@ -0,0 +1,65 @@
+package post_code_owner
+
+import (
+	"errors"
+	"net/http"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	service "github.com/4udiwe/avito-pr-service/internal/service/codeowner"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s CodeOwnerService
+}
+
+func New(codeOwnerService CodeOwnerService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: codeOwnerService})
+}
+
+// Exactly one of user_id and team_name must be set
+type Request struct {
+	Pattern  string `json:"pattern" validate:"required"`
+	UserID   string `json:"user_id" validate:"required_without=TeamName,excluded_with=TeamName"`
+	TeamName string `json:"team_name" validate:"required_without=UserID,excluded_with=UserID"`
+}
+
+type Response struct {
+	ID       uuid.UUID `json:"id"`
+	Pattern  string    `json:"pattern"`
+	UserID   *string   `json:"user_id,omitempty"`
+	TeamName *string   `json:"team_name,omitempty"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	owner, err := h.s.AddCodeOwner(ctx.Request().Context(), in.Pattern, in.UserID, in.TeamName)
+
+	if err != nil {
+		var errResponse dto.ErrorResponse
+
+		if errors.Is(err, service.ErrUserNotFound) || errors.Is(err, service.ErrTeamNotFound) {
+			errResponse.Error.Code = dto.NOTFOUND
+			errResponse.Error.Message = "resource not found"
+			return echo.NewHTTPError(http.StatusNotFound, errResponse)
+		}
+		if errors.Is(err, service.ErrInvalidCodeOwner) {
+			errResponse.Error.Code = dto.INVALIDINPUT
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusBadRequest, errResponse)
+		}
+
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+
+	return ctx.JSON(http.StatusCreated, Response{
+		ID:       owner.ID,
+		Pattern:  owner.Pattern,
+		UserID:   lo.EmptyableToPtr(owner.UserID),
+		TeamName: lo.EmptyableToPtr(owner.TeamName),
+	})
+}
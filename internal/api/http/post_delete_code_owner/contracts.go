@@ -0,0 +1,11 @@
+package post_delete_code_owner
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type CodeOwnerService interface {
+	DeleteCodeOwner(ctx context.Context, ID uuid.UUID) error
+}
@@ -0,0 +1,11 @@
+package post_delete_user_unavailability
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type UserService interface {
+	DeleteUnavailability(ctx context.Context, ID uuid.UUID) error
+}
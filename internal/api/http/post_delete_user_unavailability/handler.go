@@ -0,0 +1,44 @@
+package post_delete_user_unavailability
+
+import (
+	"errors"
+	"net/http"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	service "github.com/4udiwe/avito-pr-service/internal/service/user"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s UserService
+}
+
+func New(userService UserService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: userService})
+}
+
+type Request struct {
+	ID uuid.UUID `json:"id" validate:"required"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	err := h.s.DeleteUnavailability(ctx.Request().Context(), in.ID)
+
+	if err != nil {
+		var errResponse dto.ErrorResponse
+
+		if errors.Is(err, service.ErrUnavailabilityNotFound) {
+			errResponse.Error.Code = dto.NOTFOUND
+			errResponse.Error.Message = "resource not found"
+			return echo.NewHTTPError(http.StatusNotFound, errResponse)
+		}
+
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
@@ -0,0 +1,11 @@
+package post_delete_webhook
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type WebhookService interface {
+	Unsubscribe(ctx context.Context, ID uuid.UUID) error
+}
@@ -7,5 +7,5 @@ import (
 )
 
 type PRService interface {
-	MergePR(ctx context.Context, prID string) (entity.PullRequest, error)
+	MergePR(ctx context.Context, prID string, force bool) (entity.PullRequest, error)
 }
@@ -7,8 +7,10 @@ import (
 	api "github.com/4udiwe/avito-pr-service/internal/api/http"
 	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
 	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
 	service "github.com/4udiwe/avito-pr-service/internal/service/pr"
 	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
 )
 
 type handler struct {
@@ -26,7 +28,7 @@ type Response struct {
 }
 
 func (h *handler) Handle(ctx echo.Context, in Request) error {
-	PR, err := h.s.MergePR(ctx.Request().Context(), in.PullRequestId)
+	PR, err := h.s.MergePR(ctx.Request().Context(), in.PullRequestId, lo.FromPtr(in.Force))
 
 	if err != nil {
 		var errResponse dto.ErrorResponse
@@ -36,6 +38,30 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 			errResponse.Error.Message = "resource not found"
 			return echo.NewHTTPError(http.StatusNotFound, errResponse)
 		}
+		if errors.Is(err, service.ErrMergeForbidden) || errors.Is(err, service.ErrForceMergeForbidden) {
+			errResponse.Error.Code = dto.FORBIDDEN
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusForbidden, errResponse)
+		}
+		var blockedErr *service.MergeBlockedError
+		if errors.As(err, &blockedErr) {
+			errResponse.Error.Code = dto.MERGEBLOCKED
+			errResponse.Error.Message = service.ErrMergeBlocked.Error()
+			errResponse.Error.UnmetConditions = lo.ToPtr(lo.Map(blockedErr.Unmet, func(c entity.MergeCondition, _ int) dto.ErrorResponseErrorUnmetConditions {
+				return dto.ErrorResponseErrorUnmetConditions(c)
+			}))
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
+		}
+		if errors.Is(err, service.ErrPRMerged) {
+			errResponse.Error.Code = dto.PRMERGED
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
+		}
+		if errors.Is(err, service.ErrInvalidPRState) {
+			errResponse.Error.Code = dto.INVALIDSTATE
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
+		}
 
 		errResponse.Error.Message = err.Error()
 		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
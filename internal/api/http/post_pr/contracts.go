@@ -7,5 +7,5 @@ import (
 )
 
 type PRService interface {
-	CreatePR(ctx context.Context, pullRequestID, title, authorID string) (entity.PullRequest, error)
+	CreatePR(ctx context.Context, pullRequestID, title, authorID string, changedFiles, labels []string, draft bool) (entity.PullRequest, error)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/4udiwe/avito-pr-service/internal/dto"
 	service "github.com/4udiwe/avito-pr-service/internal/service/pr"
 	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
 )
 
 type handler struct {
@@ -26,7 +27,15 @@ type Response struct {
 }
 
 func (h *handler) Handle(ctx echo.Context, in Request) error {
-	PR, err := h.s.CreatePR(ctx.Request().Context(), in.PullRequestId, in.PullRequestName, in.AuthorId)
+	PR, err := h.s.CreatePR(
+		ctx.Request().Context(),
+		in.PullRequestId,
+		in.PullRequestName,
+		in.AuthorId,
+		lo.FromPtr(in.ChangedFiles),
+		lo.FromPtr(in.Labels),
+		lo.FromPtr(in.Draft),
+	)
 
 	if err != nil {
 		var errResponse dto.ErrorResponse
@@ -0,0 +1,11 @@
+package post_pr_bulk
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type PRService interface {
+	ImportPRs(ctx context.Context, items []entity.PRImport) []entity.PRImportResult
+}
@@ -0,0 +1,213 @@
+package post_pr_bulk
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"unicode"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	service "github.com/4udiwe/avito-pr-service/internal/service/pr"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	StatusCreated = "created"
+	StatusFailed  = "failed"
+)
+
+type handler struct {
+	s            PRService
+	maxItems     int
+	maxBodyBytes int64
+}
+
+// Body is parsed by the handler itself: it is a JSON array or NDJSON stream, which can't be bound to a struct
+func New(PRService PRService, maxItems int, maxBodyBytes int64) api.Handler {
+	return &handler{s: PRService, maxItems: maxItems, maxBodyBytes: maxBodyBytes}
+}
+
+type Item dto.PostPullRequestCreateJSONRequestBody
+
+type ItemError struct {
+	Code    dto.ErrorResponseErrorCode `json:"code,omitempty"`
+	Message string                     `json:"message"`
+}
+
+type ItemResult struct {
+	Index         int              `json:"index"`
+	PullRequestID string           `json:"pull_request_id"`
+	Status        string           `json:"status"`
+	PR            *dto.PullRequest `json:"pr,omitempty"`
+	Error         *ItemError       `json:"error,omitempty"`
+}
+
+type Response struct {
+	Created int          `json:"created"`
+	Failed  int          `json:"failed"`
+	Results []ItemResult `json:"results"`
+}
+
+func (h *handler) Handle(ctx echo.Context) error {
+	logrus.Infof("HTTP %s %s from %s", ctx.Request().Method, ctx.Path(), ctx.Request().RemoteAddr)
+
+	items, err := h.decode(http.MaxBytesReader(ctx.Response(), ctx.Request().Body, h.maxBodyBytes))
+	if err != nil {
+		logrus.Errorf("Failed to decode bulk request: %v", err)
+
+		var errResponse dto.ErrorResponse
+		errResponse.Error.Code = dto.INVALIDINPUT
+		errResponse.Error.Message = err.Error()
+
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			errResponse.Error.Message = fmt.Sprintf("request body is larger than %d bytes", tooLarge.Limit)
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, errResponse)
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, errResponse)
+	}
+
+	decorator.SetActor(ctx)
+
+	response := Response{Results: make([]ItemResult, len(items))}
+
+	// Invalid items are reported without reaching the service
+	imports := make([]entity.PRImport, 0, len(items))
+	indexes := make([]int, 0, len(items))
+	for i, item := range items {
+		response.Results[i] = ItemResult{Index: i, PullRequestID: item.PullRequestId}
+
+		if err := validate(item); err != nil {
+			response.Results[i].Status = StatusFailed
+			response.Results[i].Error = &ItemError{Message: err.Error()}
+			continue
+		}
+
+		imports = append(imports, entity.PRImport{
+			ID:           item.PullRequestId,
+			Title:        item.PullRequestName,
+			AuthorID:     item.AuthorId,
+			ChangedFiles: lo.FromPtr(item.ChangedFiles),
+			Labels:       lo.FromPtr(item.Labels),
+			Draft:        lo.FromPtr(item.Draft),
+		})
+		indexes = append(indexes, i)
+	}
+
+	for _, result := range h.s.ImportPRs(ctx.Request().Context(), imports) {
+		itemResult := &response.Results[indexes[result.Index]]
+
+		if result.Err != nil {
+			itemResult.Status = StatusFailed
+			itemResult.Error = itemError(result.Err)
+			continue
+		}
+
+		itemResult.Status = StatusCreated
+		itemResult.PR = &dto.PullRequest{}
+		itemResult.PR.FillFromEntity(result.PR)
+	}
+
+	for _, result := range response.Results {
+		if result.Status == StatusCreated {
+			response.Created++
+		} else {
+			response.Failed++
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// Accepts a JSON array of items or items one after another (NDJSON). Items are decoded one by one,
+// so a request with too many items is rejected without reading the rest of it
+func (h *handler) decode(body io.Reader) ([]Item, error) {
+	reader := bufio.NewReader(body)
+	first, err := firstNonSpace(reader)
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("no PRs to import")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read body: %w", err)
+	}
+
+	decoder := json.NewDecoder(reader)
+	isArray := first == '['
+	if isArray {
+		// Opening bracket
+		if _, err := decoder.Token(); err != nil {
+			return nil, fmt.Errorf("invalid JSON array: %w", err)
+		}
+	}
+
+	var items []Item
+	for decoder.More() {
+		if len(items) == h.maxItems {
+			return nil, fmt.Errorf("too many PRs, at most %d per request", h.maxItems)
+		}
+
+		var item Item
+		if err := decoder.Decode(&item); err != nil {
+			return nil, fmt.Errorf("invalid item %d: %w", len(items), err)
+		}
+		items = append(items, item)
+	}
+
+	if isArray {
+		if _, err := decoder.Token(); err != nil {
+			return nil, fmt.Errorf("invalid JSON array: %w", err)
+		}
+		if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+			return nil, errors.New("invalid JSON array: unexpected data after the array")
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, errors.New("no PRs to import")
+	}
+	return items, nil
+}
+
+// Returns the first non-whitespace byte without consuming it
+func firstNonSpace(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if !unicode.IsSpace(rune(b)) {
+			return b, reader.UnreadByte()
+		}
+	}
+}
+
+func validate(item Item) error {
+	switch {
+	case item.PullRequestId == "":
+		return errors.New("pull_request_id is required")
+	case item.PullRequestName == "":
+		return errors.New("pull_request_name is required")
+	case item.AuthorId == "":
+		return errors.New("author_id is required")
+	}
+	return nil
+}
+
+func itemError(err error) *ItemError {
+	switch {
+	case errors.Is(err, service.ErrAuthorNotFound) || errors.Is(err, service.ErrReviewerNotFound):
+		return &ItemError{Code: dto.NOTFOUND, Message: "resource not found"}
+	case errors.Is(err, service.ErrPRAlreadyExists):
+		return &ItemError{Code: dto.PREXISTS, Message: "PR id already exists"}
+	default:
+		return &ItemError{Message: err.Error()}
+	}
+}
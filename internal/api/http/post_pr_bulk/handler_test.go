@@ -0,0 +1,129 @@
+package post_pr_bulk_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_pr_bulk"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePRService struct {
+	imported []entity.PRImport
+}
+
+func (s *fakePRService) ImportPRs(_ context.Context, items []entity.PRImport) []entity.PRImportResult {
+	s.imported = items
+	results := make([]entity.PRImportResult, len(items))
+	for i, item := range items {
+		results[i] = entity.PRImportResult{Index: i, PR: entity.PullRequest{ID: item.ID}}
+	}
+	return results
+}
+
+func TestHandler_Decode(t *testing.T) {
+	pr := func(id string) string {
+		return `{"pull_request_id":"` + id + `","pull_request_name":"title","author_id":"u1"}`
+	}
+
+	tests := []struct {
+		name             string
+		body             string
+		expectedStatus   int
+		expectedImported []string
+	}{
+		{
+			name:             "JSON array",
+			body:             " [" + pr("pr1") + ",\n" + pr("pr2") + "] ",
+			expectedStatus:   http.StatusOK,
+			expectedImported: []string{"pr1", "pr2"},
+		},
+		{
+			name:             "NDJSON",
+			body:             pr("pr1") + "\n" + pr("pr2") + "\n",
+			expectedStatus:   http.StatusOK,
+			expectedImported: []string{"pr1", "pr2"},
+		},
+		{
+			name:             "JSON array with max items",
+			body:             "[" + pr("pr1") + "," + pr("pr2") + "," + pr("pr3") + "]",
+			expectedStatus:   http.StatusOK,
+			expectedImported: []string{"pr1", "pr2", "pr3"},
+		},
+		{
+			name:           "too many items in JSON array",
+			body:           "[" + pr("pr1") + "," + pr("pr2") + "," + pr("pr3") + "," + pr("pr4") + "]",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "too many items in NDJSON",
+			body:           pr("pr1") + "\n" + pr("pr2") + "\n" + pr("pr3") + "\n" + pr("pr4") + "\n",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty body",
+			body:           "  \n",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty array",
+			body:           "[]",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unclosed array",
+			body:           "[" + pr("pr1"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "data after array",
+			body:           "[" + pr("pr1") + "]" + pr("pr2"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid item",
+			body:           pr("pr1") + "\n{\"pull_request_id\":",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "too large body",
+			body:           "[" + pr(strings.Repeat("a", 1024)) + "]",
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &fakePRService{}
+			e := echo.New()
+			e.POST("/pullRequest/bulk", post_pr_bulk.New(s, 3, 1024).Handle)
+
+			r := httptest.NewRequest(http.MethodPost, "/pullRequest/bulk", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				var errResponse dto.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResponse))
+				assert.Equal(t, dto.INVALIDINPUT, errResponse.Error.Code)
+				assert.Empty(t, s.imported)
+				return
+			}
+
+			imported := make([]string, len(s.imported))
+			for i, item := range s.imported {
+				imported[i] = item.ID
+			}
+			assert.Equal(t, tt.expectedImported, imported)
+		})
+	}
+}
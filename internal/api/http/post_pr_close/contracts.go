@@ -0,0 +1,11 @@
+package post_pr_close
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type PRService interface {
+	ClosePR(ctx context.Context, prID string) (entity.PullRequest, error)
+}
@@ -0,0 +1,11 @@
+package post_pr_reopen
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type PRService interface {
+	ReopenPR(ctx context.Context, prID string, changedFiles []string) (entity.PullRequest, error)
+}
@@ -0,0 +1,61 @@
+package post_pr_reopen
+
+import (
+	"errors"
+	"net/http"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	service "github.com/4udiwe/avito-pr-service/internal/service/pr"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s PRService
+}
+
+func New(PRService PRService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: PRService})
+}
+
+type Request struct {
+	PullRequestID string   `json:"pull_request_id" validate:"required"`
+	ChangedFiles  []string `json:"changed_files"`
+}
+
+type Response struct {
+	PR dto.PullRequest `json:"pr"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	PR, err := h.s.ReopenPR(ctx.Request().Context(), in.PullRequestID, in.ChangedFiles)
+
+	if err != nil {
+		var errResponse dto.ErrorResponse
+
+		if errors.Is(err, service.ErrPRNotFound) {
+			errResponse.Error.Code = dto.NOTFOUND
+			errResponse.Error.Message = "resource not found"
+			return echo.NewHTTPError(http.StatusNotFound, errResponse)
+		}
+		if errors.Is(err, service.ErrPRMerged) {
+			errResponse.Error.Code = dto.PRMERGED
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
+		}
+		if errors.Is(err, service.ErrInvalidPRState) {
+			errResponse.Error.Code = dto.INVALIDSTATE
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
+		}
+
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+
+	response := Response{}
+	response.PR.FillFromEntity(PR)
+
+	return ctx.JSON(http.StatusOK, response)
+}
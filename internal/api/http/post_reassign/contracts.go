@@ -7,5 +7,5 @@ import (
 )
 
 type PRService interface {
-	ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (entity.PullRequest, string, error)
+	ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) (entity.PullRequest, string, error)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/4udiwe/avito-pr-service/internal/dto"
 	service "github.com/4udiwe/avito-pr-service/internal/service/pr"
 	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
 )
 
 type handler struct {
@@ -27,7 +28,7 @@ type Response struct {
 }
 
 func (h *handler) Handle(ctx echo.Context, in Request) error {
-	PR, newReviewerID, err := h.s.ReassignReviewer(ctx.Request().Context(), in.PullRequestId, in.OldUserId)
+	PR, newReviewerID, err := h.s.ReassignReviewer(ctx.Request().Context(), in.PullRequestId, in.OldUserId, lo.FromPtr(in.NewUserId))
 
 	if err != nil {
 		var errResponse dto.ErrorResponse
@@ -37,10 +38,33 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 			errResponse.Error.Message = "resource not found"
 			return echo.NewHTTPError(http.StatusNotFound, errResponse)
 		}
-		if errors.Is(err, service.ErrCannotReassignReviewerForMergedPR) {
+		if errors.Is(err, service.ErrReassignForbidden) {
+			errResponse.Error.Code = dto.FORBIDDEN
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusForbidden, errResponse)
+		}
+		if errors.Is(err, service.ErrPRMerged) {
 			errResponse.Error.Code = dto.PRMERGED
-			errResponse.Error.Message = "cannot reassign on merged PR"
-			return echo.NewHTTPError(http.StatusNotFound, errResponse)
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
+		}
+		if errors.Is(err, service.ErrInvalidPRState) {
+			errResponse.Error.Code = dto.INVALIDSTATE
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
+		}
+		if errors.Is(err, service.ErrReviewerNotAssigned) {
+			errResponse.Error.Code = dto.NOTASSIGNED
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
+		}
+		if errors.Is(err, service.ErrNoMoreReviewersToReassign) ||
+			errors.Is(err, service.ErrCandidateIsAuthor) ||
+			errors.Is(err, service.ErrCandidateInactive) ||
+			errors.Is(err, service.ErrReviewerAlreadyAssigned) {
+			errResponse.Error.Code = dto.NOCANDIDATE
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
 		}
 
 		errResponse.Error.Message = err.Error()
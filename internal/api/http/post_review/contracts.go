@@ -0,0 +1,11 @@
+package post_review
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type PRService interface {
+	SubmitReview(ctx context.Context, prID, reviewerID string, state entity.ReviewState, comment string) (entity.Review, error)
+}
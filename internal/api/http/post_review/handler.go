@@ -0,0 +1,85 @@
+package post_review
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	service "github.com/4udiwe/avito-pr-service/internal/service/pr"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s PRService
+}
+
+func New(PRService PRService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: PRService})
+}
+
+type Request struct {
+	PullRequestID string `json:"pull_request_id" validate:"required"`
+	ReviewerID    string `json:"reviewer_id" validate:"required"`
+	State         string `json:"state" validate:"required,oneof=APPROVED CHANGES_REQUESTED COMMENTED"`
+	Comment       string `json:"comment"`
+}
+
+type Response struct {
+	ID            uuid.UUID `json:"id"`
+	PullRequestID string    `json:"pull_request_id"`
+	ReviewerID    string    `json:"reviewer_id"`
+	State         string    `json:"state"`
+	Comment       string    `json:"comment"`
+	SubmittedAt   time.Time `json:"submitted_at"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	review, err := h.s.SubmitReview(ctx.Request().Context(), in.PullRequestID, in.ReviewerID, entity.ReviewState(in.State), in.Comment)
+
+	if err != nil {
+		var errResponse dto.ErrorResponse
+
+		if errors.Is(err, service.ErrPRNotFound) {
+			errResponse.Error.Code = dto.NOTFOUND
+			errResponse.Error.Message = "resource not found"
+			return echo.NewHTTPError(http.StatusNotFound, errResponse)
+		}
+		if errors.Is(err, service.ErrPRMerged) {
+			errResponse.Error.Code = dto.PRMERGED
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
+		}
+		if errors.Is(err, service.ErrInvalidPRState) {
+			errResponse.Error.Code = dto.INVALIDSTATE
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
+		}
+		if errors.Is(err, service.ErrReviewerNotAssigned) {
+			errResponse.Error.Code = dto.NOTASSIGNED
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
+		}
+		if errors.Is(err, service.ErrInvalidReviewState) {
+			errResponse.Error.Code = dto.INVALIDINPUT
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusBadRequest, errResponse)
+		}
+
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+
+	return ctx.JSON(http.StatusCreated, Response{
+		ID:            review.ID,
+		PullRequestID: review.PRID,
+		ReviewerID:    review.ReviewerID,
+		State:         string(review.State),
+		Comment:       review.Comment,
+		SubmittedAt:   review.CreatedAt,
+	})
+}
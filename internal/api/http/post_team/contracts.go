@@ -7,5 +7,6 @@ import (
 )
 
 type TeamService interface {
-	CreateTeamWithUsers(ctx context.Context, teamName string, users []entity.User) (entity.Team, error)
+	CreateTeamWithUsers(ctx context.Context, teamName string, requiredReviewers, reviewSLAHours int, users []entity.User) (entity.Team, bool, error)
+	UpsertTeamWithUsers(ctx context.Context, teamName string, requiredReviewers, reviewSLAHours int, users []entity.User, reassignReviews bool) (entity.TeamUpsert, error)
 }
@@ -3,6 +3,7 @@ package post_team
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	api "github.com/4udiwe/avito-pr-service/internal/api/http"
 	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
@@ -23,6 +24,25 @@ func New(teamService TeamService) api.Handler {
 
 type Request dto.PostTeamAddJSONRequestBody
 
+type Reassignment struct {
+	PullRequestID string `json:"pull_request_id"`
+	NewReviewerID string `json:"new_reviewer_id"`
+}
+
+type MemberResult struct {
+	UserID            string         `json:"user_id"`
+	Status            string         `json:"status"`
+	PreviousTeamName  string         `json:"previous_team_name,omitempty"`
+	ReassignedReviews []Reassignment `json:"reassigned_reviews,omitempty"`
+	KeptReviews       []string       `json:"kept_reviews,omitempty"`
+}
+
+// Response of the upsert mode
+type UpsertResponse struct {
+	Team  dto.Team       `json:"team"`
+	Users []MemberResult `json:"users"`
+}
+
 func (h *handler) Handle(ctx echo.Context, in Request) error {
 	users := lo.Map(in.Members, func(m dto.TeamMember, _ int) entity.User {
 		return entity.User{
@@ -32,23 +52,115 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 		}
 	})
 
-	team, err := h.s.CreateTeamWithUsers(ctx.Request().Context(), in.TeamName, users)
+	requiredReviewers := entity.DefaultRequiredReviewers
+	if in.RequiredReviewers != nil {
+		requiredReviewers = *in.RequiredReviewers
+	}
+
+	reviewSLAHours := entity.DefaultReviewSLAHours
+	if in.ReviewSlaHours != nil {
+		reviewSLAHours = *in.ReviewSlaHours
+	}
 
+	// Echo binds query params of GET and DELETE requests only
+	upsert, err := boolParam(ctx, "upsert")
 	if err != nil {
-		var errResponse dto.ErrorResponse
+		return err
+	}
 
-		if errors.Is(err, service.ErrTeamAlreadyExists) {
-			errResponse.Error.Code = dto.TEAMEXISTS
-			errResponse.Error.Message = "team_name already exists"
-			return echo.NewHTTPError(http.StatusBadRequest, errResponse)
+	if upsert {
+		reassignReviews, err := boolParam(ctx, "reassign_reviews")
+		if err != nil {
+			return err
 		}
+		return h.upsert(ctx, in.TeamName, requiredReviewers, reviewSLAHours, users, reassignReviews)
+	}
 
-		errResponse.Error.Message = err.Error()
-		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	team, created, err := h.s.CreateTeamWithUsers(ctx.Request().Context(), in.TeamName, requiredReviewers, reviewSLAHours, users)
+
+	if err != nil {
+		return mapError(err)
 	}
 
 	var response dto.Team
 	response.FillFromEntity(team)
 
+	// Re-posted team is returned with its current members
+	if !created {
+		return ctx.JSON(http.StatusOK, response)
+	}
+	return ctx.JSON(http.StatusCreated, response)
+}
+
+func (h *handler) upsert(ctx echo.Context, teamName string, requiredReviewers, reviewSLAHours int, users []entity.User, reassignReviews bool) error {
+	result, err := h.s.UpsertTeamWithUsers(ctx.Request().Context(), teamName, requiredReviewers, reviewSLAHours, users, reassignReviews)
+
+	if err != nil {
+		return mapError(err)
+	}
+
+	response := UpsertResponse{
+		Users: lo.Map(result.Members, func(m entity.MemberUpsert, _ int) MemberResult {
+			return MemberResult{
+				UserID:           m.User.ID,
+				Status:           string(m.Status),
+				PreviousTeamName: m.PreviousTeam,
+				ReassignedReviews: lo.Map(m.Reassigned, func(e entity.AssignmentEvent, _ int) Reassignment {
+					return Reassignment{PullRequestID: e.PRID, NewReviewerID: e.ReviewerID}
+				}),
+				KeptReviews: m.KeptPRIDs,
+			}
+		}),
+	}
+	response.Team.FillFromEntity(result.Team)
+
+	if !result.TeamCreated {
+		return ctx.JSON(http.StatusOK, response)
+	}
 	return ctx.JSON(http.StatusCreated, response)
 }
+
+// Optional boolean query param, false when missing
+func boolParam(ctx echo.Context, name string) (bool, error) {
+	raw := ctx.QueryParam(name)
+	if raw == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		var errResponse dto.ErrorResponse
+		errResponse.Error.Code = dto.INVALIDINPUT
+		errResponse.Error.Message = name + " must be a boolean"
+		return false, echo.NewHTTPError(http.StatusBadRequest, errResponse)
+	}
+	return value, nil
+}
+
+func mapError(err error) error {
+	var errResponse dto.ErrorResponse
+
+	if errors.Is(err, service.ErrTeamAlreadyExists) {
+		errResponse.Error.Code = dto.TEAMEXISTS
+		errResponse.Error.Message = "team_name already exists"
+		return echo.NewHTTPError(http.StatusBadRequest, errResponse)
+	}
+	if errors.Is(err, service.ErrUserInOtherTeam) {
+		errResponse.Error.Code = dto.USERINOTHERTEAM
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusConflict, errResponse)
+	}
+	if errors.Is(err, service.ErrMembersForbidden) {
+		errResponse.Error.Code = dto.FORBIDDEN
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusForbidden, errResponse)
+	}
+	if errors.Is(err, service.ErrInvalidRequiredReviewers) || errors.Is(err, service.ErrInvalidReviewSLA) {
+		errResponse.Error.Code = dto.INVALIDINPUT
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusBadRequest, errResponse)
+	}
+
+	errResponse.Error.Message = err.Error()
+	return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+}
@@ -0,0 +1,11 @@
+package post_team_add_members
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type TeamService interface {
+	AddMembers(ctx context.Context, teamName string, users []entity.User) (entity.Team, []entity.User, error)
+}
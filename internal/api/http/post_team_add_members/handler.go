@@ -0,0 +1,67 @@
+package post_team_add_members
+
+import (
+	"errors"
+	"net/http"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	service "github.com/4udiwe/avito-pr-service/internal/service/team"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s TeamService
+}
+
+func New(teamService TeamService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: teamService})
+}
+
+type Request struct {
+	TeamName string           `json:"team_name" validate:"required"`
+	Members  []dto.TeamMember `json:"members" validate:"required,min=1"`
+}
+
+type Response struct {
+	Team         dto.Team `json:"team"`
+	AddedUserIDs []string `json:"added_user_ids"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	users := lo.Map(in.Members, func(m dto.TeamMember, _ int) entity.User { return *m.ToEntity() })
+
+	team, added, err := h.s.AddMembers(ctx.Request().Context(), in.TeamName, users)
+
+	if err != nil {
+		var errResponse dto.ErrorResponse
+
+		if errors.Is(err, service.ErrTeamNotFound) {
+			errResponse.Error.Code = dto.NOTFOUND
+			errResponse.Error.Message = "resource not found"
+			return echo.NewHTTPError(http.StatusNotFound, errResponse)
+		}
+		if errors.Is(err, service.ErrUserInOtherTeam) {
+			errResponse.Error.Code = dto.USERINOTHERTEAM
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
+		}
+
+		if errors.Is(err, service.ErrMembersForbidden) {
+			errResponse.Error.Code = dto.FORBIDDEN
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusForbidden, errResponse)
+		}
+
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+
+	response := Response{AddedUserIDs: lo.Map(added, func(u entity.User, _ int) string { return u.ID })}
+	response.Team.FillFromEntity(team)
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,11 @@
+package post_team_move_member
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type TeamService interface {
+	MoveMember(ctx context.Context, userID, teamName string, reassignReviews bool) (entity.MemberTransfer, error)
+}
@@ -0,0 +1,11 @@
+package post_team_remove_member
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type TeamService interface {
+	RemoveMember(ctx context.Context, teamName, userID string, reassignReviews bool) (entity.MemberTransfer, error)
+}
@@ -0,0 +1,79 @@
+package post_team_remove_member
+
+import (
+	"errors"
+	"net/http"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	service "github.com/4udiwe/avito-pr-service/internal/service/team"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s TeamService
+}
+
+func New(teamService TeamService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: teamService})
+}
+
+type Request struct {
+	TeamName        string `json:"team_name" validate:"required"`
+	UserID          string `json:"user_id" validate:"required"`
+	ReassignReviews bool   `json:"reassign_reviews"`
+}
+
+type Reassignment struct {
+	PullRequestID string `json:"pull_request_id"`
+	NewReviewerID string `json:"new_reviewer_id"`
+}
+
+type Response struct {
+	User              dto.User       `json:"user"`
+	PreviousTeamName  string         `json:"previous_team_name"`
+	ReassignedReviews []Reassignment `json:"reassigned_reviews"`
+	KeptReviews       []string       `json:"kept_reviews"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	transfer, err := h.s.RemoveMember(ctx.Request().Context(), in.TeamName, in.UserID, in.ReassignReviews)
+
+	if err != nil {
+		var errResponse dto.ErrorResponse
+
+		if errors.Is(err, service.ErrTeamNotFound) || errors.Is(err, service.ErrUserNotFound) {
+			errResponse.Error.Code = dto.NOTFOUND
+			errResponse.Error.Message = "resource not found"
+			return echo.NewHTTPError(http.StatusNotFound, errResponse)
+		}
+		if errors.Is(err, service.ErrUserNotInTeam) {
+			errResponse.Error.Code = dto.NOTTEAMMEMBER
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusConflict, errResponse)
+		}
+
+		if errors.Is(err, service.ErrMembersForbidden) {
+			errResponse.Error.Code = dto.FORBIDDEN
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusForbidden, errResponse)
+		}
+
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+
+	response := Response{
+		PreviousTeamName: transfer.PreviousTeam,
+		ReassignedReviews: lo.Map(transfer.Reassigned, func(e entity.AssignmentEvent, _ int) Reassignment {
+			return Reassignment{PullRequestID: e.PRID, NewReviewerID: e.ReviewerID}
+		}),
+		KeptReviews: lo.Ternary(transfer.KeptPRIDs == nil, []string{}, transfer.KeptPRIDs),
+	}
+	response.User.FillFromEntity(transfer.User)
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,11 @@
+package post_unassign
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type PRService interface {
+	UnassignReviewer(ctx context.Context, prID, reviewerID string, autoFill bool) (entity.PullRequest, error)
+}
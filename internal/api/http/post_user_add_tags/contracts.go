@@ -0,0 +1,9 @@
+package post_user_add_tags
+
+import (
+	"context"
+)
+
+type UserService interface {
+	AddTags(ctx context.Context, userID string, tags []string) ([]string, error)
+}
@@ -7,5 +7,5 @@ import (
 )
 
 type UserService interface {
-	SetUserStatus(ctx context.Context, userID string, isActive bool) (entity.User, error)
+	SetUserStatus(ctx context.Context, userID string, isActive bool) (entity.User, []entity.Reassignment, error)
 }
@@ -7,8 +7,10 @@ import (
 	api "github.com/4udiwe/avito-pr-service/internal/api/http"
 	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
 	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
 	service "github.com/4udiwe/avito-pr-service/internal/service/user"
 	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
 )
 
 type handler struct {
@@ -21,8 +23,19 @@ func New(userService UserService) api.Handler {
 
 type Request dto.PostUsersSetIsActiveJSONBody
 
+// New reviewer is null when nobody could take the review
+type Reassignment struct {
+	PullRequestID string  `json:"pull_request_id"`
+	NewReviewerID *string `json:"new_reviewer_id"`
+}
+
+type Response struct {
+	User          dto.User       `json:"user"`
+	Reassignments []Reassignment `json:"reassigned_reviews"`
+}
+
 func (h *handler) Handle(ctx echo.Context, in Request) error {
-	user, err := h.s.SetUserStatus(ctx.Request().Context(), in.UserId, in.IsActive)
+	user, reassignments, err := h.s.SetUserStatus(ctx.Request().Context(), in.UserId, in.IsActive)
 
 	if err != nil {
 		var errResponse dto.ErrorResponse
@@ -37,9 +50,14 @@ func (h *handler) Handle(ctx echo.Context, in Request) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
 	}
 
-	response := struct {
-		User dto.User `json:"user"`
-	}{}
+	response := Response{
+		Reassignments: lo.Map(reassignments, func(r entity.Reassignment, _ int) Reassignment {
+			return Reassignment{
+				PullRequestID: r.PRID,
+				NewReviewerID: lo.EmptyableToPtr(r.NewReviewerID),
+			}
+		}),
+	}
 	response.User.FillFromEntity(user)
 
 	return ctx.JSON(http.StatusOK, response)
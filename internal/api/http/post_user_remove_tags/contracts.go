@@ -0,0 +1,9 @@
+package post_user_remove_tags
+
+import (
+	"context"
+)
+
+type UserService interface {
+	RemoveTags(ctx context.Context, userID string, tags []string) ([]string, error)
+}
@@ -0,0 +1,57 @@
+package post_user_remove_tags
+
+import (
+	"errors"
+	"net/http"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	service "github.com/4udiwe/avito-pr-service/internal/service/user"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s UserService
+}
+
+func New(userService UserService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: userService})
+}
+
+type Request struct {
+	UserID string   `json:"user_id" validate:"required"`
+	Tags   []string `json:"tags" validate:"required,min=1"`
+}
+
+type Response struct {
+	UserID string   `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	tags, err := h.s.RemoveTags(ctx.Request().Context(), in.UserID, in.Tags)
+
+	if err != nil {
+		var errResponse dto.ErrorResponse
+
+		if errors.Is(err, service.ErrUserNotFound) {
+			errResponse.Error.Code = dto.NOTFOUND
+			errResponse.Error.Message = "resource not found"
+			return echo.NewHTTPError(http.StatusNotFound, errResponse)
+		}
+		if errors.Is(err, service.ErrEmptyTags) {
+			errResponse.Error.Code = dto.INVALIDINPUT
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusBadRequest, errResponse)
+		}
+
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, Response{
+		UserID: in.UserID,
+		Tags:   tags,
+	})
+}
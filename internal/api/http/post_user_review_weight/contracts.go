@@ -0,0 +1,9 @@
+package post_user_review_weight
+
+import (
+	"context"
+)
+
+type UserService interface {
+	SetReviewWeight(ctx context.Context, userID string, weight float64) error
+}
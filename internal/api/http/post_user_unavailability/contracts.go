@@ -0,0 +1,12 @@
+package post_user_unavailability
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type UserService interface {
+	AddUnavailability(ctx context.Context, userID string, startsAt, endsAt time.Time, reason string) (entity.Unavailability, error)
+}
@@ -0,0 +1,67 @@
+package post_user_unavailability
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	service "github.com/4udiwe/avito-pr-service/internal/service/user"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	s UserService
+}
+
+func New(userService UserService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: userService})
+}
+
+type Request struct {
+	UserID   string    `json:"user_id" validate:"required"`
+	StartsAt time.Time `json:"starts_at" validate:"required"`
+	EndsAt   time.Time `json:"ends_at" validate:"required"`
+	Reason   string    `json:"reason"`
+}
+
+type Response struct {
+	ID       uuid.UUID `json:"id"`
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	period, err := h.s.AddUnavailability(ctx.Request().Context(), in.UserID, in.StartsAt, in.EndsAt, in.Reason)
+
+	if err != nil {
+		var errResponse dto.ErrorResponse
+
+		if errors.Is(err, service.ErrUserNotFound) {
+			errResponse.Error.Code = dto.NOTFOUND
+			errResponse.Error.Message = "resource not found"
+			return echo.NewHTTPError(http.StatusNotFound, errResponse)
+		}
+		if errors.Is(err, service.ErrInvalidUnavailabilityPeriod) {
+			errResponse.Error.Code = dto.INVALIDINPUT
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusBadRequest, errResponse)
+		}
+
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+
+	return ctx.JSON(http.StatusCreated, Response{
+		ID:       period.ID,
+		UserID:   period.UserID,
+		StartsAt: period.StartsAt,
+		EndsAt:   period.EndsAt,
+		Reason:   period.Reason,
+	})
+}
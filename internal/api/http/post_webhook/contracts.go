@@ -0,0 +1,11 @@
+package post_webhook
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type WebhookService interface {
+	Subscribe(ctx context.Context, url, secret string, eventTypes []entity.WebhookEventType) (entity.WebhookSubscription, error)
+}
@@ -0,0 +1,67 @@
+package post_webhook
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/decorator"
+	"github.com/4udiwe/avito-pr-service/internal/dto"
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	service "github.com/4udiwe/avito-pr-service/internal/service/webhook"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+)
+
+type handler struct {
+	s WebhookService
+}
+
+func New(webhookService WebhookService) api.Handler {
+	return decorator.NewBindAndValidateDerocator(&handler{s: webhookService})
+}
+
+// Empty event_types subscribes to all events, empty secret is generated
+type Request struct {
+	URL        string   `json:"url" validate:"required"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+}
+
+// Secret is returned only once, on subscription
+type Response struct {
+	ID         uuid.UUID `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (h *handler) Handle(ctx echo.Context, in Request) error {
+	eventTypes := lo.Map(in.EventTypes, func(t string, _ int) entity.WebhookEventType { return entity.WebhookEventType(t) })
+
+	subscription, err := h.s.Subscribe(ctx.Request().Context(), in.URL, in.Secret, eventTypes)
+
+	if err != nil {
+		var errResponse dto.ErrorResponse
+
+		if errors.Is(err, service.ErrInvalidWebhookURL) || errors.Is(err, service.ErrInvalidEventType) {
+			errResponse.Error.Code = dto.INVALIDINPUT
+			errResponse.Error.Message = err.Error()
+			return echo.NewHTTPError(http.StatusBadRequest, errResponse)
+		}
+
+		errResponse.Error.Message = err.Error()
+		return echo.NewHTTPError(http.StatusInternalServerError, errResponse)
+	}
+
+	return ctx.JSON(http.StatusCreated, Response{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventTypes: lo.Map(subscription.EventTypes, func(t entity.WebhookEventType, _ int) string { return string(t) }),
+		CreatedAt:  subscription.CreatedAt,
+	})
+}
@@ -0,0 +1,11 @@
+package post_webhook_retry
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type WebhookService interface {
+	RetryDeadLetter(ctx context.Context, ID uuid.UUID) error
+}
@@ -3,19 +3,38 @@ package app
 import (
 	"context"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/4udiwe/avito-pr-service/config"
 	api "github.com/4udiwe/avito-pr-service/internal/api/http"
 	"github.com/4udiwe/avito-pr-service/internal/database"
+	repo_codeowner "github.com/4udiwe/avito-pr-service/internal/repository/codeowner"
+	repo_history "github.com/4udiwe/avito-pr-service/internal/repository/history"
+	repo_idempotency "github.com/4udiwe/avito-pr-service/internal/repository/idempotency"
+	repo_outbox "github.com/4udiwe/avito-pr-service/internal/repository/outbox"
 	repo_pr "github.com/4udiwe/avito-pr-service/internal/repository/pr"
+	repo_review "github.com/4udiwe/avito-pr-service/internal/repository/review"
 	repo_stats "github.com/4udiwe/avito-pr-service/internal/repository/stats"
 	repo_team "github.com/4udiwe/avito-pr-service/internal/repository/team"
+	repo_unavailability "github.com/4udiwe/avito-pr-service/internal/repository/unavailability"
 	repo_user "github.com/4udiwe/avito-pr-service/internal/repository/user"
+	repo_webhook "github.com/4udiwe/avito-pr-service/internal/repository/webhook"
+	"github.com/4udiwe/avito-pr-service/internal/service/codeowner"
+	"github.com/4udiwe/avito-pr-service/internal/service/idempotency"
 	"github.com/4udiwe/avito-pr-service/internal/service/pr"
 	"github.com/4udiwe/avito-pr-service/internal/service/stats"
 	"github.com/4udiwe/avito-pr-service/internal/service/team"
 	"github.com/4udiwe/avito-pr-service/internal/service/user"
+	"github.com/4udiwe/avito-pr-service/internal/service/webhook"
+	"github.com/4udiwe/avito-pr-service/internal/worker/idempotency_cleanup"
+	"github.com/4udiwe/avito-pr-service/internal/worker/outbox_cleanup"
+	"github.com/4udiwe/avito-pr-service/internal/worker/stale_reviews"
+	"github.com/4udiwe/avito-pr-service/internal/worker/webhooks"
+	"github.com/4udiwe/avito-pr-service/internal/worker/webhooks_cleanup"
+	"github.com/4udiwe/avito-pr-service/pkg/auth"
 	"github.com/4udiwe/avito-pr-service/pkg/httpserver"
+	"github.com/4udiwe/avito-pr-service/pkg/outbox"
 	"github.com/4udiwe/avito-pr-service/pkg/postgres"
 	log "github.com/sirupsen/logrus"
 
@@ -30,7 +49,8 @@ type App struct {
 	postgres *postgres.Postgres
 
 	// Echo
-	echoHandler *echo.Echo
+	echoHandler   *echo.Echo
+	authenticator *auth.Authenticator
 
 	// Repositories
 	userRepo  *repo_user.Repository
@@ -38,26 +58,77 @@ type App struct {
 	prRepo    *repo_pr.Repository
 	statsRepo *repo_stats.Repository
 
+	unavailabilityRepo *repo_unavailability.Repository
+	codeOwnerRepo      *repo_codeowner.Repository
+	historyRepo        *repo_history.Repository
+	reviewRepo         *repo_review.Repository
+	webhookRepo        *repo_webhook.Repository
+	outboxRepo         *repo_outbox.Repository
+	idempotencyRepo    *repo_idempotency.Repository
+
 	// Handlers
 	getPRsHandler         api.Handler
 	getTeamHandler        api.Handler
 	getTeamsHandler       api.Handler
 	getUserReviewsHandler api.Handler
 	getStatsHandler       api.Handler
+	getPRHistoryHandler   api.Handler
+	getPROverdueHandler   api.Handler
+
+	getUserUnavailabilityHandler        api.Handler
+	postUserUnavailabilityHandler       api.Handler
+	postDeleteUserUnavailabilityHandler api.Handler
+
+	getCodeOwnersHandler       api.Handler
+	postCodeOwnerHandler       api.Handler
+	postDeleteCodeOwnerHandler api.Handler
+
+	getWebhooksHandler           api.Handler
+	postWebhookHandler           api.Handler
+	postDeleteWebhookHandler     api.Handler
+	getWebhookDeadLettersHandler api.Handler
+	postWebhookRetryHandler      api.Handler
+
+	postTeamAddMembersHandler   api.Handler
+	postTeamRemoveMemberHandler api.Handler
+	postTeamMoveMemberHandler   api.Handler
 
 	postAssignUserToPRHandler   api.Handler
 	postMergePRHandler          api.Handler
 	postPRHandler               api.Handler
+	postPRBulkHandler           api.Handler
 	postReassignReviewerHandler api.Handler
 	postTeamHandler             api.Handler
 	postIsUserActiveHandler     api.Handler
 	postDeactivateTeamHandler   api.Handler
+	postUserReviewWeightHandler api.Handler
+	postUserAddTagsHandler      api.Handler
+	postUserRemoveTagsHandler   api.Handler
+	postReviewHandler           api.Handler
+	postPRReadyHandler          api.Handler
+	postPRCloseHandler          api.Handler
+	postPRReopenHandler         api.Handler
+	patchPRHandler              api.Handler
+	patchPRAuthorHandler        api.Handler
+	postUnassignReviewerHandler api.Handler
 
 	// Services
 	userService  *user.Service
 	teamService  *team.Service
 	prService    *pr.Service
 	statsService *stats.Service
+
+	codeOwnerService   *codeowner.Service
+	webhookService     *webhook.Service
+	idempotencyService *idempotency.Service
+
+	// Workers
+	staleReviewsWorker       *stale_reviews.Worker
+	webhooksWorker           *webhooks.Worker
+	webhooksCleanupWorker    *webhooks_cleanup.Worker
+	outboxRelay              *outbox.Relay
+	outboxCleanupWorker      *outbox_cleanup.Worker
+	idempotencyCleanupWorker *idempotency_cleanup.Worker
 }
 
 func New(configPath string) *App {
@@ -91,6 +162,56 @@ func (app *App) Start() {
 		log.Errorf("app - Start - Migrations failed: %v", err)
 	}
 
+	// Graceful shutdown on SIGINT/SIGTERM
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	app.interrupt = interrupt
+
+	// Workers
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+
+	if app.cfg.StaleReviews.Enabled {
+		log.Info("Starting stale reviews worker...")
+		app.StaleReviewsWorker().Start(workersCtx)
+	}
+	if app.cfg.Webhooks.Enabled {
+		log.Info("Starting webhooks worker...")
+		app.WebhooksWorker().Start(workersCtx)
+		app.WebhooksCleanupWorker().Start(workersCtx)
+	}
+	if app.eventsEnabled() {
+		log.Info("Starting outbox relay...")
+		app.OutboxRelay().Start(workersCtx)
+		app.OutboxCleanupWorker().Start(workersCtx)
+	}
+	if app.cfg.Idempotency.Enabled {
+		log.Info("Starting idempotency keys cleanup worker...")
+		app.IdempotencyCleanupWorker().Start(workersCtx)
+	}
+
+	// Runs after HTTP server shutdown and before Postgres is closed
+	defer func() {
+		stopWorkers()
+		if app.staleReviewsWorker != nil {
+			<-app.staleReviewsWorker.Done()
+		}
+		if app.webhooksWorker != nil {
+			<-app.webhooksWorker.Done()
+		}
+		if app.webhooksCleanupWorker != nil {
+			<-app.webhooksCleanupWorker.Done()
+		}
+		if app.outboxRelay != nil {
+			<-app.outboxRelay.Done()
+		}
+		if app.outboxCleanupWorker != nil {
+			<-app.outboxCleanupWorker.Done()
+		}
+		if app.idempotencyCleanupWorker != nil {
+			<-app.idempotencyCleanupWorker.Done()
+		}
+	}()
+
 	// App server
 	log.Info("Starting app server...")
 	httpServer := httpserver.New(app.EchoHandler(), httpserver.Port(app.cfg.HTTP.Port))
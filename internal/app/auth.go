@@ -0,0 +1,36 @@
+package app
+
+import (
+	"github.com/4udiwe/avito-pr-service/pkg/auth"
+	log "github.com/sirupsen/logrus"
+)
+
+func (app *App) Authenticator() *auth.Authenticator {
+	if app.authenticator != nil {
+		return app.authenticator
+	}
+
+	var opts []auth.Option
+	for _, t := range app.cfg.Auth.Tokens {
+		role := auth.Role(t.Role)
+		if t.Token == "" || t.Subject == "" || !role.IsValid() {
+			log.Fatalf("app - Authenticator - invalid token of %q: token, subject and role (admin, team_lead, member) are required", t.Subject)
+		}
+		if role == auth.RoleTeamLead && t.Team == "" {
+			log.Fatalf("app - Authenticator - invalid token of %q: team is required for team_lead", t.Subject)
+		}
+		opts = append(opts, auth.StaticToken(t.Token, auth.Principal{Subject: t.Subject, Role: role, Team: t.Team}))
+	}
+	if app.cfg.Auth.JWTSecret != "" {
+		opts = append(opts, auth.JWTSecret(app.cfg.Auth.JWTSecret))
+		if app.cfg.Auth.JWTAllowNoExp {
+			opts = append(opts, auth.AllowJWTWithoutExp())
+		}
+	}
+	if len(opts) == 0 {
+		log.Fatal("app - Authenticator - auth is enabled, but neither tokens nor jwt_secret are configured")
+	}
+
+	app.authenticator = auth.New(opts...)
+	return app.authenticator
+}
@@ -1,10 +1,17 @@
 package app
 
 import (
+	repo_codeowner "github.com/4udiwe/avito-pr-service/internal/repository/codeowner"
+	repo_history "github.com/4udiwe/avito-pr-service/internal/repository/history"
+	repo_idempotency "github.com/4udiwe/avito-pr-service/internal/repository/idempotency"
+	repo_outbox "github.com/4udiwe/avito-pr-service/internal/repository/outbox"
 	repo_pr "github.com/4udiwe/avito-pr-service/internal/repository/pr"
+	repo_review "github.com/4udiwe/avito-pr-service/internal/repository/review"
 	repo_stats "github.com/4udiwe/avito-pr-service/internal/repository/stats"
 	repo_team "github.com/4udiwe/avito-pr-service/internal/repository/team"
+	repo_unavailability "github.com/4udiwe/avito-pr-service/internal/repository/unavailability"
 	repo_user "github.com/4udiwe/avito-pr-service/internal/repository/user"
+	repo_webhook "github.com/4udiwe/avito-pr-service/internal/repository/webhook"
 	"github.com/4udiwe/avito-pr-service/pkg/postgres"
 )
 
@@ -43,3 +50,59 @@ func (app *App) StatsRepo() *repo_stats.Repository {
 	app.statsRepo = repo_stats.New(app.Postgres())
 	return app.statsRepo
 }
+
+func (app *App) UnavailabilityRepo() *repo_unavailability.Repository {
+	if app.unavailabilityRepo != nil {
+		return app.unavailabilityRepo
+	}
+	app.unavailabilityRepo = repo_unavailability.New(app.Postgres())
+	return app.unavailabilityRepo
+}
+
+func (app *App) CodeOwnerRepo() *repo_codeowner.Repository {
+	if app.codeOwnerRepo != nil {
+		return app.codeOwnerRepo
+	}
+	app.codeOwnerRepo = repo_codeowner.New(app.Postgres())
+	return app.codeOwnerRepo
+}
+
+func (app *App) HistoryRepo() *repo_history.Repository {
+	if app.historyRepo != nil {
+		return app.historyRepo
+	}
+	app.historyRepo = repo_history.New(app.Postgres())
+	return app.historyRepo
+}
+
+func (app *App) ReviewRepo() *repo_review.Repository {
+	if app.reviewRepo != nil {
+		return app.reviewRepo
+	}
+	app.reviewRepo = repo_review.New(app.Postgres())
+	return app.reviewRepo
+}
+
+func (app *App) OutboxRepo() *repo_outbox.Repository {
+	if app.outboxRepo != nil {
+		return app.outboxRepo
+	}
+	app.outboxRepo = repo_outbox.New(app.Postgres())
+	return app.outboxRepo
+}
+
+func (app *App) WebhookRepo() *repo_webhook.Repository {
+	if app.webhookRepo != nil {
+		return app.webhookRepo
+	}
+	app.webhookRepo = repo_webhook.New(app.Postgres())
+	return app.webhookRepo
+}
+
+func (app *App) IdempotencyRepo() *repo_idempotency.Repository {
+	if app.idempotencyRepo != nil {
+		return app.idempotencyRepo
+	}
+	app.idempotencyRepo = repo_idempotency.New(app.Postgres())
+	return app.idempotencyRepo
+}
@@ -2,18 +2,45 @@ package app
 
 import (
 	api "github.com/4udiwe/avito-pr-service/internal/api/http"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/get_code_owners"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/get_pr_history"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/get_pr_overdue"
 	"github.com/4udiwe/avito-pr-service/internal/api/http/get_prs"
 	"github.com/4udiwe/avito-pr-service/internal/api/http/get_stats"
 	"github.com/4udiwe/avito-pr-service/internal/api/http/get_team"
 	"github.com/4udiwe/avito-pr-service/internal/api/http/get_teams"
 	"github.com/4udiwe/avito-pr-service/internal/api/http/get_user_reviews"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/get_user_unavailability"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/get_webhook_dead_letters"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/get_webhooks"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/patch_pr"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/patch_pr_author"
 	"github.com/4udiwe/avito-pr-service/internal/api/http/post_assign"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_code_owner"
 	"github.com/4udiwe/avito-pr-service/internal/api/http/post_deactivate_team"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_delete_code_owner"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_delete_user_unavailability"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_delete_webhook"
 	"github.com/4udiwe/avito-pr-service/internal/api/http/post_merge"
 	"github.com/4udiwe/avito-pr-service/internal/api/http/post_pr"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_pr_bulk"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_pr_close"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_pr_ready"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_pr_reopen"
 	"github.com/4udiwe/avito-pr-service/internal/api/http/post_reassign"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_review"
 	"github.com/4udiwe/avito-pr-service/internal/api/http/post_team"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_team_add_members"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_team_move_member"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_team_remove_member"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_unassign"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_user_add_tags"
 	"github.com/4udiwe/avito-pr-service/internal/api/http/post_user_is_active"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_user_remove_tags"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_user_review_weight"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_user_unavailability"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_webhook"
+	"github.com/4udiwe/avito-pr-service/internal/api/http/post_webhook_retry"
 )
 
 func (app *App) GetPRsHandler() api.Handler {
@@ -24,6 +51,22 @@ func (app *App) GetPRsHandler() api.Handler {
 	return app.getPRsHandler
 }
 
+func (app *App) GetPRHistoryHandler() api.Handler {
+	if app.getPRHistoryHandler != nil {
+		return app.getPRHistoryHandler
+	}
+	app.getPRHistoryHandler = get_pr_history.New(app.PRService())
+	return app.getPRHistoryHandler
+}
+
+func (app *App) GetPROverdueHandler() api.Handler {
+	if app.getPROverdueHandler != nil {
+		return app.getPROverdueHandler
+	}
+	app.getPROverdueHandler = get_pr_overdue.New(app.PRService())
+	return app.getPROverdueHandler
+}
+
 func (app *App) GetTeamHandler() api.Handler {
 	if app.getTeamHandler != nil {
 		return app.getTeamHandler
@@ -72,6 +115,14 @@ func (app *App) PostPRHandler() api.Handler {
 	return app.postPRHandler
 }
 
+func (app *App) PostPRBulkHandler() api.Handler {
+	if app.postPRBulkHandler != nil {
+		return app.postPRBulkHandler
+	}
+	app.postPRBulkHandler = post_pr_bulk.New(app.PRService(), app.cfg.BulkImport.MaxItems, app.cfg.BulkImport.MaxBodyBytes)
+	return app.postPRBulkHandler
+}
+
 func (app *App) PostReassignReviewerHandler() api.Handler {
 	if app.postReassignReviewerHandler != nil {
 		return app.postReassignReviewerHandler
@@ -96,6 +147,54 @@ func (app *App) PostIsUserActiveHandler() api.Handler {
 	return app.postIsUserActiveHandler
 }
 
+func (app *App) PostUserReviewWeightHandler() api.Handler {
+	if app.postUserReviewWeightHandler != nil {
+		return app.postUserReviewWeightHandler
+	}
+	app.postUserReviewWeightHandler = post_user_review_weight.New(app.UserService())
+	return app.postUserReviewWeightHandler
+}
+
+func (app *App) PostUserAddTagsHandler() api.Handler {
+	if app.postUserAddTagsHandler != nil {
+		return app.postUserAddTagsHandler
+	}
+	app.postUserAddTagsHandler = post_user_add_tags.New(app.UserService())
+	return app.postUserAddTagsHandler
+}
+
+func (app *App) PostUserRemoveTagsHandler() api.Handler {
+	if app.postUserRemoveTagsHandler != nil {
+		return app.postUserRemoveTagsHandler
+	}
+	app.postUserRemoveTagsHandler = post_user_remove_tags.New(app.UserService())
+	return app.postUserRemoveTagsHandler
+}
+
+func (app *App) GetUserUnavailabilityHandler() api.Handler {
+	if app.getUserUnavailabilityHandler != nil {
+		return app.getUserUnavailabilityHandler
+	}
+	app.getUserUnavailabilityHandler = get_user_unavailability.New(app.UserService())
+	return app.getUserUnavailabilityHandler
+}
+
+func (app *App) PostUserUnavailabilityHandler() api.Handler {
+	if app.postUserUnavailabilityHandler != nil {
+		return app.postUserUnavailabilityHandler
+	}
+	app.postUserUnavailabilityHandler = post_user_unavailability.New(app.UserService())
+	return app.postUserUnavailabilityHandler
+}
+
+func (app *App) PostDeleteUserUnavailabilityHandler() api.Handler {
+	if app.postDeleteUserUnavailabilityHandler != nil {
+		return app.postDeleteUserUnavailabilityHandler
+	}
+	app.postDeleteUserUnavailabilityHandler = post_delete_user_unavailability.New(app.UserService())
+	return app.postDeleteUserUnavailabilityHandler
+}
+
 func (app *App) PostDeactivateTeamHandler() api.Handler {
 	if app.postDeactivateTeamHandler != nil {
 		return app.postDeactivateTeamHandler
@@ -104,6 +203,30 @@ func (app *App) PostDeactivateTeamHandler() api.Handler {
 	return app.postDeactivateTeamHandler
 }
 
+func (app *App) PostTeamAddMembersHandler() api.Handler {
+	if app.postTeamAddMembersHandler != nil {
+		return app.postTeamAddMembersHandler
+	}
+	app.postTeamAddMembersHandler = post_team_add_members.New(app.TeamService())
+	return app.postTeamAddMembersHandler
+}
+
+func (app *App) PostTeamRemoveMemberHandler() api.Handler {
+	if app.postTeamRemoveMemberHandler != nil {
+		return app.postTeamRemoveMemberHandler
+	}
+	app.postTeamRemoveMemberHandler = post_team_remove_member.New(app.TeamService())
+	return app.postTeamRemoveMemberHandler
+}
+
+func (app *App) PostTeamMoveMemberHandler() api.Handler {
+	if app.postTeamMoveMemberHandler != nil {
+		return app.postTeamMoveMemberHandler
+	}
+	app.postTeamMoveMemberHandler = post_team_move_member.New(app.TeamService())
+	return app.postTeamMoveMemberHandler
+}
+
 func (app *App) GetStatsHandler() api.Handler {
 	if app.getStatsHandler != nil {
 		return app.getStatsHandler
@@ -111,3 +234,123 @@ func (app *App) GetStatsHandler() api.Handler {
 	app.getStatsHandler = get_stats.New(app.StatsService())
 	return app.getStatsHandler
 }
+
+func (app *App) GetCodeOwnersHandler() api.Handler {
+	if app.getCodeOwnersHandler != nil {
+		return app.getCodeOwnersHandler
+	}
+	app.getCodeOwnersHandler = get_code_owners.New(app.CodeOwnerService())
+	return app.getCodeOwnersHandler
+}
+
+func (app *App) PostCodeOwnerHandler() api.Handler {
+	if app.postCodeOwnerHandler != nil {
+		return app.postCodeOwnerHandler
+	}
+	app.postCodeOwnerHandler = post_code_owner.New(app.CodeOwnerService())
+	return app.postCodeOwnerHandler
+}
+
+func (app *App) PostDeleteCodeOwnerHandler() api.Handler {
+	if app.postDeleteCodeOwnerHandler != nil {
+		return app.postDeleteCodeOwnerHandler
+	}
+	app.postDeleteCodeOwnerHandler = post_delete_code_owner.New(app.CodeOwnerService())
+	return app.postDeleteCodeOwnerHandler
+}
+
+func (app *App) PostReviewHandler() api.Handler {
+	if app.postReviewHandler != nil {
+		return app.postReviewHandler
+	}
+	app.postReviewHandler = post_review.New(app.PRService())
+	return app.postReviewHandler
+}
+
+func (app *App) PostPRReadyHandler() api.Handler {
+	if app.postPRReadyHandler != nil {
+		return app.postPRReadyHandler
+	}
+	app.postPRReadyHandler = post_pr_ready.New(app.PRService())
+	return app.postPRReadyHandler
+}
+
+func (app *App) PostPRCloseHandler() api.Handler {
+	if app.postPRCloseHandler != nil {
+		return app.postPRCloseHandler
+	}
+	app.postPRCloseHandler = post_pr_close.New(app.PRService())
+	return app.postPRCloseHandler
+}
+
+func (app *App) PostPRReopenHandler() api.Handler {
+	if app.postPRReopenHandler != nil {
+		return app.postPRReopenHandler
+	}
+	app.postPRReopenHandler = post_pr_reopen.New(app.PRService())
+	return app.postPRReopenHandler
+}
+
+func (app *App) PatchPRHandler() api.Handler {
+	if app.patchPRHandler != nil {
+		return app.patchPRHandler
+	}
+	app.patchPRHandler = patch_pr.New(app.PRService())
+	return app.patchPRHandler
+}
+
+func (app *App) PatchPRAuthorHandler() api.Handler {
+	if app.patchPRAuthorHandler != nil {
+		return app.patchPRAuthorHandler
+	}
+	app.patchPRAuthorHandler = patch_pr_author.New(app.PRService())
+	return app.patchPRAuthorHandler
+}
+
+func (app *App) PostUnassignReviewerHandler() api.Handler {
+	if app.postUnassignReviewerHandler != nil {
+		return app.postUnassignReviewerHandler
+	}
+	app.postUnassignReviewerHandler = post_unassign.New(app.PRService())
+	return app.postUnassignReviewerHandler
+}
+
+func (app *App) GetWebhooksHandler() api.Handler {
+	if app.getWebhooksHandler != nil {
+		return app.getWebhooksHandler
+	}
+	app.getWebhooksHandler = get_webhooks.New(app.WebhookService())
+	return app.getWebhooksHandler
+}
+
+func (app *App) PostWebhookHandler() api.Handler {
+	if app.postWebhookHandler != nil {
+		return app.postWebhookHandler
+	}
+	app.postWebhookHandler = post_webhook.New(app.WebhookService())
+	return app.postWebhookHandler
+}
+
+func (app *App) PostDeleteWebhookHandler() api.Handler {
+	if app.postDeleteWebhookHandler != nil {
+		return app.postDeleteWebhookHandler
+	}
+	app.postDeleteWebhookHandler = post_delete_webhook.New(app.WebhookService())
+	return app.postDeleteWebhookHandler
+}
+
+func (app *App) GetWebhookDeadLettersHandler() api.Handler {
+	if app.getWebhookDeadLettersHandler != nil {
+		return app.getWebhookDeadLettersHandler
+	}
+	app.getWebhookDeadLettersHandler = get_webhook_dead_letters.New(app.WebhookService())
+	return app.getWebhookDeadLettersHandler
+}
+
+func (app *App) PostWebhookRetryHandler() api.Handler {
+	if app.postWebhookRetryHandler != nil {
+		return app.postWebhookRetryHandler
+	}
+	app.postWebhookRetryHandler = post_webhook_retry.New(app.WebhookService())
+	return app.postWebhookRetryHandler
+}
@@ -3,6 +3,8 @@ package app
 import (
 	"net/http"
 
+	"github.com/4udiwe/avito-pr-service/internal/api/http/middleware"
+	"github.com/4udiwe/avito-pr-service/pkg/auth"
 	"github.com/4udiwe/avito-pr-service/pkg/validator"
 	"github.com/labstack/echo/v4"
 )
@@ -22,27 +24,70 @@ func (app *App) EchoHandler() *echo.Echo {
 }
 
 func (app *App) configureRouter(handler *echo.Echo) {
+	if app.cfg.Auth.Enabled {
+		handler.Use(middleware.Auth(app.Authenticator(), "/health"))
+	}
+	// After authentication, so that keys of different callers don't collide
+	if app.cfg.Idempotency.Enabled {
+		handler.Use(middleware.Idempotency(app.IdempotencyService(), app.cfg.Idempotency.MaxBodyBytes))
+	}
+
 	teamGroup := handler.Group("team")
 	{
 		teamGroup.POST("/add", app.PostTeamHandler().Handle)
 		teamGroup.GET("/get", app.GetTeamHandler().Handle)
 		teamGroup.GET("", app.GetTeamsHandler().Handle)
-		teamGroup.POST("/deactivate", app.PostDeactivateTeamHandler().Handle)
+		teamGroup.POST("/deactivate", app.PostDeactivateTeamHandler().Handle, middleware.RequireRole(auth.RoleAdmin))
+		teamGroup.POST("/addMembers", app.PostTeamAddMembersHandler().Handle, middleware.RequireRole(auth.RoleAdmin, auth.RoleTeamLead))
+		teamGroup.POST("/removeMember", app.PostTeamRemoveMemberHandler().Handle, middleware.RequireRole(auth.RoleAdmin, auth.RoleTeamLead))
+		teamGroup.POST("/moveMember", app.PostTeamMoveMemberHandler().Handle, middleware.RequireRole(auth.RoleAdmin, auth.RoleTeamLead))
 	}
 
 	userGroup := handler.Group("users")
 	{
 		userGroup.POST("/setIsActive", app.PostIsUserActiveHandler().Handle)
+		userGroup.POST("/setReviewWeight", app.PostUserReviewWeightHandler().Handle)
+		userGroup.POST("/addTags", app.PostUserAddTagsHandler().Handle)
+		userGroup.POST("/removeTags", app.PostUserRemoveTagsHandler().Handle)
 		userGroup.GET("/getReview", app.GetUserReviewsHandler().Handle)
+		userGroup.POST("/unavailability/add", app.PostUserUnavailabilityHandler().Handle)
+		userGroup.GET("/unavailability", app.GetUserUnavailabilityHandler().Handle)
+		userGroup.POST("/unavailability/delete", app.PostDeleteUserUnavailabilityHandler().Handle)
 	}
 
 	pullRequestGroup := handler.Group("pullRequest")
 	{
 		pullRequestGroup.POST("/create", app.PostPRHandler().Handle)
+		pullRequestGroup.POST("/bulk", app.PostPRBulkHandler().Handle)
 		pullRequestGroup.POST("/merge", app.PostMergePRHandler().Handle)
 		pullRequestGroup.POST("/reassign", app.PostReassignReviewerHandler().Handle)
 		pullRequestGroup.POST("/assign", app.PostAssignUserToPRHandler().Handle)
+		pullRequestGroup.POST("/unassign", app.PostUnassignReviewerHandler().Handle)
+		pullRequestGroup.POST("/review", app.PostReviewHandler().Handle)
+		pullRequestGroup.POST("/ready", app.PostPRReadyHandler().Handle)
+		pullRequestGroup.POST("/close", app.PostPRCloseHandler().Handle)
+		pullRequestGroup.POST("/reopen", app.PostPRReopenHandler().Handle)
 		pullRequestGroup.GET("", app.GetPRsHandler().Handle)
+		pullRequestGroup.PATCH("", app.PatchPRHandler().Handle)
+		pullRequestGroup.PATCH("/author", app.PatchPRAuthorHandler().Handle)
+		pullRequestGroup.GET("/history", app.GetPRHistoryHandler().Handle)
+		pullRequestGroup.GET("/overdue", app.GetPROverdueHandler().Handle)
+	}
+
+	codeOwnersGroup := handler.Group("codeOwners")
+	{
+		codeOwnersGroup.POST("/add", app.PostCodeOwnerHandler().Handle)
+		codeOwnersGroup.GET("", app.GetCodeOwnersHandler().Handle)
+		codeOwnersGroup.POST("/delete", app.PostDeleteCodeOwnerHandler().Handle)
+	}
+
+	webhooksGroup := handler.Group("webhooks")
+	{
+		webhooksGroup.POST("/add", app.PostWebhookHandler().Handle)
+		webhooksGroup.GET("", app.GetWebhooksHandler().Handle)
+		webhooksGroup.POST("/delete", app.PostDeleteWebhookHandler().Handle)
+		webhooksGroup.GET("/deadLetters", app.GetWebhookDeadLettersHandler().Handle)
+		webhooksGroup.POST("/retry", app.PostWebhookRetryHandler().Handle)
 	}
 
 	handler.GET("/stats", app.GetStatsHandler().Handle)
@@ -1,17 +1,27 @@
 package app
 
 import (
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/service/codeowner"
+	"github.com/4udiwe/avito-pr-service/internal/service/idempotency"
 	"github.com/4udiwe/avito-pr-service/internal/service/pr"
 	"github.com/4udiwe/avito-pr-service/internal/service/stats"
 	"github.com/4udiwe/avito-pr-service/internal/service/team"
 	"github.com/4udiwe/avito-pr-service/internal/service/user"
+	"github.com/4udiwe/avito-pr-service/internal/service/webhook"
+	"github.com/samber/lo"
 )
 
 func (app *App) TeamService() *team.Service {
 	if app.teamService != nil {
 		return app.teamService
 	}
-	app.teamService = team.New(app.UserRepo(), app.TeamRepo(), app.PRRepo(), app.Postgres())
+	var opts []team.Option
+	if app.eventsEnabled() {
+		opts = append(opts, team.WithEvents(app.OutboxRepo()))
+	}
+
+	app.teamService = team.New(app.UserRepo(), app.TeamRepo(), app.PRRepo(), app.HistoryRepo(), app.PRService(), app.Postgres(), opts...)
 	return app.teamService
 }
 
@@ -19,7 +29,32 @@ func (app *App) PRService() *pr.Service {
 	if app.prService != nil {
 		return app.prService
 	}
-	app.prService = pr.New(app.PRRepo(), app.UserRepo(), app.Postgres())
+	opts := []pr.Option{
+		pr.WithStrategy(entity.ReviewerStrategy(app.cfg.Reviewers.Strategy)),
+		pr.WithTeamStrategies(lo.MapValues(app.cfg.Reviewers.TeamStrategies, func(strategy string, _ string) entity.ReviewerStrategy {
+			return entity.ReviewerStrategy(strategy)
+		})),
+		pr.WithBuddyTeams(app.cfg.Reviewers.BuddyTeams),
+		pr.WithImportBatchSize(app.cfg.BulkImport.BatchSize),
+		pr.WithMergePolicy(entity.MergePolicy{
+			MinApprovals:            app.cfg.MergePolicy.MinApprovals,
+			BlockOnChangesRequested: app.cfg.MergePolicy.BlockOnChangesRequested,
+			RequireTeamApproval:     app.cfg.MergePolicy.RequireTeamApproval,
+		}),
+	}
+	if app.eventsEnabled() {
+		opts = append(opts, pr.WithEvents(app.OutboxRepo()))
+	}
+
+	app.prService = pr.New(
+		app.PRRepo(),
+		app.UserRepo(),
+		app.CodeOwnerRepo(),
+		app.HistoryRepo(),
+		app.ReviewRepo(),
+		app.Postgres(),
+		opts...,
+	)
 	return app.prService
 }
 
@@ -27,7 +62,12 @@ func (app *App) UserService() *user.Service {
 	if app.userService != nil {
 		return app.userService
 	}
-	app.userService = user.New(app.UserRepo(), app.PRRepo(), app.Postgres())
+	var opts []user.Option
+	if app.eventsEnabled() {
+		opts = append(opts, user.WithEvents(app.OutboxRepo()))
+	}
+
+	app.userService = user.New(app.UserRepo(), app.PRRepo(), app.UnavailabilityRepo(), app.HistoryRepo(), app.ReviewRepo(), app.PRService(), app.Postgres(), opts...)
 	return app.userService
 }
 
@@ -35,6 +75,39 @@ func (app *App) StatsService() *stats.Service {
 	if app.statsService != nil {
 		return app.statsService
 	}
-	app.statsService = stats.New(app.StatsRepo())
+	app.statsService = stats.New(app.StatsRepo(), stats.WithReviewSLAWindow(app.cfg.Stats.ReviewSLAWindow))
 	return app.statsService
 }
+
+func (app *App) CodeOwnerService() *codeowner.Service {
+	if app.codeOwnerService != nil {
+		return app.codeOwnerService
+	}
+	app.codeOwnerService = codeowner.New(app.CodeOwnerRepo(), app.TeamRepo())
+	return app.codeOwnerService
+}
+
+func (app *App) WebhookService() *webhook.Service {
+	if app.webhookService != nil {
+		return app.webhookService
+	}
+	app.webhookService = webhook.New(
+		app.WebhookRepo(),
+		webhook.NewHTTPSender(app.cfg.Webhooks.Timeout),
+		app.cfg.Webhooks.MaxAttempts,
+		app.cfg.Webhooks.Timeout,
+	)
+	return app.webhookService
+}
+
+func (app *App) IdempotencyService() *idempotency.Service {
+	if app.idempotencyService != nil {
+		return app.idempotencyService
+	}
+	app.idempotencyService = idempotency.New(
+		app.IdempotencyRepo(),
+		app.cfg.Idempotency.TTL,
+		app.cfg.Idempotency.LockTimeout,
+	)
+	return app.idempotencyService
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"github.com/4udiwe/avito-pr-service/internal/worker/idempotency_cleanup"
+	"github.com/4udiwe/avito-pr-service/internal/worker/outbox_cleanup"
+	"github.com/4udiwe/avito-pr-service/internal/worker/stale_reviews"
+	"github.com/4udiwe/avito-pr-service/internal/worker/webhooks"
+	"github.com/4udiwe/avito-pr-service/internal/worker/webhooks_cleanup"
+	"github.com/4udiwe/avito-pr-service/pkg/outbox"
+	log "github.com/sirupsen/logrus"
+)
+
+func (app *App) StaleReviewsWorker() *stale_reviews.Worker {
+	if app.staleReviewsWorker != nil {
+		return app.staleReviewsWorker
+	}
+	app.staleReviewsWorker = stale_reviews.New(
+		app.PRService(),
+		app.Postgres(),
+		app.cfg.StaleReviews.Interval,
+		app.cfg.StaleReviews.Threshold,
+		app.cfg.StaleReviews.RetryAfter,
+		app.cfg.StaleReviews.BatchSize,
+	)
+	return app.staleReviewsWorker
+}
+
+func (app *App) WebhooksWorker() *webhooks.Worker {
+	if app.webhooksWorker != nil {
+		return app.webhooksWorker
+	}
+	app.webhooksWorker = webhooks.New(
+		app.WebhookService(),
+		app.cfg.Webhooks.Interval,
+		app.cfg.Webhooks.BatchSize,
+	)
+	return app.webhooksWorker
+}
+
+func (app *App) WebhooksCleanupWorker() *webhooks_cleanup.Worker {
+	if app.webhooksCleanupWorker != nil {
+		return app.webhooksCleanupWorker
+	}
+	app.webhooksCleanupWorker = webhooks_cleanup.New(
+		app.WebhookService(),
+		app.cfg.Webhooks.CleanupInterval,
+		app.cfg.Webhooks.Retention,
+	)
+	return app.webhooksCleanupWorker
+}
+
+// Domain events are published when the outbox is enabled or when webhooks need them
+func (app *App) eventsEnabled() bool {
+	return app.cfg.Outbox.Enabled || app.cfg.Webhooks.Enabled
+}
+
+func (app *App) OutboxRelay() *outbox.Relay {
+	if app.outboxRelay != nil {
+		return app.outboxRelay
+	}
+
+	var sinks []outbox.Sink
+	if app.cfg.Outbox.Enabled {
+		for _, name := range app.cfg.Outbox.Sinks {
+			switch name {
+			case "log":
+				sinks = append(sinks, outbox.NewLogSink())
+			case "http":
+				sinks = append(sinks, outbox.NewHTTPSink(app.cfg.Outbox.HTTPURL, app.cfg.Outbox.HTTPTimeout))
+			case "file":
+				sinks = append(sinks, outbox.NewFileSink(app.cfg.Outbox.FilePath))
+			default:
+				log.Fatalf("app - OutboxRelay - unknown outbox sink %q", name)
+			}
+		}
+	}
+	if app.cfg.Webhooks.Enabled {
+		sinks = append(sinks, app.WebhookService())
+	}
+
+	app.outboxRelay = outbox.NewRelay(
+		app.Postgres(),
+		app.cfg.Outbox.Interval,
+		app.cfg.Outbox.BatchSize,
+		app.cfg.Outbox.MaxAttempts,
+		sinks...,
+	)
+	return app.outboxRelay
+}
+
+func (app *App) OutboxCleanupWorker() *outbox_cleanup.Worker {
+	if app.outboxCleanupWorker != nil {
+		return app.outboxCleanupWorker
+	}
+	app.outboxCleanupWorker = outbox_cleanup.New(
+		app.Postgres(),
+		app.cfg.Outbox.CleanupInterval,
+		app.cfg.Outbox.Retention,
+	)
+	return app.outboxCleanupWorker
+}
+
+func (app *App) IdempotencyCleanupWorker() *idempotency_cleanup.Worker {
+	if app.idempotencyCleanupWorker != nil {
+		return app.idempotencyCleanupWorker
+	}
+	app.idempotencyCleanupWorker = idempotency_cleanup.New(
+		app.IdempotencyService(),
+		app.cfg.Idempotency.CleanupInterval,
+	)
+	return app.idempotencyCleanupWorker
+}
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE app_user ADD COLUMN review_weight REAL NOT NULL DEFAULT 1 CHECK (review_weight > 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE app_user DROP COLUMN IF EXISTS review_weight;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE code_owner (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    pattern TEXT NOT NULL,
+    user_id TEXT REFERENCES app_user(id) ON DELETE CASCADE,
+    team_id UUID REFERENCES team(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    CHECK ((user_id IS NULL) <> (team_id IS NULL))
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS code_owner;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_tag (
+    user_id TEXT NOT NULL REFERENCES app_user(id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    PRIMARY KEY (user_id, tag)
+);
+
+CREATE INDEX idx_user_tag_tag ON user_tag(tag);
+
+CREATE TABLE pr_label (
+    pr_id TEXT NOT NULL REFERENCES pr(id) ON DELETE CASCADE,
+    label TEXT NOT NULL,
+    PRIMARY KEY (pr_id, label)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS pr_label;
+
+DROP INDEX IF EXISTS idx_user_tag_tag;
+
+DROP TABLE IF EXISTS user_tag;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pr_reviewer ADD COLUMN is_fallback BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pr_reviewer DROP COLUMN IF EXISTS is_fallback;
+-- +goose StatementEnd
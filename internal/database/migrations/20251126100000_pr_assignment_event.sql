@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE pr_assignment_event (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    pr_id TEXT NOT NULL REFERENCES pr(id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL CHECK (event_type IN ('assign', 'reassign', 'auto_reassign', 'unassign')),
+    reviewer_id TEXT,
+    previous_reviewer_id TEXT,
+    actor TEXT NOT NULL DEFAULT 'system',
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_pr_assignment_event_pr_id ON pr_assignment_event(pr_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_pr_assignment_event_pr_id;
+
+DROP TABLE IF EXISTS pr_assignment_event;
+-- +goose StatementEnd
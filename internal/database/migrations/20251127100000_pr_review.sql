@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE pr_review (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    pr_id TEXT NOT NULL REFERENCES pr(id) ON DELETE CASCADE,
+    reviewer_id TEXT NOT NULL REFERENCES app_user(id) ON DELETE CASCADE,
+    state TEXT NOT NULL CHECK (state IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_pr_review_pr_reviewer ON pr_review(pr_id, reviewer_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_pr_review_pr_reviewer;
+
+DROP TABLE IF EXISTS pr_review;
+-- +goose StatementEnd
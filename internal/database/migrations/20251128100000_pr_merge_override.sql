@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE pr_merge_override (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    pr_id TEXT NOT NULL REFERENCES pr(id) ON DELETE CASCADE,
+    actor TEXT NOT NULL,
+    unmet_conditions TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_pr_merge_override_pr_id ON pr_merge_override(pr_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_pr_merge_override_pr_id;
+
+DROP TABLE IF EXISTS pr_merge_override;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO pr_status (id, name) VALUES (2, 'DRAFT'), (3, 'CLOSED');
+
+ALTER TABLE pr ADD COLUMN closed_at TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pr DROP COLUMN IF EXISTS closed_at;
+
+DELETE FROM pr_status WHERE name IN ('DRAFT', 'CLOSED');
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE team ADD COLUMN review_sla_hours INT NOT NULL DEFAULT 24 CHECK (review_sla_hours > 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE team DROP COLUMN IF EXISTS review_sla_hours;
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE webhook_subscription (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Events for subscribers, written by the outbox relay with the ID and creation time of the outbox message
+CREATE TABLE webhook_event (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE webhook_delivery (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES webhook_event(id) ON DELETE CASCADE,
+    subscription_id UUID NOT NULL REFERENCES webhook_subscription(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'DELIVERED', 'DEAD')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT NOT NULL DEFAULT '',
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_webhook_delivery_pending ON webhook_delivery(next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX idx_webhook_delivery_dead ON webhook_delivery(created_at) WHERE status = 'DEAD';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_webhook_delivery_dead;
+DROP INDEX IF EXISTS idx_webhook_delivery_pending;
+
+DROP TABLE IF EXISTS webhook_delivery;
+DROP TABLE IF EXISTS webhook_event;
+DROP TABLE IF EXISTS webhook_subscription;
+-- +goose StatementEnd
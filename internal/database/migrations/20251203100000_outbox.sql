@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Domain events written in the same transaction as the change, see pkg/postgres/outbox.go
+CREATE TABLE outbox (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    topic TEXT NOT NULL,
+    key TEXT NOT NULL DEFAULT '',
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT NOT NULL DEFAULT '',
+    published_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_outbox_pending ON outbox(next_attempt_at) WHERE published_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_outbox_pending;
+
+DROP TABLE IF EXISTS outbox;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Responses of mutating requests sent with Idempotency-Key. status_code is NULL while the first request is in progress
+CREATE TABLE idempotency_key (
+    key TEXT NOT NULL,
+    scope TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    status_code INT,
+    content_type TEXT NOT NULL DEFAULT '',
+    response_body BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (key, scope)
+);
+
+CREATE INDEX idx_idempotency_key_expires_at ON idempotency_key(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_idempotency_key_expires_at;
+
+DROP TABLE IF EXISTS idempotency_key;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Stale reviews worker skips the review until this moment. NULL means the review is checked once it is old enough
+ALTER TABLE pr_reviewer ADD COLUMN stale_check_after TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pr_reviewer DROP COLUMN IF EXISTS stale_check_after;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Sinks which already accepted the message, a retry after partial failure skips them
+ALTER TABLE outbox ADD COLUMN published_sinks TEXT[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE outbox DROP COLUMN IF EXISTS published_sinks;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Review SLA stats read only assignments within a window
+CREATE INDEX idx_pr_reviewer_assigned_at ON pr_reviewer(assigned_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_pr_reviewer_assigned_at;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Cleanup worker deletes old delivered deliveries, then events without deliveries
+CREATE INDEX idx_webhook_delivery_delivered ON webhook_delivery(delivered_at) WHERE status = 'DELIVERED';
+CREATE INDEX idx_webhook_delivery_event_id ON webhook_delivery(event_id);
+CREATE INDEX idx_webhook_event_created_at ON webhook_event(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_webhook_event_created_at;
+DROP INDEX IF EXISTS idx_webhook_delivery_event_id;
+DROP INDEX IF EXISTS idx_webhook_delivery_delivered;
+-- +goose StatementEnd
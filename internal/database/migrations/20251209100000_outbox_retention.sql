@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Message is dead when it failed max attempts, relay no longer claims it
+ALTER TABLE outbox ADD COLUMN dead_at TIMESTAMPTZ;
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX idx_outbox_pending ON outbox(next_attempt_at) WHERE published_at IS NULL AND dead_at IS NULL;
+-- Cleanup worker deletes published messages after retention
+CREATE INDEX idx_outbox_published ON outbox(published_at) WHERE published_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_outbox_published;
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX idx_outbox_pending ON outbox(next_attempt_at) WHERE published_at IS NULL;
+
+ALTER TABLE outbox DROP COLUMN IF EXISTS dead_at;
+-- +goose StatementEnd
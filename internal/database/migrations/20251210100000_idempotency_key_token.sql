@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Identifies the reservation, so a request whose key was taken over after lock_timeout cannot complete or release the new one
+ALTER TABLE idempotency_key ADD COLUMN token UUID NOT NULL DEFAULT gen_random_uuid();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE idempotency_key DROP COLUMN IF EXISTS token;
+-- +goose StatementEnd
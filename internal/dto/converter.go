@@ -24,18 +24,23 @@ func (m *TeamMember) ToEntity() *entity.User {
 
 func (t *Team) FillFromEntity(e entity.Team) {
 	t.TeamName = e.Name
+	t.RequiredReviewers = lo.ToPtr(e.ReviewersRequired())
+	t.ReviewSlaHours = lo.ToPtr(int(e.ReviewSLA().Hours()))
 	t.Members = lo.Map(e.Members, func(u entity.User, _ int) TeamMember {
 		return TeamMember{
 			IsActive: u.IsActive,
 			UserId:   u.ID,
 			Username: u.Name,
+			Tags:     sliceToPtr(u.Tags),
 		}
 	})
 }
 
 func (t *Team) ToEntity() *entity.Team {
 	return &entity.Team{
-		Name: t.TeamName,
+		Name:              t.TeamName,
+		RequiredReviewers: lo.FromPtr(t.RequiredReviewers),
+		ReviewSLAHours:    lo.FromPtr(t.ReviewSlaHours),
 		Members: lo.Map(t.Members, func(m TeamMember, _ int) entity.User {
 			return *m.ToEntity()
 		}),
@@ -45,9 +50,21 @@ func (t *Team) ToEntity() *entity.Team {
 func (pr *PullRequest) FillFromEntity(e entity.PullRequest) {
 	pr.PullRequestId = e.ID
 	pr.PullRequestName = e.Title
+	pr.Description = &e.Description
 	pr.AuthorId = e.AuthorID
 	pr.Status = PullRequestStatus(e.Status.Name)
 	pr.AssignedReviewers = e.Reviewers
 	pr.CreatedAt = &e.CreatedAt
 	pr.MergedAt = e.MergedAt
+	pr.ClosedAt = e.ClosedAt
+	pr.Labels = sliceToPtr(e.Labels)
+	pr.FallbackReviewers = sliceToPtr(e.FallbackReviewers)
+}
+
+// Optional array fields are omitted when entity didn't load them
+func sliceToPtr(s []string) *[]string {
+	if s == nil {
+		return nil
+	}
+	return &s
 }
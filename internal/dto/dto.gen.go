@@ -14,22 +14,49 @@ const (
 
 // Defines values for ErrorResponseErrorCode.
 const (
-	NOCANDIDATE ErrorResponseErrorCode = "NO_CANDIDATE"
-	NOTASSIGNED ErrorResponseErrorCode = "NOT_ASSIGNED"
-	NOTFOUND    ErrorResponseErrorCode = "NOT_FOUND"
-	PREXISTS    ErrorResponseErrorCode = "PR_EXISTS"
-	PRMERGED    ErrorResponseErrorCode = "PR_MERGED"
-	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
+	FORBIDDEN            ErrorResponseErrorCode = "FORBIDDEN"
+	IDEMPOTENCYKEYINUSE  ErrorResponseErrorCode = "IDEMPOTENCY_KEY_IN_USE"
+	IDEMPOTENCYKEYREUSED ErrorResponseErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	INVALIDINPUT         ErrorResponseErrorCode = "INVALID_INPUT"
+	INVALIDSTATE         ErrorResponseErrorCode = "INVALID_STATE"
+	MERGEBLOCKED         ErrorResponseErrorCode = "MERGE_BLOCKED"
+	NOCANDIDATE          ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTASSIGNED          ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTFOUND             ErrorResponseErrorCode = "NOT_FOUND"
+	NOTTEAMMEMBER        ErrorResponseErrorCode = "NOT_TEAM_MEMBER"
+	PREXISTS             ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED             ErrorResponseErrorCode = "PR_MERGED"
+	TEAMEXISTS           ErrorResponseErrorCode = "TEAM_EXISTS"
+	UNAUTHORIZED         ErrorResponseErrorCode = "UNAUTHORIZED"
+	USERINOTHERTEAM      ErrorResponseErrorCode = "USER_IN_OTHER_TEAM"
+)
+
+// Defines values for ErrorResponseErrorUnmetConditions.
+const (
+	MINAPPROVALS       ErrorResponseErrorUnmetConditions = "MIN_APPROVALS"
+	NOCHANGESREQUESTED ErrorResponseErrorUnmetConditions = "NO_CHANGES_REQUESTED"
+	TEAMAPPROVAL       ErrorResponseErrorUnmetConditions = "TEAM_APPROVAL"
 )
 
 // Defines values for PullRequestStatus.
 const (
+	PullRequestStatusCLOSED PullRequestStatus = "CLOSED"
+	PullRequestStatusDRAFT  PullRequestStatus = "DRAFT"
 	PullRequestStatusMERGED PullRequestStatus = "MERGED"
 	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
 )
 
+// Defines values for PullRequestShortReviewState.
+const (
+	APPROVED         PullRequestShortReviewState = "APPROVED"
+	CHANGESREQUESTED PullRequestShortReviewState = "CHANGES_REQUESTED"
+	COMMENTED        PullRequestShortReviewState = "COMMENTED"
+)
+
 // Defines values for PullRequestShortStatus.
 const (
+	PullRequestShortStatusCLOSED PullRequestShortStatus = "CLOSED"
+	PullRequestShortStatusDRAFT  PullRequestShortStatus = "DRAFT"
 	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
 	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
 )
@@ -39,22 +66,38 @@ type ErrorResponse struct {
 	Error struct {
 		Code    ErrorResponseErrorCode `json:"code"`
 		Message string                 `json:"message"`
+
+		// UnmetConditions Невыполненные условия политики мерджа (только для MERGE_BLOCKED)
+		UnmetConditions *[]ErrorResponseErrorUnmetConditions `json:"unmet_conditions,omitempty"`
 	} `json:"error"`
 }
 
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// ErrorResponseErrorUnmetConditions defines model for ErrorResponse.Error.UnmetConditions.
+type ErrorResponseErrorUnmetConditions string
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
-	// AssignedReviewers user_id назначенных ревьюверов (0..2)
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	AuthorId          string            `json:"author_id"`
-	CreatedAt         *time.Time        `json:"createdAt"`
-	MergedAt          *time.Time        `json:"mergedAt"`
-	PullRequestId     string            `json:"pull_request_id"`
-	PullRequestName   string            `json:"pull_request_name"`
-	Status            PullRequestStatus `json:"status"`
+	// AssignedReviewers user_id назначенных ревьюверов (0..required_reviewers команды автора)
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	AuthorId          string     `json:"author_id"`
+	ClosedAt          *time.Time `json:"closedAt"`
+	CreatedAt         *time.Time `json:"createdAt"`
+
+	// Description Описание PR. Изменяется через PATCH /pullRequest
+	Description *string `json:"description,omitempty"`
+
+	// FallbackReviewers Часть assigned_reviewers, назначенная из команд-партнеров (buddy teams), когда в команде автора не хватило активных ревьюверов
+	FallbackReviewers *[]string `json:"fallback_reviewers,omitempty"`
+
+	// Labels Метки PR, по которым предпочтительно выбираются ревьюверы с совпадающими тегами
+	Labels          *[]string         `json:"labels,omitempty"`
+	MergedAt        *time.Time        `json:"mergedAt"`
+	PullRequestId   string            `json:"pull_request_id"`
+	PullRequestName string            `json:"pull_request_name"`
+	Status          PullRequestStatus `json:"status"`
 }
 
 // PullRequestStatus defines model for PullRequest.Status.
@@ -62,26 +105,41 @@ type PullRequestStatus string
 
 // PullRequestShort defines model for PullRequestShort.
 type PullRequestShort struct {
-	AuthorId        string                 `json:"author_id"`
-	PullRequestId   string                 `json:"pull_request_id"`
-	PullRequestName string                 `json:"pull_request_name"`
-	Status          PullRequestShortStatus `json:"status"`
+	AuthorId        string `json:"author_id"`
+	PullRequestId   string `json:"pull_request_id"`
+	PullRequestName string `json:"pull_request_name"`
+
+	// ReviewState Последнее решение пользователя по PR, null если решение еще не отправлено
+	ReviewState *PullRequestShortReviewState `json:"review_state"`
+	Status      PullRequestShortStatus       `json:"status"`
 }
 
+// PullRequestShortReviewState Последнее решение пользователя по PR, null если решение еще не отправлено
+type PullRequestShortReviewState string
+
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
 // Team defines model for Team.
 type Team struct {
-	Members  []TeamMember `json:"members"`
-	TeamName string       `json:"team_name"`
+	Members []TeamMember `json:"members"`
+
+	// RequiredReviewers Сколько ревьюверов назначается на PR автора из этой команды
+	RequiredReviewers *int `json:"required_reviewers,omitempty"`
+
+	// ReviewSlaHours За сколько рабочих часов (без суббот и воскресений) ревьювер должен принять решение по PR
+	ReviewSlaHours *int   `json:"review_sla_hours,omitempty"`
+	TeamName       string `json:"team_name"`
 }
 
 // TeamMember defines model for TeamMember.
 type TeamMember struct {
-	IsActive bool   `json:"is_active"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	IsActive bool `json:"is_active"`
+
+	// Tags Теги экспертизы пользователя. Изменяются через /users/addTags и /users/removeTags
+	Tags     *[]string `json:"tags,omitempty"`
+	UserId   string    `json:"user_id"`
+	Username string    `json:"username"`
 }
 
 // User defines model for User.
@@ -92,6 +150,9 @@ type User struct {
 	Username string `json:"username"`
 }
 
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
 // TeamNameQuery defines model for TeamNameQuery.
 type TeamNameQuery = string
 
@@ -100,20 +161,75 @@ type UserIdQuery = string
 
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
-	AuthorId        string `json:"author_id"`
-	PullRequestId   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
+	AuthorId string `json:"author_id"`
+
+	// ChangedFiles Пути измененных файлов. Владельцы файлов (code owners) назначаются ревьюерами в первую очередь
+	ChangedFiles *[]string `json:"changed_files,omitempty"`
+
+	// Draft Создать PR в статусе DRAFT, ревьюверы назначаются после перевода в OPEN
+	Draft *bool `json:"draft,omitempty"`
+
+	// Labels Метки PR. Ревьюверы, чьи теги совпадают с метками, назначаются в первую очередь
+	Labels          *[]string `json:"labels,omitempty"`
+	PullRequestId   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+}
+
+// PostPullRequestCreateParams defines parameters for PostPullRequestCreate.
+type PostPullRequestCreateParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ сохраняется и возвращается повторно на запросы с тем же ключом
+	// (с заголовком Idempotent-Replayed: true). Тот же ключ с другим телом запроса отклоняется
+	// с кодом 422 IDEMPOTENCY_KEY_REUSED, дубликат во время выполнения первого запроса — 409 IDEMPOTENCY_KEY_IN_USE
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
 }
 
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
 type PostPullRequestMergeJSONBody struct {
+	// Force Смерджить в обход политики мерджа, обход записывается в аудит
+	Force         *bool  `json:"force,omitempty"`
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// PostPullRequestMergeParams defines parameters for PostPullRequestMerge.
+type PostPullRequestMergeParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ сохраняется и возвращается повторно на запросы с тем же ключом
+	// (с заголовком Idempotent-Replayed: true). Тот же ключ с другим телом запроса отклоняется
+	// с кодом 422 IDEMPOTENCY_KEY_REUSED, дубликат во время выполнения первого запроса — 409 IDEMPOTENCY_KEY_IN_USE
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
 // PostPullRequestReassignJSONBody defines parameters for PostPullRequestReassign.
 type PostPullRequestReassignJSONBody struct {
-	OldUserId     string `json:"old_user_id"`
-	PullRequestId string `json:"pull_request_id"`
+	// NewUserId Новый ревьювер. Если не передан, выбирается участник команды старого ревьювера
+	NewUserId     *string `json:"new_user_id,omitempty"`
+	OldUserId     string  `json:"old_user_id"`
+	PullRequestId string  `json:"pull_request_id"`
+}
+
+// PostPullRequestReassignParams defines parameters for PostPullRequestReassign.
+type PostPullRequestReassignParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ сохраняется и возвращается повторно на запросы с тем же ключом
+	// (с заголовком Idempotent-Replayed: true). Тот же ключ с другим телом запроса отклоняется
+	// с кодом 422 IDEMPOTENCY_KEY_REUSED, дубликат во время выполнения первого запроса — 409 IDEMPOTENCY_KEY_IN_USE
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PostTeamAddParams defines parameters for PostTeamAdd.
+type PostTeamAddParams struct {
+	// Upsert Режим upsert: в одной транзакции новые пользователи создаются, имена существующих обновляются,
+	// участники других команд и пользователи без команды переводятся в эту команду.
+	// Активность существующих пользователей не меняется. Ответ содержит статус каждого пользователя.
+	// Доступен только team_lead (своей команды) и admin
+	Upsert *bool `form:"upsert,omitempty" json:"upsert,omitempty"`
+
+	// ReassignReviews Только с upsert=true: открытые ревью переведенных пользователей передаются участникам прежней команды,
+	// как в POST /team/moveMember. По умолчанию ревью остаются за пользователями
+	ReassignReviews *bool `form:"reassign_reviews,omitempty" json:"reassign_reviews,omitempty"`
+
+	// IdempotencyKey Ключ идемпотентности. Первый ответ сохраняется и возвращается повторно на запросы с тем же ключом
+	// (с заголовком Idempotent-Replayed: true). Тот же ключ с другим телом запроса отклоняется
+	// с кодом 422 IDEMPOTENCY_KEY_REUSED, дубликат во время выполнения первого запроса — 409 IDEMPOTENCY_KEY_IN_USE
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
 }
 
 // GetTeamGetParams defines parameters for GetTeamGet.
@@ -134,6 +250,14 @@ type PostUsersSetIsActiveJSONBody struct {
 	UserId   string `json:"user_id"`
 }
 
+// PostUsersSetIsActiveParams defines parameters for PostUsersSetIsActive.
+type PostUsersSetIsActiveParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ сохраняется и возвращается повторно на запросы с тем же ключом
+	// (с заголовком Idempotent-Replayed: true). Тот же ключ с другим телом запроса отклоняется
+	// с кодом 422 IDEMPOTENCY_KEY_REUSED, дубликат во время выполнения первого запроса — 409 IDEMPOTENCY_KEY_IN_USE
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
 // PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
 type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
 
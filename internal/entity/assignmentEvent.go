@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AssignmentEventType string
+
+const (
+	AssignmentEventAssign       AssignmentEventType = "assign"
+	AssignmentEventReassign     AssignmentEventType = "reassign"
+	AssignmentEventAutoReassign AssignmentEventType = "auto_reassign"
+	AssignmentEventUnassign     AssignmentEventType = "unassign"
+)
+
+// Append-only record of a reviewer change on a PR.
+// ReviewerID is empty for unassign, PreviousReviewerID is empty for assign
+type AssignmentEvent struct {
+	ID                 uuid.UUID
+	PRID               string
+	Type               AssignmentEventType
+	ReviewerID         string
+	PreviousReviewerID string
+	Actor              string
+	Reason             string
+	CreatedAt          time.Time
+}
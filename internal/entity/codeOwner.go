@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CODEOWNERS-style rule: files matching the pattern are owned by a user or by a team.
+// Exactly one of UserID and TeamID is set
+type CodeOwner struct {
+	ID        uuid.UUID
+	Pattern   string
+	UserID    string
+	TeamID    uuid.UUID
+	TeamName  string
+	CreatedAt time.Time
+}
+
+// Reports whether file is covered by the rule:
+//   - pattern without slash ("*.go", "docs") matches any path segment
+//   - pattern ending with "/" or "/**" matches everything inside the directory
+//   - other patterns are matched against the whole path and each of its parent directories
+func (o CodeOwner) Matches(filePath string) bool {
+	pattern := strings.TrimPrefix(o.Pattern, "/")
+	filePath = strings.TrimPrefix(filePath, "/")
+
+	if pattern == "" || filePath == "" {
+		return false
+	}
+
+	if dir, ok := strings.CutSuffix(pattern, "**"); ok && strings.HasSuffix(dir, "/") {
+		pattern = dir
+	}
+	if strings.HasSuffix(pattern, "/") {
+		return strings.HasPrefix(filePath, pattern)
+	}
+
+	if !strings.Contains(pattern, "/") {
+		for _, segment := range strings.Split(filePath, "/") {
+			if ok, _ := path.Match(pattern, segment); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	for p := filePath; p != "." && p != "/"; p = path.Dir(p) {
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Change of domain state published through the outbox. EventType is the outbox topic,
+// AggregateID identifies the changed entity. Every event carries the actor of the change
+type DomainEvent interface {
+	EventType() string
+	AggregateID() string
+}
+
+const (
+	EventPRCreated                 = "pr.created"
+	EventPRUpdated                 = "pr.updated"
+	EventPRAuthorChanged           = "pr.author_changed"
+	EventPRStatusChanged           = "pr.status_changed"
+	EventPRMerged                  = "pr.merged"
+	EventReviewSubmitted           = "review.submitted"
+	EventReviewerAssigned          = "reviewer.assigned"
+	EventReviewerUnassigned        = "reviewer.unassigned"
+	EventTeamCreated               = "team.created"
+	EventTeamDeactivated           = "team.deactivated"
+	EventUserStatusChanged         = "user.status_changed"
+	EventUserReviewWeightChanged   = "user.review_weight_changed"
+	EventUserUnavailabilityAdded   = "user.unavailability_added"
+	EventUserUnavailabilityDeleted = "user.unavailability_deleted"
+	EventUserTagsChanged           = "user.tags_changed"
+	EventUserTeamChanged           = "user.team_changed"
+	EventUserRenamed               = "user.renamed"
+)
+
+type PRCreated struct {
+	PRID      string       `json:"pull_request_id"`
+	Title     string       `json:"pull_request_name"`
+	AuthorID  string       `json:"author_id"`
+	Status    PRStatusName `json:"status"`
+	Reviewers []string     `json:"assigned_reviewers"`
+	Labels    []string     `json:"labels"`
+	Actor     string       `json:"actor"`
+}
+
+func (e PRCreated) EventType() string   { return EventPRCreated }
+func (e PRCreated) AggregateID() string { return e.PRID }
+
+type PRUpdated struct {
+	PRID        string `json:"pull_request_id"`
+	Title       string `json:"pull_request_name"`
+	Description string `json:"description"`
+	Actor       string `json:"actor"`
+}
+
+func (e PRUpdated) EventType() string   { return EventPRUpdated }
+func (e PRUpdated) AggregateID() string { return e.PRID }
+
+type PRAuthorChanged struct {
+	PRID             string `json:"pull_request_id"`
+	AuthorID         string `json:"author_id"`
+	PreviousAuthorID string `json:"previous_author_id"`
+	Actor            string `json:"actor"`
+}
+
+func (e PRAuthorChanged) EventType() string   { return EventPRAuthorChanged }
+func (e PRAuthorChanged) AggregateID() string { return e.PRID }
+
+// PR moved between DRAFT, OPEN and CLOSED. Merge is published as PRMerged
+type PRStatusChanged struct {
+	PRID   string       `json:"pull_request_id"`
+	From   PRStatusName `json:"from"`
+	To     PRStatusName `json:"to"`
+	Action PRAction     `json:"action"`
+	Actor  string       `json:"actor"`
+}
+
+func (e PRStatusChanged) EventType() string   { return EventPRStatusChanged }
+func (e PRStatusChanged) AggregateID() string { return e.PRID }
+
+type PRMerged struct {
+	PRID     string    `json:"pull_request_id"`
+	Title    string    `json:"pull_request_name"`
+	AuthorID string    `json:"author_id"`
+	MergedAt time.Time `json:"merged_at"`
+	Actor    string    `json:"actor"`
+	Forced   bool      `json:"forced"`
+}
+
+func (e PRMerged) EventType() string   { return EventPRMerged }
+func (e PRMerged) AggregateID() string { return e.PRID }
+
+type ReviewSubmitted struct {
+	ReviewID   uuid.UUID   `json:"review_id"`
+	PRID       string      `json:"pull_request_id"`
+	ReviewerID string      `json:"reviewer_id"`
+	State      ReviewState `json:"state"`
+	Actor      string      `json:"actor"`
+}
+
+func (e ReviewSubmitted) EventType() string   { return EventReviewSubmitted }
+func (e ReviewSubmitted) AggregateID() string { return e.PRID }
+
+// Reviewer was assigned on creation, manually or automatically
+type ReviewerAssigned struct {
+	PRID               string `json:"pull_request_id"`
+	ReviewerID         string `json:"reviewer_id"`
+	PreviousReviewerID string `json:"previous_reviewer_id,omitempty"`
+	AssignmentType     string `json:"assignment_type"`
+	Actor              string `json:"actor"`
+	Reason             string `json:"reason"`
+}
+
+func (e ReviewerAssigned) EventType() string   { return EventReviewerAssigned }
+func (e ReviewerAssigned) AggregateID() string { return e.PRID }
+
+type ReviewerUnassigned struct {
+	PRID       string `json:"pull_request_id"`
+	ReviewerID string `json:"reviewer_id"`
+	Actor      string `json:"actor"`
+	Reason     string `json:"reason"`
+}
+
+func (e ReviewerUnassigned) EventType() string   { return EventReviewerUnassigned }
+func (e ReviewerUnassigned) AggregateID() string { return e.PRID }
+
+type TeamCreated struct {
+	TeamName          string   `json:"team_name"`
+	RequiredReviewers int      `json:"required_reviewers"`
+	ReviewSLAHours    int      `json:"review_sla_hours"`
+	MemberIDs         []string `json:"member_ids"`
+	Actor             string   `json:"actor"`
+}
+
+func (e TeamCreated) EventType() string   { return EventTeamCreated }
+func (e TeamCreated) AggregateID() string { return e.TeamName }
+
+type TeamDeactivated struct {
+	TeamName           string   `json:"team_name"`
+	DeactivatedUserIDs []string `json:"deactivated_user_ids"`
+	Actor              string   `json:"actor"`
+}
+
+func (e TeamDeactivated) EventType() string   { return EventTeamDeactivated }
+func (e TeamDeactivated) AggregateID() string { return e.TeamName }
+
+type UserStatusChanged struct {
+	UserID   string `json:"user_id"`
+	IsActive bool   `json:"is_active"`
+	Actor    string `json:"actor"`
+}
+
+func (e UserStatusChanged) EventType() string   { return EventUserStatusChanged }
+func (e UserStatusChanged) AggregateID() string { return e.UserID }
+
+type UserReviewWeightChanged struct {
+	UserID string  `json:"user_id"`
+	Weight float64 `json:"review_weight"`
+	Actor  string  `json:"actor"`
+}
+
+func (e UserReviewWeightChanged) EventType() string   { return EventUserReviewWeightChanged }
+func (e UserReviewWeightChanged) AggregateID() string { return e.UserID }
+
+type UserUnavailabilityAdded struct {
+	ID       uuid.UUID `json:"id"`
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+	Actor    string    `json:"actor"`
+}
+
+func (e UserUnavailabilityAdded) EventType() string   { return EventUserUnavailabilityAdded }
+func (e UserUnavailabilityAdded) AggregateID() string { return e.UserID }
+
+// UserID is not known when the period is deleted by ID, so the aggregate is the period itself
+type UserUnavailabilityDeleted struct {
+	ID    uuid.UUID `json:"id"`
+	Actor string    `json:"actor"`
+}
+
+func (e UserUnavailabilityDeleted) EventType() string   { return EventUserUnavailabilityDeleted }
+func (e UserUnavailabilityDeleted) AggregateID() string { return e.ID.String() }
+
+type UserTagsChanged struct {
+	UserID string   `json:"user_id"`
+	Tags   []string `json:"tags"`
+	Actor  string   `json:"actor"`
+}
+
+func (e UserTagsChanged) EventType() string   { return EventUserTagsChanged }
+func (e UserTagsChanged) AggregateID() string { return e.UserID }
+
+// User added to, removed from or moved between teams. Empty team means no team
+type UserTeamChanged struct {
+	UserID       string `json:"user_id"`
+	TeamName     string `json:"team_name"`
+	PreviousTeam string `json:"previous_team_name"`
+	Actor        string `json:"actor"`
+}
+
+func (e UserTeamChanged) EventType() string   { return EventUserTeamChanged }
+func (e UserTeamChanged) AggregateID() string { return e.UserID }
+
+type UserRenamed struct {
+	UserID       string `json:"user_id"`
+	Name         string `json:"username"`
+	PreviousName string `json:"previous_username"`
+	Actor        string `json:"actor"`
+}
+
+func (e UserRenamed) EventType() string   { return EventUserRenamed }
+func (e UserRenamed) AggregateID() string { return e.UserID }
+
+// Domain events for reviewer assignment history: ReviewerUnassigned for unassignments, ReviewerAssigned for the rest
+func AssignmentDomainEvents(events []AssignmentEvent) []DomainEvent {
+	result := make([]DomainEvent, 0, len(events))
+	for _, e := range events {
+		if e.Type == AssignmentEventUnassign {
+			result = append(result, ReviewerUnassigned{
+				PRID:       e.PRID,
+				ReviewerID: e.PreviousReviewerID,
+				Actor:      e.Actor,
+				Reason:     e.Reason,
+			})
+			continue
+		}
+		result = append(result, ReviewerAssigned{
+			PRID:               e.PRID,
+			ReviewerID:         e.ReviewerID,
+			PreviousReviewerID: e.PreviousReviewerID,
+			AssignmentType:     string(e.Type),
+			Actor:              e.Actor,
+			Reason:             e.Reason,
+		})
+	}
+	return result
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stored response of a request sent with Idempotency-Key. Scope is the route and the caller,
+// so the same key of different callers or routes does not collide. Token identifies the reservation:
+// a request whose reservation was taken over after the lock timeout cannot complete or release the new one
+type IdempotencyRecord struct {
+	Key         string
+	Scope       string
+	RequestHash string
+	Token       uuid.UUID
+	StatusCode  int // 0 while the first request is in progress
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func (r IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
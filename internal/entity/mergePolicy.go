@@ -0,0 +1,20 @@
+package entity
+
+type MergeCondition string
+
+const (
+	MergeConditionMinApprovals       MergeCondition = "MIN_APPROVALS"
+	MergeConditionNoChangesRequested MergeCondition = "NO_CHANGES_REQUESTED"
+	MergeConditionTeamApproval       MergeCondition = "TEAM_APPROVAL"
+)
+
+// Conditions PR has to satisfy before it can be merged. Zero value allows any open PR to be merged
+type MergePolicy struct {
+	MinApprovals            int
+	BlockOnChangesRequested bool
+	RequireTeamApproval     bool // At least one approver is from the author's team
+}
+
+func (p MergePolicy) IsEmpty() bool {
+	return p.MinApprovals <= 0 && !p.BlockOnChangesRequested && !p.RequireTeamApproval
+}
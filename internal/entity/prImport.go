@@ -0,0 +1,18 @@
+package entity
+
+// PR of the bulk import, created the same way as a single PR
+type PRImport struct {
+	ID           string
+	Title        string
+	AuthorID     string
+	ChangedFiles []string
+	Labels       []string
+	Draft        bool
+}
+
+// Outcome of one imported PR. Err is nil when PR is created
+type PRImportResult struct {
+	Index int
+	PR    PullRequest
+	Err   error
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"errors"
+	"slices"
+)
+
+// PRAction is an operation on PR that is validated by the PR state machine.
+// Some actions move PR to another status, others are only allowed in certain statuses
+type PRAction string
+
+const (
+	ActionReady  PRAction = "ready"  // DRAFT -> OPEN
+	ActionMerge  PRAction = "merge"  // OPEN -> MERGED
+	ActionClose  PRAction = "close"  // DRAFT, OPEN -> CLOSED
+	ActionReopen PRAction = "reopen" // CLOSED -> OPEN
+
+	ActionAssignReviewer   PRAction = "assign_reviewer"   // OPEN
+	ActionReassignReviewer PRAction = "reassign_reviewer" // OPEN
+	ActionUnassignReviewer PRAction = "unassign_reviewer" // OPEN
+	ActionSubmitReview     PRAction = "submit_review"     // OPEN
+	ActionUpdateMetadata   PRAction = "update_metadata"   // DRAFT, OPEN, CLOSED
+	ActionTransferAuthor   PRAction = "transfer_author"   // DRAFT, OPEN, CLOSED
+)
+
+var (
+	ErrPRMerged       = errors.New("PR is merged")
+	ErrInvalidPRState = errors.New("action is not allowed in current PR status")
+)
+
+type prActionRule struct {
+	from []PRStatusName
+	to   PRStatusName // Empty when action keeps the status
+}
+
+var prStateMachine = map[PRAction]prActionRule{
+	ActionReady:  {from: []PRStatusName{StatusDRAFT}, to: StatusOPEN},
+	ActionMerge:  {from: []PRStatusName{StatusOPEN}, to: StatusMERGED},
+	ActionClose:  {from: []PRStatusName{StatusDRAFT, StatusOPEN}, to: StatusCLOSED},
+	ActionReopen: {from: []PRStatusName{StatusCLOSED}, to: StatusOPEN},
+
+	ActionAssignReviewer:   {from: []PRStatusName{StatusOPEN}},
+	ActionReassignReviewer: {from: []PRStatusName{StatusOPEN}},
+	ActionUnassignReviewer: {from: []PRStatusName{StatusOPEN}},
+	ActionSubmitReview:     {from: []PRStatusName{StatusOPEN}},
+	ActionUpdateMetadata:   {from: []PRStatusName{StatusDRAFT, StatusOPEN, StatusCLOSED}},
+	ActionTransferAuthor:   {from: []PRStatusName{StatusDRAFT, StatusOPEN, StatusCLOSED}},
+}
+
+// Apply returns the status PR has after the action.
+// Fails with ErrPRMerged for merged PR and with ErrInvalidPRState for other disallowed statuses
+func (a PRAction) Apply(from PRStatusName) (PRStatusName, error) {
+	rule, exists := prStateMachine[a]
+	if !exists || !slices.Contains(rule.from, from) {
+		if from == StatusMERGED {
+			return "", ErrPRMerged
+		}
+		return "", ErrInvalidPRState
+	}
+
+	if rule.to == "" {
+		return from, nil
+	}
+	return rule.to, nil
+}
+
+// Allowed reports whether the action can be applied to PR in the given status
+func (a PRAction) Allowed(from PRStatusName) bool {
+	_, err := a.Apply(from)
+	return err == nil
+}
@@ -7,8 +7,8 @@ type PRStatusName string
 const (
 	StatusOPEN   PRStatusName = "OPEN"
 	StatusMERGED PRStatusName = "MERGED"
-
-	MinAmountOfReviewers int = 2
+	StatusDRAFT  PRStatusName = "DRAFT"  // Work in progress, reviewers are not assigned
+	StatusCLOSED PRStatusName = "CLOSED" // Abandoned without merge, reviewers are released
 )
 
 type Status struct {
@@ -19,12 +19,17 @@ type Status struct {
 type PullRequest struct {
 	ID                string
 	Title             string
+	Description       string
 	AuthorID          string
 	Status            Status
 	NeedMoreReviewers bool
 	CreatedAt         time.Time
 	MergedAt          *time.Time
+	ClosedAt          *time.Time
 	Reviewers         []string
+	FallbackReviewers []string // Subset of Reviewers picked from buddy teams
+	Labels            []string
+	Reviews           []Review // Latest decision of each reviewer who submitted one
 }
 
 type PRReviewer struct {
@@ -32,4 +37,13 @@ type PRReviewer struct {
 	PRID       string
 	ReviewerID string
 	AssignedAt time.Time
+	IsFallback bool // Reviewer was picked from a buddy team
+}
+
+// Result of moving one review from a deactivated reviewer.
+// Empty NewReviewerID means no active candidate was found and the review stayed with the old reviewer
+type Reassignment struct {
+	PRID          string
+	OldReviewerID string
+	NewReviewerID string
 }
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReviewState string
+
+const (
+	ReviewApproved         ReviewState = "APPROVED"
+	ReviewChangesRequested ReviewState = "CHANGES_REQUESTED"
+	ReviewCommented        ReviewState = "COMMENTED"
+)
+
+func (s ReviewState) IsValid() bool {
+	switch s {
+	case ReviewApproved, ReviewChangesRequested, ReviewCommented:
+		return true
+	}
+	return false
+}
+
+// Decision submitted by a reviewer. Reviewer can submit several times, the latest one is the current state
+type Review struct {
+	ID         uuid.UUID
+	PRID       string
+	ReviewerID string
+	State      ReviewState
+	Comment    string
+	CreatedAt  time.Time
+}
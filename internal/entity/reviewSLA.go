@@ -0,0 +1,58 @@
+package entity
+
+import "time"
+
+// Review assignment without a decision from the reviewer after the team's review SLA has passed
+type OverdueReview struct {
+	PRID       string
+	Title      string
+	AuthorID   string
+	TeamName   string
+	ReviewerID string
+	AssignedAt time.Time
+	ReviewSLA  time.Duration
+	Overdue    time.Duration // Working time passed after the SLA
+}
+
+// Assignment of a reviewer used to measure SLA compliance. DecidedAt is nil while there is no decision
+type ReviewSLASample struct {
+	TeamName   string
+	ReviewSLA  time.Duration
+	AssignedAt time.Time
+	DecidedAt  *time.Time
+}
+
+// WorkingTime returns time between from and to without Saturdays and Sundays (UTC)
+func WorkingTime(from, to time.Time) time.Duration {
+	from, to = from.UTC(), to.UTC()
+
+	var total time.Duration
+	for from.Before(to) {
+		dayEnd := time.Date(from.Year(), from.Month(), from.Day()+1, 0, 0, 0, 0, time.UTC)
+		if dayEnd.After(to) {
+			dayEnd = to
+		}
+		if weekday := from.Weekday(); weekday != time.Saturday && weekday != time.Sunday {
+			total += dayEnd.Sub(from)
+		}
+		from = dayEnd
+	}
+	return total
+}
+
+// WorkingDeadline returns the moment when working time since from reaches d
+func WorkingDeadline(from time.Time, d time.Duration) time.Time {
+	from = from.UTC()
+
+	for {
+		dayEnd := time.Date(from.Year(), from.Month(), from.Day()+1, 0, 0, 0, 0, time.UTC)
+		if weekday := from.Weekday(); weekday != time.Saturday && weekday != time.Sunday {
+			left := dayEnd.Sub(from)
+			if d <= left {
+				return from.Add(d)
+			}
+			d -= left
+		}
+		from = dayEnd
+	}
+}
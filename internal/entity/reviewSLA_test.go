@@ -0,0 +1,69 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// 2025-12-05 is Friday
+func at(day, hour int) time.Time {
+	return time.Date(2025, time.December, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestWorkingTime(t *testing.T) {
+	tests := []struct {
+		name string
+		from time.Time
+		to   time.Time
+		want time.Duration
+	}{
+		{name: "same day", from: at(1, 10), to: at(1, 15), want: 5 * time.Hour},
+		{name: "several working days", from: at(1, 10), to: at(3, 10), want: 48 * time.Hour},
+		{name: "crosses weekend", from: at(5, 18), to: at(8, 10), want: 16 * time.Hour},
+		{name: "starts on Saturday", from: at(6, 12), to: at(8, 9), want: 9 * time.Hour},
+		{name: "starts on Sunday", from: at(7, 23), to: at(9, 1), want: 25 * time.Hour},
+		{name: "ends on weekend", from: at(5, 20), to: at(7, 10), want: 4 * time.Hour},
+		{name: "whole weekend", from: at(6, 0), to: at(8, 0), want: 0},
+		{name: "to before from", from: at(3, 10), to: at(1, 10), want: 0},
+		{
+			name: "counted in UTC",
+			from: time.Date(2025, time.December, 8, 2, 0, 0, 0, time.FixedZone("MSK", 3*60*60)),
+			to:   at(8, 1),
+			want: time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, entity.WorkingTime(tt.from, tt.to))
+		})
+	}
+}
+
+func TestWorkingDeadline(t *testing.T) {
+	tests := []struct {
+		name string
+		from time.Time
+		d    time.Duration
+		want time.Time
+	}{
+		{name: "same day", from: at(1, 10), d: 5 * time.Hour, want: at(1, 15)},
+		{name: "lands on Friday", from: at(4, 12), d: 24 * time.Hour, want: at(5, 12)},
+		{name: "ends with Friday", from: at(5, 0), d: 24 * time.Hour, want: at(6, 0)},
+		{name: "lands on Monday", from: at(5, 12), d: 24 * time.Hour, want: at(8, 12)},
+		{name: "starts on Saturday", from: at(6, 10), d: 8 * time.Hour, want: at(8, 8)},
+		{name: "starts on Sunday", from: at(7, 23), d: 30 * time.Hour, want: at(9, 6)},
+		{name: "spans two weekends", from: at(5, 12), d: 6 * 24 * time.Hour, want: at(15, 12)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := entity.WorkingDeadline(tt.from, tt.d)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.d, entity.WorkingTime(tt.from, got))
+		})
+	}
+}
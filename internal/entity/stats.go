@@ -4,12 +4,16 @@ type Stats struct {
 	PullRequests PullRequestStats `json:"pull_request_stats"`
 	Users        UserStats        `json:"user_stats"`
 	Teams        TeamStats        `json:"team_stats"`
+	ReviewLoad   ReviewLoadStats  `json:"review_load_stats"`
+	ReviewSLA    []TeamSLAStats   `json:"review_sla_stats"`
 }
 
 type PullRequestStats struct {
 	TotalPRs  int64 `json:"total_prs"`
 	OpenPRs   int64 `json:"open_prs"`
 	MergedPRs int64 `json:"merged_prs"`
+	DraftPRs  int64 `json:"draft_prs"`
+	ClosedPRs int64 `json:"closed_prs"`
 }
 
 type UserStats struct {
@@ -33,3 +37,20 @@ type MostActiveTeamStats struct {
 	TeamName string `json:"team_name"`
 	PRsCount int64  `json:"team_pr_count"`
 }
+
+// Distribution of open reviews among active users
+type ReviewLoadStats struct {
+	MinOpenReviews    int64   `json:"min_open_reviews"`
+	MaxOpenReviews    int64   `json:"max_open_reviews"`
+	AvgOpenReviews    float64 `json:"avg_open_reviews"`
+	StdDevOpenReviews float64 `json:"stddev_open_reviews"`
+}
+
+// Share of reviews decided within the team's review SLA. Open reviews count only after they breached the SLA
+type TeamSLAStats struct {
+	TeamName          string  `json:"team_name"`
+	ReviewSLAHours    int     `json:"review_sla_hours"`
+	MetSLA            int64   `json:"met_sla"`
+	BreachedSLA       int64   `json:"breached_sla"`
+	CompliancePercent float64 `json:"compliance_percent"`
+}
@@ -0,0 +1,14 @@
+package entity
+
+type ReviewerStrategy string
+
+const (
+	// Random active teammates, ignoring current load
+	StrategyRandom ReviewerStrategy = "random"
+	// Active teammates with the fewest open reviews, ties broken randomly
+	StrategyLeastLoaded ReviewerStrategy = "least_loaded"
+	// Active teammates who were assigned least recently go first
+	StrategyRoundRobin ReviewerStrategy = "round_robin"
+	// Random active teammates, probability is proportional to user's review weight
+	StrategyWeighted ReviewerStrategy = "weighted"
+)
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// Lowercases and trims user tags and PR labels, drops empty and duplicate ones.
+// Tags and labels are compared to each other, so both go through the same normalization
+func NormalizeTags(tags []string) []string {
+	normalized := lo.FilterMap(tags, func(tag string, _ int) (string, bool) {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		return tag, tag != ""
+	})
+	return lo.Uniq(normalized)
+}
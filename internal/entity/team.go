@@ -6,9 +6,70 @@ import (
 	"github.com/google/uuid"
 )
 
+// Used when team has no required_reviewers setting
+const DefaultRequiredReviewers int = 2
+
+// Used when team has no review_sla_hours setting
+const DefaultReviewSLAHours int = 24
+
 type Team struct {
-	ID        uuid.UUID
-	Name      string
-	CreatedAt time.Time
-	Members   []User
+	ID                uuid.UUID
+	Name              string
+	RequiredReviewers int
+	ReviewSLAHours    int // Working hours reviewer has to submit a decision
+	CreatedAt         time.Time
+	Members           []User
+}
+
+// Amount of reviewers each PR of the team needs
+func (t Team) ReviewersRequired() int {
+	if t.RequiredReviewers > 0 {
+		return t.RequiredReviewers
+	}
+	return DefaultRequiredReviewers
+}
+
+// Time reviewer has to submit a decision, counted in working hours
+func (t Team) ReviewSLA() time.Duration {
+	if t.ReviewSLAHours > 0 {
+		return time.Duration(t.ReviewSLAHours) * time.Hour
+	}
+	return time.Duration(DefaultReviewSLAHours) * time.Hour
+}
+
+// Outcome of removing a user from a team or moving it to another one
+type MemberTransfer struct {
+	User         User
+	PreviousTeam string
+	// Open reviews handed over to teammates of the previous team
+	Reassigned []AssignmentEvent
+	// Open reviews the user keeps
+	KeptPRIDs []string
+}
+
+type MemberUpsertStatus string
+
+const (
+	MemberCreated   MemberUpsertStatus = "created"
+	MemberUpdated   MemberUpsertStatus = "updated"
+	MemberMoved     MemberUpsertStatus = "moved"
+	MemberUnchanged MemberUpsertStatus = "unchanged"
+)
+
+// What upsert of the team did with one of the listed users
+type MemberUpsert struct {
+	User         User
+	Status       MemberUpsertStatus
+	PreviousTeam string // Team the user was moved from, empty for users without a team
+	// Open reviews of a moved user handed over to teammates of the previous team
+	Reassigned []AssignmentEvent
+	// Open reviews a moved user keeps
+	KeptPRIDs []string
+}
+
+// Outcome of creating or updating a team with its members
+type TeamUpsert struct {
+	Team        Team
+	TeamCreated bool
+	Members     []MemberUpsert
 }
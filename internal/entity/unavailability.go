@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Period when user is away (vacation, out-of-office) and can't be picked as a reviewer
+type Unavailability struct {
+	ID        uuid.UUID
+	UserID    string
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Reason    string
+	CreatedAt time.Time
+}
@@ -9,5 +9,6 @@ type User struct {
 	Name      string
 	IsActive  bool
 	Team      Team
+	Tags      []string
 	CreatedAt time.Time
 }
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookEventType string
+
+const (
+	WebhookReviewerAssigned WebhookEventType = EventReviewerAssigned
+	WebhookPRMerged         WebhookEventType = EventPRMerged
+	WebhookTeamDeactivated  WebhookEventType = EventTeamDeactivated
+)
+
+func (t WebhookEventType) IsValid() bool {
+	switch t {
+	case WebhookReviewerAssigned, WebhookPRMerged, WebhookTeamDeactivated:
+		return true
+	}
+	return false
+}
+
+// Event to be delivered to subscribers. ID and CreatedAt are taken from the outbox message,
+// so an event relayed twice is stored once. Payload is marshalled to JSON
+type WebhookEvent struct {
+	ID        uuid.UUID
+	Type      WebhookEventType
+	Payload   any
+	CreatedAt time.Time
+}
+
+// Subscriber endpoint. Empty EventTypes means all events
+type WebhookSubscription struct {
+	ID         uuid.UUID
+	URL        string
+	Secret     string
+	EventTypes []WebhookEventType
+	CreatedAt  time.Time
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryDead      WebhookDeliveryStatus = "DEAD"
+)
+
+// Delivery of one event to one subscription. Dead deliveries form the dead-letter list
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	EventID        uuid.UUID
+	EventType      WebhookEventType
+	Payload        json.RawMessage
+	EventCreatedAt time.Time
+	SubscriptionID uuid.UUID
+	URL            string
+	Secret         string
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastError      string
+	CreatedAt      time.Time
+}
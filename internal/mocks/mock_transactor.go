@@ -40,6 +40,20 @@ func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
 	return m.recorder
 }
 
+// WithinSavepoint mocks base method.
+func (m *MockTransactor) WithinSavepoint(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinSavepoint", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinSavepoint indicates an expected call of WithinSavepoint.
+func (mr *MockTransactorMockRecorder) WithinSavepoint(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinSavepoint", reflect.TypeOf((*MockTransactor)(nil).WithinSavepoint), ctx, fn)
+}
+
 // WithinTransaction mocks base method.
 func (m *MockTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
 	m.ctrl.T.Helper()
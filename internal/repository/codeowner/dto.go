@@ -0,0 +1,29 @@
+package repo_codeowner
+
+import (
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type RowCodeOwner struct {
+	ID        uuid.UUID  `db:"id"`
+	Pattern   string     `db:"pattern"`
+	UserID    *string    `db:"user_id"`
+	TeamID    *uuid.UUID `db:"team_id"`
+	TeamName  *string    `db:"team_name"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+func (r *RowCodeOwner) ToEntity() entity.CodeOwner {
+	return entity.CodeOwner{
+		ID:        r.ID,
+		Pattern:   r.Pattern,
+		UserID:    lo.FromPtr(r.UserID),
+		TeamID:    lo.FromPtr(r.TeamID),
+		TeamName:  lo.FromPtr(r.TeamName),
+		CreatedAt: r.CreatedAt,
+	}
+}
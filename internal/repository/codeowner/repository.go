@@ -0,0 +1,115 @@
+package repo_codeowner
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/4udiwe/avito-pr-service/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+type Repository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *Repository {
+	return &Repository{pg}
+}
+
+// Creates rule owned either by user or by team, the other owner must be empty
+func (r *Repository) Create(ctx context.Context, pattern, userID string, teamID uuid.UUID) (entity.CodeOwner, error) {
+	logrus.Infof("CodeOwnerRepository.Create: adding code owner for pattern %s", pattern)
+
+	query, args, _ := r.Builder.Insert("code_owner").
+		Columns("pattern", "user_id", "team_id").
+		Values(pattern, lo.EmptyableToPtr(userID), lo.EmptyableToPtr(teamID)).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	owner := entity.CodeOwner{
+		Pattern: pattern,
+		UserID:  userID,
+		TeamID:  teamID,
+	}
+
+	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
+		&owner.ID,
+		&owner.CreatedAt,
+	)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == pgerrcode.ForeignKeyViolation {
+				logrus.Warnf("CodeOwnerRepository.Create: owner of pattern %s not found", pattern)
+				if pgErr.ConstraintName == "code_owner_team_id_fkey" {
+					return entity.CodeOwner{}, repository.ErrTeamNotFound
+				}
+				return entity.CodeOwner{}, repository.ErrUserNotFound
+			}
+		}
+		logrus.Errorf("CodeOwnerRepository.Create: failed to add code owner: %v", err)
+		return entity.CodeOwner{}, err
+	}
+
+	logrus.Infof("CodeOwnerRepository.Create: code owner %s added for pattern %s", owner.ID, pattern)
+	return owner, nil
+}
+
+// Rules are ordered by creation time, so later rules go last
+func (r *Repository) GetAll(ctx context.Context) ([]entity.CodeOwner, error) {
+	logrus.Infof("CodeOwnerRepository.GetAll: getting all code owners")
+
+	query, args, _ := r.Builder.
+		Select("c.id", "c.pattern", "c.user_id", "c.team_id", "t.name AS team_name", "c.created_at").
+		From("code_owner AS c").
+		LeftJoin("team AS t ON c.team_id = t.id").
+		OrderBy("c.created_at ASC", "c.id ASC").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("CodeOwnerRepository.GetAll: failed to query code owners: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsOwners, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowCodeOwner])
+	if err != nil {
+		logrus.Errorf("CodeOwnerRepository.GetAll: failed to scan code owners: %v", err)
+		return nil, err
+	}
+
+	owners := lo.Map(rowsOwners, func(r RowCodeOwner, _ int) entity.CodeOwner { return r.ToEntity() })
+
+	logrus.Infof("CodeOwnerRepository.GetAll: found %d code owners", len(owners))
+	return owners, nil
+}
+
+func (r *Repository) Delete(ctx context.Context, ID uuid.UUID) error {
+	logrus.Infof("CodeOwnerRepository.Delete: deleting code owner %s", ID)
+
+	query, args, _ := r.Builder.Delete("code_owner").
+		Where("id = ?", ID).
+		ToSql()
+
+	cmdTag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("CodeOwnerRepository.Delete: failed to delete code owner %s: %v", ID, err)
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		logrus.Warnf("CodeOwnerRepository.Delete: no code owner with ID %s", ID)
+		return repository.ErrCodeOwnerNotFound
+	}
+
+	logrus.Infof("CodeOwnerRepository.Delete: code owner %s deleted", ID)
+	return nil
+}
@@ -18,4 +18,13 @@ var (
 	ErrCannotFetchPRs          = errors.New("cannot fetch PRs")
 
 	ErrStatusNotFound = errors.New("status not found")
+
+	ErrUnavailabilityNotFound = errors.New("unavailability period not found")
+
+	ErrCodeOwnerNotFound = errors.New("code owner not found")
+
+	ErrWebhookNotFound         = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
 )
@@ -0,0 +1,33 @@
+package repo_history
+
+import (
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type RowAssignmentEvent struct {
+	ID                 uuid.UUID `db:"id"`
+	PRID               string    `db:"pr_id"`
+	EventType          string    `db:"event_type"`
+	ReviewerID         *string   `db:"reviewer_id"`
+	PreviousReviewerID *string   `db:"previous_reviewer_id"`
+	Actor              string    `db:"actor"`
+	Reason             string    `db:"reason"`
+	CreatedAt          time.Time `db:"created_at"`
+}
+
+func (r *RowAssignmentEvent) ToEntity() entity.AssignmentEvent {
+	return entity.AssignmentEvent{
+		ID:                 r.ID,
+		PRID:               r.PRID,
+		Type:               entity.AssignmentEventType(r.EventType),
+		ReviewerID:         lo.FromPtr(r.ReviewerID),
+		PreviousReviewerID: lo.FromPtr(r.PreviousReviewerID),
+		Actor:              r.Actor,
+		Reason:             r.Reason,
+		CreatedAt:          r.CreatedAt,
+	}
+}
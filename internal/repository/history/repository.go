@@ -0,0 +1,81 @@
+package repo_history
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+type Repository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *Repository {
+	return &Repository{pg}
+}
+
+func (r *Repository) Create(ctx context.Context, events []entity.AssignmentEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	logrus.Infof("HistoryRepository.Create: recording %d assignment events", len(events))
+
+	builder := r.Builder.Insert("pr_assignment_event").
+		Columns("pr_id", "event_type", "reviewer_id", "previous_reviewer_id", "actor", "reason")
+
+	for _, e := range events {
+		builder = builder.Values(
+			e.PRID,
+			string(e.Type),
+			lo.EmptyableToPtr(e.ReviewerID),
+			lo.EmptyableToPtr(e.PreviousReviewerID),
+			e.Actor,
+			e.Reason,
+		)
+	}
+
+	query, args, _ := builder.ToSql()
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("HistoryRepository.Create: failed to record assignment events: %v", err)
+		return err
+	}
+
+	logrus.Infof("HistoryRepository.Create: %d assignment events recorded", len(events))
+	return nil
+}
+
+func (r *Repository) ListByPR(ctx context.Context, prID string) ([]entity.AssignmentEvent, error) {
+	logrus.Infof("HistoryRepository.ListByPR: listing assignment events for PR %s", prID)
+
+	query, args, _ := r.Builder.
+		Select("id", "pr_id", "event_type", "reviewer_id", "previous_reviewer_id", "actor", "reason", "created_at").
+		From("pr_assignment_event").
+		Where("pr_id = ?", prID).
+		OrderBy("created_at ASC", "id ASC").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("HistoryRepository.ListByPR: failed to list assignment events for PR %s: %v", prID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsEvents, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowAssignmentEvent])
+	if err != nil {
+		logrus.Errorf("HistoryRepository.ListByPR: failed to scan rows for PR %s: %v", prID, err)
+		return nil, err
+	}
+
+	events := lo.Map(rowsEvents, func(r RowAssignmentEvent, _ int) entity.AssignmentEvent { return r.ToEntity() })
+
+	logrus.Infof("HistoryRepository.ListByPR: found %d assignment events for PR %s", len(events), prID)
+	return events, nil
+}
@@ -0,0 +1,35 @@
+package repo_idempotency
+
+import (
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type RowIdempotencyKey struct {
+	Key          string    `db:"key"`
+	Scope        string    `db:"scope"`
+	RequestHash  string    `db:"request_hash"`
+	Token        uuid.UUID `db:"token"`
+	StatusCode   *int      `db:"status_code"`
+	ContentType  string    `db:"content_type"`
+	ResponseBody []byte    `db:"response_body"`
+	CreatedAt    time.Time `db:"created_at"`
+	ExpiresAt    time.Time `db:"expires_at"`
+}
+
+func (r *RowIdempotencyKey) ToEntity() entity.IdempotencyRecord {
+	return entity.IdempotencyRecord{
+		Key:         r.Key,
+		Scope:       r.Scope,
+		RequestHash: r.RequestHash,
+		Token:       r.Token,
+		StatusCode:  lo.FromPtr(r.StatusCode),
+		ContentType: r.ContentType,
+		Body:        r.ResponseBody,
+		CreatedAt:   r.CreatedAt,
+		ExpiresAt:   r.ExpiresAt,
+	}
+}
@@ -0,0 +1,126 @@
+package repo_idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/4udiwe/avito-pr-service/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type Repository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *Repository {
+	return &Repository{pg}
+}
+
+// Reserves the key for a new request and returns the token of the reservation. Expired records and records
+// of requests that have been in progress longer than lockTimeout are replaced. Returns false when the key is already taken
+func (r *Repository) Reserve(ctx context.Context, key, scope, requestHash string, ttl, lockTimeout time.Duration) (uuid.UUID, bool, error) {
+	deleteQuery := `
+		DELETE FROM idempotency_key
+		WHERE key = $1 AND scope = $2
+			AND (expires_at <= now() OR (status_code IS NULL AND created_at <= now() - make_interval(secs => $3::float8)));
+	`
+	if _, err := r.GetTxManager(ctx).Exec(ctx, deleteQuery, key, scope, lockTimeout.Seconds()); err != nil {
+		logrus.Errorf("IdempotencyRepository.Reserve: failed to delete expired key %s: %v", key, err)
+		return uuid.Nil, false, err
+	}
+
+	insertQuery := `
+		INSERT INTO idempotency_key (key, scope, request_hash, expires_at)
+		VALUES ($1, $2, $3, now() + make_interval(secs => $4::float8))
+		ON CONFLICT (key, scope) DO NOTHING
+		RETURNING token;
+	`
+	var token uuid.UUID
+	err := r.GetTxManager(ctx).QueryRow(ctx, insertQuery, key, scope, requestHash, ttl.Seconds()).Scan(&token)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, false, nil
+		}
+		logrus.Errorf("IdempotencyRepository.Reserve: failed to reserve key %s: %v", key, err)
+		return uuid.Nil, false, err
+	}
+
+	return token, true, nil
+}
+
+func (r *Repository) Get(ctx context.Context, key, scope string) (entity.IdempotencyRecord, error) {
+	query, args, _ := r.Builder.
+		Select("key", "scope", "request_hash", "token", "status_code", "content_type", "response_body", "created_at", "expires_at").
+		From("idempotency_key").
+		Where("key = ? AND scope = ?", key, scope).
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("IdempotencyRepository.Get: failed to query key %s: %v", key, err)
+		return entity.IdempotencyRecord{}, err
+	}
+
+	row, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[RowIdempotencyKey])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.IdempotencyRecord{}, repository.ErrIdempotencyKeyNotFound
+		}
+		logrus.Errorf("IdempotencyRepository.Get: failed to scan key %s: %v", key, err)
+		return entity.IdempotencyRecord{}, err
+	}
+
+	return row.ToEntity(), nil
+}
+
+// Saves response of the request that holds the reservation with the token.
+// Returns ErrIdempotencyKeyNotFound when the reservation has been taken over or released
+func (r *Repository) Complete(ctx context.Context, key, scope string, token uuid.UUID, statusCode int, contentType string, body []byte) error {
+	query, args, _ := r.Builder.Update("idempotency_key").
+		Set("status_code", statusCode).
+		Set("content_type", contentType).
+		Set("response_body", body).
+		Where("key = ? AND scope = ? AND token = ?", key, scope, token).
+		ToSql()
+
+	tag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("IdempotencyRepository.Complete: failed to save response for key %s: %v", key, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repository.ErrIdempotencyKeyNotFound
+	}
+	return nil
+}
+
+// Deletes the reservation with the token, a newer reservation of the key is kept
+func (r *Repository) Delete(ctx context.Context, key, scope string, token uuid.UUID) error {
+	query, args, _ := r.Builder.Delete("idempotency_key").
+		Where("key = ? AND scope = ? AND token = ?", key, scope, token).
+		ToSql()
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		logrus.Errorf("IdempotencyRepository.Delete: failed to delete key %s: %v", key, err)
+		return err
+	}
+	return nil
+}
+
+func (r *Repository) DeleteExpired(ctx context.Context) (int, error) {
+	query, args, _ := r.Builder.Delete("idempotency_key").
+		Where("expires_at <= now()").
+		ToSql()
+
+	tag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("IdempotencyRepository.DeleteExpired: failed to delete expired keys: %v", err)
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
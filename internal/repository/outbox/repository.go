@@ -0,0 +1,29 @@
+package repo_outbox
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/pkg/postgres"
+	"github.com/sirupsen/logrus"
+)
+
+type Repository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *Repository {
+	return &Repository{pg}
+}
+
+// Writes domain events to the outbox. Must be called inside the transaction of the change,
+// so events are committed together with it
+func (r *Repository) Publish(ctx context.Context, events ...entity.DomainEvent) error {
+	for _, event := range events {
+		if err := r.EnqueueOutbox(ctx, event.EventType(), event.AggregateID(), event); err != nil {
+			logrus.Errorf("OutboxRepository.Publish: failed to enqueue %s event: %v", event.EventType(), err)
+			return err
+		}
+	}
+	return nil
+}
@@ -14,17 +14,21 @@ type RowStatus struct {
 type RowPullRequest struct {
 	ID                string     `db:"id"`
 	Title             string     `db:"title"`
+	Description       string     `db:"description"`
 	AuthorID          string     `db:"author_id"`
 	StatusID          int        `db:"status_id"`
 	StatusName        string     `db:"status_name"`
 	NeedMoreReviewers bool       `db:"need_more_reviewers"`
 	CreatedAt         time.Time  `db:"created_at"`
 	MergedAt          *time.Time `db:"merged_at"`
+	ClosedAt          *time.Time `db:"closed_at"`
 }
 
 type RowPullRequestWithReviewerIDs struct {
 	RowPullRequest
-	ReviewerIDs []string `db:"reviewer_ids"`
+	ReviewerIDs         []string `db:"reviewer_ids"`
+	FallbackReviewerIDs []string `db:"fallback_reviewer_ids"`
+	Labels              []string `db:"labels"`
 }
 
 type RowPRReviewer struct {
@@ -32,6 +36,17 @@ type RowPRReviewer struct {
 	PRID       string    `db:"pr_id"`
 	ReviewerID string    `db:"reviewer_id"`
 	AssignedAt time.Time `db:"assigned_at"`
+	IsFallback bool      `db:"is_fallback"`
+}
+
+type RowPendingReview struct {
+	PRID           string    `db:"pr_id"`
+	Title          string    `db:"title"`
+	AuthorID       string    `db:"author_id"`
+	TeamName       string    `db:"team_name"`
+	ReviewSLAHours int       `db:"review_sla_hours"`
+	ReviewerID     string    `db:"reviewer_id"`
+	AssignedAt     time.Time `db:"assigned_at"`
 }
 
 func (r *RowStatus) ToEntity() entity.Status {
@@ -45,11 +60,13 @@ func (r *RowPullRequest) ToEntity() entity.PullRequest {
 	return entity.PullRequest{
 		ID:                r.ID,
 		Title:             r.Title,
+		Description:       r.Description,
 		AuthorID:          r.AuthorID,
 		Status:            entity.Status{ID: r.StatusID, Name: entity.PRStatusName(r.StatusName)},
 		NeedMoreReviewers: r.NeedMoreReviewers,
 		CreatedAt:         r.CreatedAt,
 		MergedAt:          r.MergedAt,
+		ClosedAt:          r.ClosedAt,
 	}
 }
 
@@ -57,12 +74,16 @@ func (r *RowPullRequestWithReviewerIDs) ToEntity() entity.PullRequest {
 	return entity.PullRequest{
 		ID:                r.ID,
 		Title:             r.Title,
+		Description:       r.Description,
 		AuthorID:          r.AuthorID,
 		Status:            entity.Status{ID: r.StatusID, Name: entity.PRStatusName(r.StatusName)},
 		NeedMoreReviewers: r.NeedMoreReviewers,
 		CreatedAt:         r.CreatedAt,
 		MergedAt:          r.MergedAt,
+		ClosedAt:          r.ClosedAt,
 		Reviewers:         r.ReviewerIDs,
+		FallbackReviewers: r.FallbackReviewerIDs,
+		Labels:            r.Labels,
 	}
 }
 
@@ -72,5 +93,18 @@ func (r *RowPRReviewer) ToEntity() entity.PRReviewer {
 		PRID:       r.PRID,
 		ReviewerID: r.ReviewerID,
 		AssignedAt: r.AssignedAt,
+		IsFallback: r.IsFallback,
+	}
+}
+
+func (r *RowPendingReview) ToEntity() entity.OverdueReview {
+	return entity.OverdueReview{
+		PRID:       r.PRID,
+		Title:      r.Title,
+		AuthorID:   r.AuthorID,
+		TeamName:   r.TeamName,
+		ReviewerID: r.ReviewerID,
+		AssignedAt: r.AssignedAt,
+		ReviewSLA:  time.Duration(r.ReviewSLAHours) * time.Hour,
 	}
 }
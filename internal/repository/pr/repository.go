@@ -8,6 +8,7 @@ import (
 	"github.com/4udiwe/avito-pr-service/internal/entity"
 	"github.com/4udiwe/avito-pr-service/internal/repository"
 	"github.com/4udiwe/avito-pr-service/pkg/postgres"
+	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -35,7 +36,8 @@ func (r *Repository) Create(ctx context.Context, ID, title, authorID, statusName
             status_id,
             (SELECT name FROM pr_status WHERE id = status_id) AS status_name,
             created_at,
-            merged_at;
+            merged_at,
+            closed_at;
 		`
 
 	row := RowPullRequest{
@@ -49,6 +51,7 @@ func (r *Repository) Create(ctx context.Context, ID, title, authorID, statusName
 		&row.StatusName,
 		&row.CreatedAt,
 		&row.MergedAt,
+		&row.ClosedAt,
 	)
 
 	if err != nil {
@@ -105,6 +108,44 @@ func (r *Repository) AssignReviewers(ctx context.Context, prID string, reviewerI
 	return nil
 }
 
+// Same as AssignReviewers, but reviewers are marked as picked from buddy teams
+func (r *Repository) AssignFallbackReviewers(ctx context.Context, prID string, reviewerIDs []string) error {
+	logrus.Infof("PRRepository.AssignFallbackReviewers: assigning fallback reviewers to PR %s", prID)
+
+	queryBuilder := r.Builder.Insert("pr_reviewer").
+		Columns("pr_id", "reviewer_id", "is_fallback")
+
+	for _, reviewerID := range reviewerIDs {
+		queryBuilder = queryBuilder.Values(
+			prID,
+			reviewerID,
+			true,
+		)
+	}
+	query, args, _ := queryBuilder.ToSql()
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				logrus.Warnf("PRRepository.AssignFallbackReviewers: reviewer already assigned to PR %s", prID)
+				return repository.ErrReviewerAlreadyAssigned
+			case pgerrcode.ForeignKeyViolation:
+				logrus.Warnf("PRRepository.AssignFallbackReviewers: reviewer not found for PR %s", prID)
+				return repository.ErrReviewerNotFound
+			}
+		}
+		logrus.Errorf("PRRepository.AssignFallbackReviewers: failed to assign fallback reviewers to PR: %v", err)
+		return err
+	}
+
+	logrus.Infof("PRRepository.AssignFallbackReviewers: fallback reviewers assigned to PR %s", prID)
+	return nil
+}
+
 func (r *Repository) AssignReviewer(ctx context.Context, prID string, reviewerID string) error {
 	logrus.Infof("PRRepository.AssignReviewer: assigning reviewer %s to PR %s", reviewerID, prID)
 
@@ -135,11 +176,16 @@ func (r *Repository) AssignReviewer(ctx context.Context, prID string, reviewerID
 	return nil
 }
 
-func (r *Repository) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error {
+// Replaces the reviewer. isFallback marks whether the new reviewer comes from a buddy team of the author
+func (r *Repository) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string, isFallback bool) error {
 	logrus.Infof("PRRepository.ReassignReviewer: reassigning reviewer for PR %s", prID)
 
 	query, args, _ := r.Builder.Update("pr_reviewer").
 		Set("reviewer_id", newReviewerID).
+		Set("is_fallback", isFallback).
+		// SLA of the new reviewer starts from reassignment
+		Set("assigned_at", squirrel.Expr("now()")).
+		Set("stale_check_after", nil).
 		Where("pr_id = ? AND reviewer_id = ?", prID, oldReviewerID).
 		ToSql()
 
@@ -161,6 +207,61 @@ func (r *Repository) ReassignReviewer(ctx context.Context, prID, oldReviewerID,
 	return nil
 }
 
+func (r *Repository) AddLabels(ctx context.Context, prID string, labels []string) error {
+	logrus.Infof("PRRepository.AddLabels: adding labels %+v to PR %s", labels, prID)
+
+	queryBuilder := r.Builder.Insert("pr_label").
+		Columns("pr_id", "label")
+
+	for _, label := range labels {
+		queryBuilder = queryBuilder.Values(prID, label)
+	}
+
+	query, args, _ := queryBuilder.Suffix("ON CONFLICT (pr_id, label) DO NOTHING").ToSql()
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgerrcode.ForeignKeyViolation {
+				logrus.Warnf("PRRepository.AddLabels: PR %s not found", prID)
+				return repository.ErrPRNotFound
+			}
+		}
+		logrus.Errorf("PRRepository.AddLabels: failed to add labels to PR %s: %v", prID, err)
+		return err
+	}
+
+	logrus.Infof("PRRepository.AddLabels: labels added to PR %s", prID)
+	return nil
+}
+
+// Records that PR was merged by actor despite unmet merge policy conditions
+func (r *Repository) AddMergeOverride(ctx context.Context, prID, actor string, unmetConditions []string) error {
+	logrus.Infof("PRRepository.AddMergeOverride: %s forces merge of PR %s", actor, prID)
+
+	query, args, _ := r.Builder.Insert("pr_merge_override").
+		Columns("pr_id", "actor", "unmet_conditions").
+		Values(prID, actor, unmetConditions).
+		ToSql()
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgerrcode.ForeignKeyViolation {
+				logrus.Warnf("PRRepository.AddMergeOverride: PR %s not found", prID)
+				return repository.ErrPRNotFound
+			}
+		}
+		logrus.Errorf("PRRepository.AddMergeOverride: failed to record merge override for PR %s: %v", prID, err)
+		return err
+	}
+
+	logrus.Infof("PRRepository.AddMergeOverride: merge override recorded for PR %s", prID)
+	return nil
+}
+
 func (r *Repository) GetByID(ctx context.Context, ID string) (entity.PullRequest, error) {
 	logrus.Infof("PRRepository.GetByID: getting PR by ID %s", ID)
 
@@ -168,13 +269,17 @@ func (r *Repository) GetByID(ctx context.Context, ID string) (entity.PullRequest
 		Select(
 			"p.id",
 			"p.title",
+			"p.description",
 			"p.author_id",
 			"p.status_id",
 			"s.name AS status_name",
 			"p.need_more_reviewers",
 			"p.created_at",
 			"p.merged_at",
+			"p.closed_at",
 			"COALESCE(array_agg(r.reviewer_id) FILTER (WHERE r.reviewer_id IS NOT NULL), '{}') AS reviewer_ids",
+			"COALESCE(array_agg(r.reviewer_id) FILTER (WHERE r.is_fallback), '{}') AS fallback_reviewer_ids",
+			"ARRAY(SELECT l.label FROM pr_label AS l WHERE l.pr_id = p.id ORDER BY l.label) AS labels",
 		).
 		From("pr AS p").
 		LeftJoin("pr_reviewer AS r ON p.id = r.pr_id").
@@ -187,13 +292,17 @@ func (r *Repository) GetByID(ctx context.Context, ID string) (entity.PullRequest
 	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
 		&row.ID,
 		&row.Title,
+		&row.Description,
 		&row.AuthorID,
 		&row.StatusID,
 		&row.StatusName,
 		&row.NeedMoreReviewers,
 		&row.CreatedAt,
 		&row.MergedAt,
+		&row.ClosedAt,
 		&row.ReviewerIDs,
+		&row.FallbackReviewerIDs,
+		&row.Labels,
 	)
 
 	if err != nil {
@@ -209,6 +318,95 @@ func (r *Repository) GetByID(ctx context.Context, ID string) (entity.PullRequest
 	return row.ToEntity(), nil
 }
 
+// Locks the PR row until the end of the transaction. Changes of PR status and reviews take the lock first,
+// so checks made under it stay valid until commit
+func (r *Repository) LockByID(ctx context.Context, ID string) error {
+	logrus.Infof("PRRepository.LockByID: locking PR %s", ID)
+
+	query, args, _ := r.Builder.
+		Select("id").
+		From("pr").
+		Where("id = ?", ID).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	var lockedID string
+	if err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(&lockedID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logrus.Warnf("PRRepository.LockByID: no PR with ID %s", ID)
+			return repository.ErrPRNotFound
+		}
+		logrus.Errorf("PRRepository.LockByID: failed to lock PR %s: %v", ID, err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repository) UpdateTitle(ctx context.Context, ID, title string) error {
+	logrus.Infof("PRRepository.UpdateTitle: updating title for PR %s", ID)
+
+	query, args, _ := r.Builder.Update("pr").Set("title", title).Where("id = ?", ID).ToSql()
+
+	cmdTag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("PRRepository.UpdateTitle: failed to update title for PR %s: %v", ID, err)
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		logrus.Warnf("PRRepository.UpdateTitle: no PR with ID %s to update", ID)
+		return repository.ErrPRNotFound
+	}
+
+	logrus.Infof("PRRepository.UpdateTitle: title updated for PR %s", ID)
+	return nil
+}
+
+func (r *Repository) UpdateDescription(ctx context.Context, ID, description string) error {
+	logrus.Infof("PRRepository.UpdateDescription: updating description for PR %s", ID)
+
+	query, args, _ := r.Builder.Update("pr").Set("description", description).Where("id = ?", ID).ToSql()
+
+	cmdTag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("PRRepository.UpdateDescription: failed to update description for PR %s: %v", ID, err)
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		logrus.Warnf("PRRepository.UpdateDescription: no PR with ID %s to update", ID)
+		return repository.ErrPRNotFound
+	}
+
+	logrus.Infof("PRRepository.UpdateDescription: description updated for PR %s", ID)
+	return nil
+}
+
+func (r *Repository) UpdateAuthor(ctx context.Context, ID, authorID string) error {
+	logrus.Infof("PRRepository.UpdateAuthor: transferring PR %s to %s", ID, authorID)
+
+	query, args, _ := r.Builder.Update("pr").Set("author_id", authorID).Where("id = ?", ID).ToSql()
+
+	cmdTag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgerrcode.ForeignKeyViolation {
+				logrus.Warnf("PRRepository.UpdateAuthor: author %s not found", authorID)
+				return repository.ErrAuthorNotFound
+			}
+		}
+		logrus.Errorf("PRRepository.UpdateAuthor: failed to update author for PR %s: %v", ID, err)
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		logrus.Warnf("PRRepository.UpdateAuthor: no PR with ID %s to update", ID)
+		return repository.ErrPRNotFound
+	}
+
+	logrus.Infof("PRRepository.UpdateAuthor: author updated for PR %s", ID)
+	return nil
+}
+
 func (r *Repository) UpdateStatus(ctx context.Context, ID string, statusID int, mergedAt time.Time) error {
 	logrus.Infof("PRRepository.UpdateStatus: updating status for PR %s", ID)
 
@@ -234,6 +432,99 @@ func (r *Repository) UpdateStatus(ctx context.Context, ID string, statusID int,
 	return nil
 }
 
+// Sets PR status without touching merged_at. closedAt is NULL for any status except CLOSED
+func (r *Repository) SetStatus(ctx context.Context, ID string, statusID int, closedAt *time.Time) error {
+	logrus.Infof("PRRepository.SetStatus: setting status %d for PR %s", statusID, ID)
+
+	query, args, _ := r.Builder.
+		Update("pr").
+		Set("status_id", statusID).
+		Set("closed_at", closedAt).
+		Where("id = ?", ID).
+		ToSql()
+
+	cmdTag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("PRRepository.SetStatus: failed to set status for PR %s: %v", ID, err)
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		logrus.Warnf("PRRepository.SetStatus: no PR with ID %s to update", ID)
+		return repository.ErrPRNotFound
+	}
+
+	logrus.Infof("PRRepository.SetStatus: status updated for PR %s", ID)
+	return nil
+}
+
+func (r *Repository) SetNeedMoreReviewers(ctx context.Context, ID string, needMoreReviewers bool) error {
+	logrus.Infof("PRRepository.SetNeedMoreReviewers: setting flag to %v for PR %s", needMoreReviewers, ID)
+
+	query, args, _ := r.Builder.Update("pr").Set("need_more_reviewers", needMoreReviewers).Where("id = ?", ID).ToSql()
+
+	cmdTag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("PRRepository.SetNeedMoreReviewers: failed to set flag for PR %s: %v", ID, err)
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		logrus.Warnf("PRRepository.SetNeedMoreReviewers: no PR with ID %s to update", ID)
+		return repository.ErrPRNotFound
+	}
+
+	logrus.Infof("PRRepository.SetNeedMoreReviewers: flag updated for PR %s", ID)
+	return nil
+}
+
+// Removes all reviewers from PR and returns their IDs
+func (r *Repository) RemoveReviewers(ctx context.Context, prID string) ([]string, error) {
+	logrus.Infof("PRRepository.RemoveReviewers: removing reviewers from PR %s", prID)
+
+	query, args, _ := r.Builder.
+		Delete("pr_reviewer").
+		Where("pr_id = ?", prID).
+		Suffix("RETURNING reviewer_id").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("PRRepository.RemoveReviewers: failed to remove reviewers from PR %s: %v", prID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviewerIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		logrus.Errorf("PRRepository.RemoveReviewers: failed to scan rows for PR %s: %v", prID, err)
+		return nil, err
+	}
+
+	logrus.Infof("PRRepository.RemoveReviewers: %d reviewers removed from PR %s", len(reviewerIDs), prID)
+	return reviewerIDs, nil
+}
+
+func (r *Repository) RemoveReviewer(ctx context.Context, prID, reviewerID string) error {
+	logrus.Infof("PRRepository.RemoveReviewer: removing reviewer %s from PR %s", reviewerID, prID)
+
+	query, args, _ := r.Builder.
+		Delete("pr_reviewer").
+		Where("pr_id = ? AND reviewer_id = ?", prID, reviewerID).
+		ToSql()
+
+	cmdTag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("PRRepository.RemoveReviewer: failed to remove reviewer from PR %s: %v", prID, err)
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		logrus.Warnf("PRRepository.RemoveReviewer: reviewer %s is not assigned to PR %s", reviewerID, prID)
+		return repository.ErrReviewerNotFound
+	}
+
+	logrus.Infof("PRRepository.RemoveReviewer: reviewer %s removed from PR %s", reviewerID, prID)
+	return nil
+}
+
 func (r *Repository) UpdateNeedMoreReviewers(ctx context.Context, ID string) error {
 	logrus.Infof("PRRepository.UpdateNeedMoreReviewers: updating flag for PR %s", ID)
 
@@ -256,7 +547,7 @@ func (r *Repository) UpdateNeedMoreReviewers(ctx context.Context, ID string) err
 
 func (r *Repository) GetReviewersByPR(ctx context.Context, prID string) ([]entity.PRReviewer, error) {
 	query, args, _ := r.Builder.Select(
-		"id", "pr_id", "reviewer_id", "assigned_at",
+		"id", "pr_id", "reviewer_id", "assigned_at", "is_fallback",
 	).From("pr_reviewer").
 		Where("pr_id = ?", prID).
 		ToSql()
@@ -285,12 +576,14 @@ func (r *Repository) ListByReviewer(ctx context.Context, reviewerID string) ([]e
 		Select(
 			"p.id",
 			"p.title",
+			"p.description",
 			"p.author_id",
 			"p.status_id",
 			"s.name AS status_name",
 			"p.need_more_reviewers",
 			"p.created_at",
 			"p.merged_at",
+			"p.closed_at",
 		).
 		From("pr AS p").
 		Join("pr_reviewer AS r ON p.id = r.pr_id").
@@ -378,13 +671,17 @@ func (r *Repository) GetAll(
 		Select(
 			"p.id",
 			"p.title",
+			"p.description",
 			"p.author_id",
 			"p.status_id",
 			"s.name AS status_name",
 			"p.need_more_reviewers",
 			"p.created_at",
 			"p.merged_at",
+			"p.closed_at",
 			"COALESCE(array_agg(r.reviewer_id) FILTER (WHERE r.reviewer_id IS NOT NULL), '{}') AS reviewer_ids",
+			"COALESCE(array_agg(r.reviewer_id) FILTER (WHERE r.is_fallback), '{}') AS fallback_reviewer_ids",
+			"ARRAY(SELECT l.label FROM pr_label AS l WHERE l.pr_id = p.id ORDER BY l.label) AS labels",
 		).
 		From("pr AS p").
 		LeftJoin("pr_reviewer AS r ON p.id = r.pr_id").
@@ -425,3 +722,113 @@ func (r *Repository) GetAll(
 	logrus.Infof("PRRepository.GetAll success: count=%d", len(PRs))
 	return PRs, total, nil
 }
+
+// Lists reviewers of OPEN PRs without a decision whose calendar time since assignment exceeds author team's review SLA.
+// Working time is not checked here
+func (r *Repository) ListPendingReviewsPastSLA(ctx context.Context) ([]entity.OverdueReview, error) {
+	logrus.Info("PRRepository.ListPendingReviewsPastSLA: listing pending reviews past SLA")
+
+	query, args, _ := r.Builder.
+		Select(
+			"p.id AS pr_id",
+			"p.title",
+			"p.author_id",
+			"t.name AS team_name",
+			"t.review_sla_hours",
+			"r.reviewer_id",
+			"r.assigned_at",
+		).
+		From("pr_reviewer AS r").
+		Join("pr AS p ON p.id = r.pr_id").
+		Join("pr_status AS s ON s.id = p.status_id").
+		Join("app_user AS a ON a.id = p.author_id").
+		Join("team AS t ON t.id = a.team_id").
+		Where(squirrel.Eq{"s.name": string(entity.StatusOPEN)}).
+		Where("r.assigned_at < now() - make_interval(hours => t.review_sla_hours)").
+		Where(`NOT EXISTS (
+			SELECT 1 FROM pr_review AS rv
+			WHERE rv.pr_id = r.pr_id
+				AND rv.reviewer_id = r.reviewer_id
+				AND rv.state IN (?, ?)
+				AND rv.created_at >= r.assigned_at
+		)`, string(entity.ReviewApproved), string(entity.ReviewChangesRequested)).
+		OrderBy("r.assigned_at ASC").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("PRRepository.ListPendingReviewsPastSLA: failed to list pending reviews: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsReviews, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowPendingReview])
+	if err != nil {
+		logrus.Errorf("PRRepository.ListPendingReviewsPastSLA: failed to scan rows: %v", err)
+		return nil, err
+	}
+
+	reviews := lo.Map(rowsReviews, func(r RowPendingReview, _ int) entity.OverdueReview { return r.ToEntity() })
+
+	logrus.Infof("PRRepository.ListPendingReviewsPastSLA: found %d pending reviews", len(reviews))
+	return reviews, nil
+}
+
+// Lists reviewers of OPEN PRs assigned before assignedBefore who have not submitted any review since assignment, the oldest first.
+// Reviews deferred with DeferStaleCheck are skipped until their time comes
+func (r *Repository) ListStaleReviews(ctx context.Context, assignedBefore time.Time, limit int) ([]entity.PRReviewer, error) {
+	logrus.Infof("PRRepository.ListStaleReviews: listing reviews assigned before %v", assignedBefore)
+
+	query, args, _ := r.Builder.
+		Select("r.id", "r.pr_id", "r.reviewer_id", "r.assigned_at", "r.is_fallback").
+		From("pr_reviewer AS r").
+		Join("pr AS p ON p.id = r.pr_id").
+		Join("pr_status AS s ON s.id = p.status_id").
+		Where(squirrel.Eq{"s.name": string(entity.StatusOPEN)}).
+		Where("r.assigned_at < ?", assignedBefore).
+		Where("(r.stale_check_after IS NULL OR r.stale_check_after <= now())").
+		Where(`NOT EXISTS (
+			SELECT 1 FROM pr_review AS rv
+			WHERE rv.pr_id = r.pr_id
+				AND rv.reviewer_id = r.reviewer_id
+				AND rv.created_at >= r.assigned_at
+		)`).
+		OrderBy("r.assigned_at ASC").
+		Limit(uint64(limit)).
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("PRRepository.ListStaleReviews: failed to list stale reviews: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsReviewers, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowPRReviewer])
+	if err != nil {
+		logrus.Errorf("PRRepository.ListStaleReviews: failed to scan rows: %v", err)
+		return nil, err
+	}
+
+	reviewers := lo.Map(rowsReviewers, func(r RowPRReviewer, _ int) entity.PRReviewer { return r.ToEntity() })
+
+	logrus.Infof("PRRepository.ListStaleReviews: found %d stale reviews", len(reviewers))
+	return reviewers, nil
+}
+
+// Excludes the review from ListStaleReviews until the given moment
+func (r *Repository) DeferStaleCheck(ctx context.Context, prID, reviewerID string, until time.Time) error {
+	logrus.Infof("PRRepository.DeferStaleCheck: deferring stale check of reviewer %s on PR %s until %v", reviewerID, prID, until)
+
+	query, args, _ := r.Builder.Update("pr_reviewer").
+		Set("stale_check_after", until).
+		Where("pr_id = ? AND reviewer_id = ?", prID, reviewerID).
+		ToSql()
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		logrus.Errorf("PRRepository.DeferStaleCheck: failed to defer stale check on PR %s: %v", prID, err)
+		return err
+	}
+
+	return nil
+}
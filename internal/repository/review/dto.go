@@ -0,0 +1,28 @@
+package repo_review
+
+import (
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type RowReview struct {
+	ID         uuid.UUID `db:"id"`
+	PRID       string    `db:"pr_id"`
+	ReviewerID string    `db:"reviewer_id"`
+	State      string    `db:"state"`
+	Comment    string    `db:"comment"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func (r *RowReview) ToEntity() entity.Review {
+	return entity.Review{
+		ID:         r.ID,
+		PRID:       r.PRID,
+		ReviewerID: r.ReviewerID,
+		State:      entity.ReviewState(r.State),
+		Comment:    r.Comment,
+		CreatedAt:  r.CreatedAt,
+	}
+}
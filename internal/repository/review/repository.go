@@ -0,0 +1,82 @@
+package repo_review
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+type Repository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *Repository {
+	return &Repository{pg}
+}
+
+func (r *Repository) Create(ctx context.Context, prID, reviewerID string, state entity.ReviewState, comment string) (entity.Review, error) {
+	logrus.Infof("ReviewRepository.Create: saving %s review of %s for PR %s", state, reviewerID, prID)
+
+	query, args, _ := r.Builder.Insert("pr_review").
+		Columns("pr_id", "reviewer_id", "state", "comment").
+		Values(prID, reviewerID, string(state), comment).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	row := RowReview{
+		PRID:       prID,
+		ReviewerID: reviewerID,
+		State:      string(state),
+		Comment:    comment,
+	}
+
+	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
+		&row.ID,
+		&row.CreatedAt,
+	)
+	if err != nil {
+		logrus.Errorf("ReviewRepository.Create: failed to save review for PR %s: %v", prID, err)
+		return entity.Review{}, err
+	}
+
+	logrus.Infof("ReviewRepository.Create: review %s saved for PR %s", row.ID, prID)
+	return row.ToEntity(), nil
+}
+
+// Returns the latest review of every reviewer on the given PRs
+func (r *Repository) GetLatestByPRs(ctx context.Context, prIDs []string) ([]entity.Review, error) {
+	logrus.Infof("ReviewRepository.GetLatestByPRs: getting latest reviews for %d PRs", len(prIDs))
+
+	if len(prIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, _ := r.Builder.
+		Select("DISTINCT ON (pr_id, reviewer_id) id", "pr_id", "reviewer_id", "state", "comment", "created_at").
+		From("pr_review").
+		Where("pr_id = ANY(?)", prIDs).
+		OrderBy("pr_id", "reviewer_id", "created_at DESC").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("ReviewRepository.GetLatestByPRs: failed to get reviews: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsReviews, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowReview])
+	if err != nil {
+		logrus.Errorf("ReviewRepository.GetLatestByPRs: failed to scan rows: %v", err)
+		return nil, err
+	}
+
+	reviews := lo.Map(rowsReviews, func(r RowReview, _ int) entity.Review { return r.ToEntity() })
+
+	logrus.Infof("ReviewRepository.GetLatestByPRs: found %d reviews", len(reviews))
+	return reviews, nil
+}
@@ -1,11 +1,17 @@
 package repo_stats
 
-import "github.com/4udiwe/avito-pr-service/internal/entity"
+import (
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
 
 type RowPullRequestStats struct {
 	TotalPRs  int64 `db:"total_prs"`
 	OpenPRs   int64 `db:"open_prs"`
 	MergedPRs int64 `db:"merged_prs"`
+	DraftPRs  int64 `db:"draft_prs"`
+	ClosedPRs int64 `db:"closed_prs"`
 }
 
 type RowUserAssignment struct {
@@ -19,16 +25,32 @@ type RowMostActiveTeam struct {
 	PRCount  int64  `db:"pr_count"`
 }
 
+type RowReviewLoadStats struct {
+	MinOpenReviews    int64   `db:"min_open_reviews"`
+	MaxOpenReviews    int64   `db:"max_open_reviews"`
+	AvgOpenReviews    float64 `db:"avg_open_reviews"`
+	StdDevOpenReviews float64 `db:"stddev_open_reviews"`
+}
+
 type RowUserStats struct {
 	ActiveUsers   int64 `db:"active_users"`
 	InactiveUsers int64 `db:"inactive_users"`
 }
 
+type RowReviewSLASample struct {
+	TeamName       string     `db:"team_name"`
+	ReviewSLAHours int        `db:"review_sla_hours"`
+	AssignedAt     time.Time  `db:"assigned_at"`
+	DecidedAt      *time.Time `db:"decided_at"`
+}
+
 func (r *RowPullRequestStats) ToEntity() *entity.PullRequestStats {
 	return &entity.PullRequestStats{
 		TotalPRs:  r.TotalPRs,
 		OpenPRs:   r.OpenPRs,
 		MergedPRs: r.MergedPRs,
+		DraftPRs:  r.DraftPRs,
+		ClosedPRs: r.ClosedPRs,
 	}
 }
 
@@ -53,3 +75,21 @@ func (r *RowUserStats) ToEntity() *entity.UserStats {
 		InactiveUsers: r.InactiveUsers,
 	}
 }
+
+func (r *RowReviewLoadStats) ToEntity() *entity.ReviewLoadStats {
+	return &entity.ReviewLoadStats{
+		MinOpenReviews:    r.MinOpenReviews,
+		MaxOpenReviews:    r.MaxOpenReviews,
+		AvgOpenReviews:    r.AvgOpenReviews,
+		StdDevOpenReviews: r.StdDevOpenReviews,
+	}
+}
+
+func (r *RowReviewSLASample) ToEntity() entity.ReviewSLASample {
+	return entity.ReviewSLASample{
+		TeamName:   r.TeamName,
+		ReviewSLA:  time.Duration(r.ReviewSLAHours) * time.Hour,
+		AssignedAt: r.AssignedAt,
+		DecidedAt:  r.DecidedAt,
+	}
+}
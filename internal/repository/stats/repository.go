@@ -2,11 +2,13 @@ package repo_stats
 
 import (
 	"context"
+	"time"
 
 	"github.com/4udiwe/avito-pr-service/internal/entity"
 	"github.com/4udiwe/avito-pr-service/pkg/postgres"
 	"github.com/jackc/pgx/v5"
 	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
 )
 
 type Repository struct {
@@ -27,6 +29,8 @@ func (r *Repository) GetStats(ctx context.Context) (*entity.Stats, error) {
 			"COUNT(*) FILTER (WHERE ps.name = 'OPEN') AS open_prs",
 			// считаем MERGED точно так же
 			"COUNT(*) FILTER (WHERE ps.name = 'MERGED') AS merged_prs",
+			"COUNT(*) FILTER (WHERE ps.name = 'DRAFT') AS draft_prs",
+			"COUNT(*) FILTER (WHERE ps.name = 'CLOSED') AS closed_prs",
 		).
 		From("pr AS p").
 		Join("pr_status AS ps ON p.status_id = ps.id").
@@ -120,5 +124,78 @@ func (r *Repository) GetStats(ctx context.Context) (*entity.Stats, error) {
 
 	stats.Teams.MostActiveTeam = *rowActiveTeam.ToEntity()
 
+	// Open reviews per active user, users without reviews count as 0
+	queryReviewLoad := `
+		WITH load AS (
+			SELECT u.id, COUNT(p.id) AS open_reviews
+			FROM app_user AS u
+			LEFT JOIN pr_reviewer AS prr ON prr.reviewer_id = u.id
+			LEFT JOIN pr AS p ON p.id = prr.pr_id
+				AND p.status_id = (SELECT id FROM pr_status WHERE name = 'OPEN')
+			WHERE u.is_active = TRUE
+			GROUP BY u.id
+		)
+		SELECT
+			COALESCE(MIN(open_reviews), 0) AS min_open_reviews,
+			COALESCE(MAX(open_reviews), 0) AS max_open_reviews,
+			COALESCE(AVG(open_reviews), 0)::float8 AS avg_open_reviews,
+			COALESCE(STDDEV_POP(open_reviews), 0)::float8 AS stddev_open_reviews
+		FROM load;
+	`
+
+	rows, err = r.GetTxManager(ctx).Query(ctx, queryReviewLoad)
+	if err != nil {
+		return nil, err
+	}
+
+	rowReviewLoad, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[RowReviewLoadStats])
+	if err != nil {
+		return nil, err
+	}
+
+	stats.ReviewLoad = *rowReviewLoad.ToEntity()
+
 	return stats, nil
 }
+
+// Reviewer assignments since the given moment used for SLA compliance: decided ones and undecided ones of OPEN PRs.
+// Decision is the first APPROVED or CHANGES_REQUESTED review after assignment
+func (r *Repository) GetReviewSLASamples(ctx context.Context, since time.Time) ([]entity.ReviewSLASample, error) {
+	query := `
+		SELECT
+			t.name AS team_name,
+			t.review_sla_hours,
+			prr.assigned_at,
+			d.decided_at
+		FROM pr_reviewer AS prr
+		JOIN pr AS p ON p.id = prr.pr_id
+		JOIN pr_status AS ps ON ps.id = p.status_id
+		JOIN app_user AS a ON a.id = p.author_id
+		JOIN team AS t ON t.id = a.team_id
+		LEFT JOIN LATERAL (
+			SELECT MIN(rv.created_at) AS decided_at
+			FROM pr_review AS rv
+			WHERE rv.pr_id = prr.pr_id
+				AND rv.reviewer_id = prr.reviewer_id
+				AND rv.state IN ('APPROVED', 'CHANGES_REQUESTED')
+				AND rv.created_at >= prr.assigned_at
+		) AS d ON TRUE
+		WHERE prr.assigned_at >= $1
+			AND (d.decided_at IS NOT NULL OR ps.name = 'OPEN');
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, since)
+	if err != nil {
+		logrus.Errorf("StatsRepository.GetReviewSLASamples: failed to get review SLA samples: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsSamples, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowReviewSLASample])
+	if err != nil {
+		logrus.Errorf("StatsRepository.GetReviewSLASamples: failed to scan review SLA samples: %v", err)
+		return nil, err
+	}
+
+	return lo.Map(rowsSamples, func(r RowReviewSLASample, _ int) entity.ReviewSLASample { return r.ToEntity() }), nil
+}
@@ -8,15 +8,19 @@ import (
 )
 
 type RowTeam struct {
-	ID        uuid.UUID `db:"id"`
-	Name      string    `db:"name"`
-	CreatedAt time.Time `db:"created_at"`
+	ID                uuid.UUID `db:"id"`
+	Name              string    `db:"name"`
+	RequiredReviewers int       `db:"required_reviewers"`
+	ReviewSLAHours    int       `db:"review_sla_hours"`
+	CreatedAt         time.Time `db:"created_at"`
 }
 
 func (rt *RowTeam) ToEntity() entity.Team {
 	return entity.Team{
-		ID:        rt.ID,
-		Name:      rt.Name,
-		CreatedAt: rt.CreatedAt,
+		ID:                rt.ID,
+		Name:              rt.Name,
+		RequiredReviewers: rt.RequiredReviewers,
+		ReviewSLAHours:    rt.ReviewSLAHours,
+		CreatedAt:         rt.CreatedAt,
 	}
 }
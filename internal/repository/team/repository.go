@@ -23,17 +23,19 @@ func New(pg *postgres.Postgres) *Repository {
 	return &Repository{pg}
 }
 
-func (r *Repository) Create(ctx context.Context, name string) (entity.Team, error) {
+func (r *Repository) Create(ctx context.Context, name string, requiredReviewers, reviewSLAHours int) (entity.Team, error) {
 	logrus.Infof("TeamRepository.Create: creating team with name %s", name)
 
 	query, args, _ := r.Builder.Insert("team").
-		Columns("name").
-		Values(name).
+		Columns("name", "required_reviewers", "review_sla_hours").
+		Values(name, requiredReviewers, reviewSLAHours).
 		Suffix("RETURNING id, created_at").
 		ToSql()
 
 	rowTeam := RowTeam{
-		Name: name,
+		Name:              name,
+		RequiredReviewers: requiredReviewers,
+		ReviewSLAHours:    reviewSLAHours,
 	}
 
 	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
@@ -60,7 +62,7 @@ func (r *Repository) Create(ctx context.Context, name string) (entity.Team, erro
 func (r *Repository) GetByName(ctx context.Context, name string) (entity.Team, error) {
 	logrus.Infof("TeamRepository.GetByName: getting team by name %s", name)
 
-	query, args, _ := r.Builder.Select("id", "created_at").
+	query, args, _ := r.Builder.Select("id", "required_reviewers", "review_sla_hours", "created_at").
 		From("team").
 		Where("name = ?", name).
 		ToSql()
@@ -71,6 +73,8 @@ func (r *Repository) GetByName(ctx context.Context, name string) (entity.Team, e
 
 	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
 		&rowTeam.ID,
+		&rowTeam.RequiredReviewers,
+		&rowTeam.ReviewSLAHours,
 		&rowTeam.CreatedAt,
 	)
 
@@ -98,6 +102,8 @@ func (r *Repository) GetAll(
 		Select(
 			"id",
 			"name",
+			"required_reviewers",
+			"review_sla_hours",
 			"created_at",
 		).
 		From("team").
@@ -154,6 +160,7 @@ func (r *Repository) DeactivateTeamMembers(ctx context.Context, teamName string)
 			u.name, 
 			u.team_id, 
 			t.name AS team_name, 
+			t.required_reviewers AS team_required_reviewers,
 			u.is_active, 
 			u.created_at
 		FROM updated_users u
@@ -0,0 +1,28 @@
+package repo_unavailability
+
+import (
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+type RowUnavailability struct {
+	ID        uuid.UUID `db:"id"`
+	UserID    string    `db:"user_id"`
+	StartsAt  time.Time `db:"starts_at"`
+	EndsAt    time.Time `db:"ends_at"`
+	Reason    string    `db:"reason"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (r *RowUnavailability) ToEntity() entity.Unavailability {
+	return entity.Unavailability{
+		ID:        r.ID,
+		UserID:    r.UserID,
+		StartsAt:  r.StartsAt,
+		EndsAt:    r.EndsAt,
+		Reason:    r.Reason,
+		CreatedAt: r.CreatedAt,
+	}
+}
@@ -0,0 +1,112 @@
+package repo_unavailability
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/4udiwe/avito-pr-service/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+type Repository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *Repository {
+	return &Repository{pg}
+}
+
+func (r *Repository) Create(ctx context.Context, userID string, startsAt, endsAt time.Time, reason string) (entity.Unavailability, error) {
+	logrus.Infof("UnavailabilityRepository.Create: adding unavailability for user %s", userID)
+
+	query, args, _ := r.Builder.Insert("user_unavailability").
+		Columns("user_id", "starts_at", "ends_at", "reason").
+		Values(userID, startsAt, endsAt, reason).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	row := RowUnavailability{
+		UserID:   userID,
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+		Reason:   reason,
+	}
+
+	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
+		&row.ID,
+		&row.CreatedAt,
+	)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == pgerrcode.ForeignKeyViolation {
+				logrus.Warnf("UnavailabilityRepository.Create: user %s not found", userID)
+				return entity.Unavailability{}, repository.ErrUserNotFound
+			}
+		}
+		logrus.Errorf("UnavailabilityRepository.Create: failed to add unavailability: %v", err)
+		return entity.Unavailability{}, err
+	}
+
+	logrus.Infof("UnavailabilityRepository.Create: unavailability %s added for user %s", row.ID, userID)
+	return row.ToEntity(), nil
+}
+
+func (r *Repository) ListByUser(ctx context.Context, userID string) ([]entity.Unavailability, error) {
+	logrus.Infof("UnavailabilityRepository.ListByUser: listing unavailability for user %s", userID)
+
+	query, args, _ := r.Builder.
+		Select("id", "user_id", "starts_at", "ends_at", "reason", "created_at").
+		From("user_unavailability").
+		Where("user_id = ?", userID).
+		OrderBy("starts_at ASC").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("UnavailabilityRepository.ListByUser: failed to list unavailability for user %s: %v", userID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsUnavailability, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowUnavailability])
+	if err != nil {
+		logrus.Errorf("UnavailabilityRepository.ListByUser: failed to scan rows for user %s: %v", userID, err)
+		return nil, err
+	}
+
+	periods := lo.Map(rowsUnavailability, func(r RowUnavailability, _ int) entity.Unavailability { return r.ToEntity() })
+
+	logrus.Infof("UnavailabilityRepository.ListByUser: found %d periods for user %s", len(periods), userID)
+	return periods, nil
+}
+
+func (r *Repository) Delete(ctx context.Context, ID uuid.UUID) error {
+	logrus.Infof("UnavailabilityRepository.Delete: deleting unavailability %s", ID)
+
+	query, args, _ := r.Builder.Delete("user_unavailability").
+		Where("id = ?", ID).
+		ToSql()
+
+	cmdTag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("UnavailabilityRepository.Delete: failed to delete unavailability %s: %v", ID, err)
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		logrus.Warnf("UnavailabilityRepository.Delete: no unavailability with ID %s", ID)
+		return repository.ErrUnavailabilityNotFound
+	}
+
+	logrus.Infof("UnavailabilityRepository.Delete: unavailability %s deleted", ID)
+	return nil
+}
@@ -8,20 +8,32 @@ import (
 )
 
 type RowUser struct {
-	ID        string    `db:"id"`
-	Name      string    `db:"name"`
-	TeamID    uuid.UUID `db:"team_id"`
-	TeamName  string    `db:"team_name"`
-	IsActive  bool      `db:"is_active"`
-	CreatedAt time.Time `db:"created_at"`
+	ID                    string    `db:"id"`
+	Name                  string    `db:"name"`
+	TeamID                uuid.UUID `db:"team_id"`
+	TeamName              string    `db:"team_name"`
+	TeamRequiredReviewers int       `db:"team_required_reviewers"`
+	IsActive              bool      `db:"is_active"`
+	CreatedAt             time.Time `db:"created_at"`
+}
+
+type RowUserWithTags struct {
+	RowUser
+	Tags []string `db:"tags"`
 }
 
 func (ru *RowUser) ToEntity() entity.User {
 	return entity.User{
 		ID:        ru.ID,
 		Name:      ru.Name,
-		Team:      entity.Team{ID: ru.TeamID, Name: ru.TeamName},
+		Team:      entity.Team{ID: ru.TeamID, Name: ru.TeamName, RequiredReviewers: ru.TeamRequiredReviewers},
 		IsActive:  ru.IsActive,
 		CreatedAt: ru.CreatedAt,
 	}
 }
+
+func (ru *RowUserWithTags) ToEntity() entity.User {
+	user := ru.RowUser.ToEntity()
+	user.Tags = ru.Tags
+	return user
+}
@@ -25,6 +25,12 @@ func New(pg *postgres.Postgres) *Repository {
 	return &Repository{pg}
 }
 
+// Skips users who have an unavailability period (vacation, out-of-office) covering the current moment
+const availableNow = `NOT EXISTS (
+	SELECT 1 FROM user_unavailability AS ua
+	WHERE ua.user_id = u.id AND ua.starts_at <= now() AND ua.ends_at > now()
+)`
+
 func (r *Repository) CreateUsersBatch(ctx context.Context, users []entity.User, teamID uuid.UUID) ([]entity.User, error) {
     logrus.Info("UserRepository.CreateUsersBatch: creating users")
 
@@ -84,7 +90,9 @@ func (r *Repository) GetByID(ctx context.Context, ID string) (entity.User, error
 			"u.name",
 			"u.is_active",
 			"u.team_id",
-			"t.name AS team_name",
+			// User removed from the team has no team
+			"COALESCE(t.name, '') AS team_name",
+			"COALESCE(t.required_reviewers, 0) AS team_required_reviewers",
 			"u.created_at",
 		).
 		From("app_user AS u").
@@ -99,6 +107,7 @@ func (r *Repository) GetByID(ctx context.Context, ID string) (entity.User, error
 		&row.IsActive,
 		&row.TeamID,
 		&row.TeamName,
+		&row.TeamRequiredReviewers,
 		&row.CreatedAt,
 	)
 
@@ -117,7 +126,10 @@ func (r *Repository) GetByID(ctx context.Context, ID string) (entity.User, error
 func (r *Repository) GetByTeamID(ctx context.Context, teamID uuid.UUID) ([]entity.User, error) {
 	logrus.Infof("UserRepository.GetByTeamID: getting users by team ID %s", teamID)
 
-	query, args, _ := r.Builder.Select("u.id", "u.name", "u.is_active", "u.team_id", "t.name AS team_name", "u.created_at").
+	query, args, _ := r.Builder.Select(
+		"u.id", "u.name", "u.is_active", "u.team_id", "t.name AS team_name", "t.required_reviewers AS team_required_reviewers", "u.created_at",
+		"ARRAY(SELECT ut.tag FROM user_tag AS ut WHERE ut.user_id = u.id ORDER BY ut.tag) AS tags",
+	).
 		From("app_user AS u").
 		Join("team AS t ON u.team_id = t.id").
 		Where("team_id = ?", teamID).
@@ -130,13 +142,13 @@ func (r *Repository) GetByTeamID(ctx context.Context, teamID uuid.UUID) ([]entit
 	}
 	defer rows.Close()
 
-	rowsUsers, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowUser])
+	rowsUsers, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowUserWithTags])
 	if err != nil {
 		logrus.Errorf("UserRepository.GetByTeamID: failed to scan user row for team ID %s: %v", teamID, err)
 		return nil, err
 	}
 
-	users := lo.Map(rowsUsers, func(r RowUser, _ int) entity.User { return r.ToEntity() })
+	users := lo.Map(rowsUsers, func(r RowUserWithTags, _ int) entity.User { return r.ToEntity() })
 
 	logrus.Infof("UserRepository.GetByTeamID: found %d users for team ID %s", len(users), teamID)
 	return users, nil
@@ -161,6 +173,45 @@ func (r *Repository) SetTeamID(ctx context.Context, userID string, teamID uuid.U
 	return nil
 }
 
+// Removes user from the team, user stays with reviews and history
+func (r *Repository) ClearTeamID(ctx context.Context, userID string) error {
+	logrus.Infof("UserRepository.ClearTeamID: removing user ID %s from team", userID)
+
+	query, args, _ := r.Builder.Update("app_user").
+		Set("team_id", nil).
+		Where("id = ?", userID).
+		ToSql()
+
+	cmdTag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("UserRepository.ClearTeamID: failed to remove user ID %s from team: %v", userID, err)
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return repository.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *Repository) SetName(ctx context.Context, userID, name string) error {
+	logrus.Infof("UserRepository.SetName: setting name %s for user ID %s", name, userID)
+
+	query, args, _ := r.Builder.Update("app_user").
+		Set("name", name).
+		Where("id = ?", userID).
+		ToSql()
+
+	cmdTag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("UserRepository.SetName: failed to set name for user ID %s: %v", userID, err)
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return repository.ErrUserNotFound
+	}
+	return nil
+}
+
 func (r *Repository) SetActiveStatus(ctx context.Context, userID string, isActive bool) error {
 	logrus.Infof("UserRepository.SetActiveStatus: setting isActive=%t for user ID %s", isActive, userID)
 
@@ -180,15 +231,35 @@ func (r *Repository) SetActiveStatus(ctx context.Context, userID string, isActiv
 	return nil
 }
 
+func (r *Repository) SetReviewWeight(ctx context.Context, userID string, weight float64) error {
+	logrus.Infof("UserRepository.SetReviewWeight: setting review weight %v for user ID %s", weight, userID)
+
+	query, args, _ := r.Builder.Update("app_user").
+		Set("review_weight", weight).
+		Where("id = ?", userID).
+		ToSql()
+
+	cmdTag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("UserRepository.SetReviewWeight: failed to set review weight %v for user ID %s: %v", weight, userID, err)
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return repository.ErrUserNotFound
+	}
+	return nil
+}
+
 // Used for assigning reviewers on a new PR, or reassigning one reviewer to another teammate
 func (r *Repository) GetRandomActiveTeammates(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error) {
 	logrus.Infof("UserRepository.GetRandomActiveTeammates: getting up to %d random active teammates for team ID %s", limit, teamID)
 
 	query, args, _ := r.Builder.
-		Select("u.id", "u.name", "u.team_id", "t.name AS team_name", "u.is_active", "u.created_at").
+		Select("u.id", "u.name", "u.team_id", "t.name AS team_name", "t.required_reviewers AS team_required_reviewers", "u.is_active", "u.created_at").
 		From("app_user AS u").
 		Join("team AS t ON u.team_id = t.id").
 		Where("u.team_id = ? AND is_active = TRUE", teamID).
+		Where(availableNow).
 		Where(squirrel.NotEq{"u.id": excludeIDs}).
 		OrderBy("RANDOM()").
 		Limit(uint64(limit)).
@@ -213,6 +284,243 @@ func (r *Repository) GetRandomActiveTeammates(ctx context.Context, teamID uuid.U
 	return users, nil
 }
 
+// Same as GetRandomActiveTeammates, but teammates with fewer open reviews go first.
+// Teammates with equal load are shuffled randomly
+func (r *Repository) GetLeastLoadedActiveTeammates(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error) {
+	logrus.Infof("UserRepository.GetLeastLoadedActiveTeammates: getting up to %d least loaded active teammates for team ID %s", limit, teamID)
+
+	query, args, _ := r.Builder.
+		Select("u.id", "u.name", "u.team_id", "t.name AS team_name", "t.required_reviewers AS team_required_reviewers", "u.is_active", "u.created_at").
+		From("app_user AS u").
+		Join("team AS t ON u.team_id = t.id").
+		LeftJoin(`(
+			SELECT r.reviewer_id, COUNT(*) AS open_reviews
+			FROM pr_reviewer AS r
+			JOIN pr AS p ON p.id = r.pr_id
+			JOIN pr_status AS s ON s.id = p.status_id
+			WHERE s.name = 'OPEN'
+			GROUP BY r.reviewer_id
+		) AS l ON l.reviewer_id = u.id`).
+		Where("u.team_id = ? AND is_active = TRUE", teamID).
+		Where(availableNow).
+		Where(squirrel.NotEq{"u.id": excludeIDs}).
+		OrderBy("COALESCE(l.open_reviews, 0) ASC", "RANDOM()").
+		Limit(uint64(limit)).
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("UserRepository.GetLeastLoadedActiveTeammates: failed to query least loaded active teammates: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsUsers, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowUser])
+	if err != nil {
+		logrus.Errorf("UserRepository.GetLeastLoadedActiveTeammates: failed to scan user row for team ID %s: %v", teamID, err)
+		return nil, err
+	}
+
+	users := lo.Map(rowsUsers, func(r RowUser, _ int) entity.User { return r.ToEntity() })
+
+	logrus.Infof("UserRepository.GetLeastLoadedActiveTeammates: found %d least loaded active teammates", len(users))
+	return users, nil
+}
+
+// Same as GetRandomActiveTeammates, but teammates who were assigned least recently go first.
+// Teammates who were never assigned go before everyone else
+func (r *Repository) GetRoundRobinActiveTeammates(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error) {
+	logrus.Infof("UserRepository.GetRoundRobinActiveTeammates: getting up to %d next active teammates for team ID %s", limit, teamID)
+
+	query, args, _ := r.Builder.
+		Select("u.id", "u.name", "u.team_id", "t.name AS team_name", "t.required_reviewers AS team_required_reviewers", "u.is_active", "u.created_at").
+		From("app_user AS u").
+		Join("team AS t ON u.team_id = t.id").
+		LeftJoin(`(
+			SELECT reviewer_id, MAX(assigned_at) AS last_assigned_at
+			FROM pr_reviewer
+			GROUP BY reviewer_id
+		) AS l ON l.reviewer_id = u.id`).
+		Where("u.team_id = ? AND is_active = TRUE", teamID).
+		Where(availableNow).
+		Where(squirrel.NotEq{"u.id": excludeIDs}).
+		OrderBy("l.last_assigned_at ASC NULLS FIRST", "u.id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("UserRepository.GetRoundRobinActiveTeammates: failed to query next active teammates: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsUsers, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowUser])
+	if err != nil {
+		logrus.Errorf("UserRepository.GetRoundRobinActiveTeammates: failed to scan user row for team ID %s: %v", teamID, err)
+		return nil, err
+	}
+
+	users := lo.Map(rowsUsers, func(r RowUser, _ int) entity.User { return r.ToEntity() })
+
+	logrus.Infof("UserRepository.GetRoundRobinActiveTeammates: found %d next active teammates", len(users))
+	return users, nil
+}
+
+// Same as GetRandomActiveTeammates, but chance to be picked is proportional to user's review_weight.
+// Uses weighted random sampling without replacement: key = -ln(U) / weight, smallest keys win
+func (r *Repository) GetWeightedActiveTeammates(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error) {
+	logrus.Infof("UserRepository.GetWeightedActiveTeammates: getting up to %d weighted random active teammates for team ID %s", limit, teamID)
+
+	query, args, _ := r.Builder.
+		Select("u.id", "u.name", "u.team_id", "t.name AS team_name", "t.required_reviewers AS team_required_reviewers", "u.is_active", "u.created_at").
+		From("app_user AS u").
+		Join("team AS t ON u.team_id = t.id").
+		Where("u.team_id = ? AND is_active = TRUE", teamID).
+		Where(availableNow).
+		Where(squirrel.NotEq{"u.id": excludeIDs}).
+		OrderBy("-LN(1 - RANDOM()) / u.review_weight ASC").
+		Limit(uint64(limit)).
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("UserRepository.GetWeightedActiveTeammates: failed to query weighted random active teammates: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsUsers, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowUser])
+	if err != nil {
+		logrus.Errorf("UserRepository.GetWeightedActiveTeammates: failed to scan user row for team ID %s: %v", teamID, err)
+		return nil, err
+	}
+
+	users := lo.Map(rowsUsers, func(r RowUser, _ int) entity.User { return r.ToEntity() })
+
+	logrus.Infof("UserRepository.GetWeightedActiveTeammates: found %d weighted random active teammates", len(users))
+	return users, nil
+}
+
+// Returns users from the list who are active and not away right now. Used for picking code owners as reviewers
+func (r *Repository) GetAvailableByIDs(ctx context.Context, IDs []string) ([]entity.User, error) {
+	logrus.Infof("UserRepository.GetAvailableByIDs: getting available users among %+v", IDs)
+
+	query, args, _ := r.Builder.
+		Select("u.id", "u.name", "u.team_id", "t.name AS team_name", "t.required_reviewers AS team_required_reviewers", "u.is_active", "u.created_at").
+		From("app_user AS u").
+		Join("team AS t ON u.team_id = t.id").
+		Where(squirrel.Eq{"u.id": IDs}).
+		Where("is_active = TRUE").
+		Where(availableNow).
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("UserRepository.GetAvailableByIDs: failed to query users: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsUsers, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowUser])
+	if err != nil {
+		logrus.Errorf("UserRepository.GetAvailableByIDs: failed to scan user rows: %v", err)
+		return nil, err
+	}
+
+	users := lo.Map(rowsUsers, func(r RowUser, _ int) entity.User { return r.ToEntity() })
+
+	logrus.Infof("UserRepository.GetAvailableByIDs: found %d available users", len(users))
+	return users, nil
+}
+
+// Active teammates having at least one of the tags. Teammates with more matching tags go first,
+// equal ones are shuffled randomly
+func (r *Repository) GetTagMatchedActiveTeammates(
+	ctx context.Context,
+	teamID uuid.UUID,
+	tags []string,
+	limit int,
+	excludeIDs ...string,
+) ([]entity.User, error) {
+	logrus.Infof("UserRepository.GetTagMatchedActiveTeammates: getting up to %d teammates with tags %+v for team ID %s", limit, tags, teamID)
+
+	query, args, _ := r.Builder.
+		Select("u.id", "u.name", "u.team_id", "t.name AS team_name", "t.required_reviewers AS team_required_reviewers", "u.is_active", "u.created_at").
+		From("app_user AS u").
+		Join("team AS t ON u.team_id = t.id").
+		JoinClause("JOIN user_tag AS ut ON ut.user_id = u.id").
+		Where("u.team_id = ? AND is_active = TRUE", teamID).
+		Where(availableNow).
+		Where(squirrel.Eq{"ut.tag": tags}).
+		Where(squirrel.NotEq{"u.id": excludeIDs}).
+		GroupBy("u.id", "t.id").
+		OrderBy("COUNT(ut.tag) DESC", "RANDOM()").
+		Limit(uint64(limit)).
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("UserRepository.GetTagMatchedActiveTeammates: failed to query teammates: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsUsers, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowUser])
+	if err != nil {
+		logrus.Errorf("UserRepository.GetTagMatchedActiveTeammates: failed to scan user row for team ID %s: %v", teamID, err)
+		return nil, err
+	}
+
+	users := lo.Map(rowsUsers, func(r RowUser, _ int) entity.User { return r.ToEntity() })
+
+	logrus.Infof("UserRepository.GetTagMatchedActiveTeammates: found %d teammates with matching tags", len(users))
+	return users, nil
+}
+
+// Same as GetRandomActiveUsers, but only members of the given teams are picked.
+// Used as a fallback when author's team has too few active reviewers
+func (r *Repository) GetRandomActiveUsersByTeams(
+	ctx context.Context,
+	teamNames []string,
+	limit int,
+	excludeIDs ...string,
+) ([]entity.User, error) {
+	logrus.Infof("UserRepository.GetRandomActiveUsersByTeams: getting %d random active users from teams %+v", limit, teamNames)
+
+	builder := r.Builder.
+		Select("u.id", "u.name", "u.team_id", "t.name AS team_name", "t.required_reviewers AS team_required_reviewers", "u.is_active", "u.created_at").
+		From("app_user AS u").
+		Join("team AS t ON u.team_id = t.id").
+		Where(squirrel.Eq{"t.name": teamNames}).
+		Where("is_active = TRUE").
+		Where(availableNow)
+
+	if len(excludeIDs) > 0 {
+		builder = builder.Where(squirrel.NotEq{"u.id": excludeIDs})
+	}
+
+	query, args, _ := builder.OrderBy("RANDOM()").Limit(uint64(limit)).ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("UserRepository.GetRandomActiveUsersByTeams: query failed: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsUsers, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowUser])
+	if err != nil {
+		logrus.Errorf("UserRepository.GetRandomActiveUsersByTeams: scan failed: %v", err)
+		return nil, err
+	}
+
+	users := lo.Map(rowsUsers, func(r RowUser, _ int) entity.User { return r.ToEntity() })
+
+	logrus.Infof("UserRepository.GetRandomActiveUsersByTeams: found %d users", len(users))
+	return users, nil
+}
+
 // Used for team deactivation method to search new random reviewrs from other teams
 func (r *Repository) GetRandomActiveUsers(
 	ctx context.Context,
@@ -222,10 +530,11 @@ func (r *Repository) GetRandomActiveUsers(
 	logrus.Infof("UserRepository.GetRandomActiveUsers: getting %d random active users, excluding %+v", limit, excludeIDs)
 
 	builder := r.Builder.
-		Select("u.id", "u.name", "u.team_id", "t.name AS team_name", "u.is_active", "u.created_at").
+		Select("u.id", "u.name", "u.team_id", "t.name AS team_name", "t.required_reviewers AS team_required_reviewers", "u.is_active", "u.created_at").
 		From("app_user AS u").
 		Join("team AS t ON u.team_id = t.id").
-		Where("is_active = TRUE")
+		Where("is_active = TRUE").
+		Where(availableNow)
 
 	if len(excludeIDs) > 0 {
 		builder = builder.Where(squirrel.NotEq{"u.id": excludeIDs})
@@ -251,3 +560,77 @@ func (r *Repository) GetRandomActiveUsers(
 	users := lo.Map(rowsUsers, func(r RowUser, _ int) entity.User { return r.ToEntity() })
 	return users, nil
 }
+
+func (r *Repository) GetTags(ctx context.Context, userID string) ([]string, error) {
+	logrus.Infof("UserRepository.GetTags: getting tags for user %s", userID)
+
+	query, args, _ := r.Builder.Select("tag").
+		From("user_tag").
+		Where("user_id = ?", userID).
+		OrderBy("tag ASC").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("UserRepository.GetTags: failed to query tags for user %s: %v", userID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		logrus.Errorf("UserRepository.GetTags: failed to scan tags for user %s: %v", userID, err)
+		return nil, err
+	}
+
+	logrus.Infof("UserRepository.GetTags: found %d tags for user %s", len(tags), userID)
+	return tags, nil
+}
+
+// Already existing tags are skipped
+func (r *Repository) AddTags(ctx context.Context, userID string, tags []string) error {
+	logrus.Infof("UserRepository.AddTags: adding tags %+v to user %s", tags, userID)
+
+	queryBuilder := r.Builder.Insert("user_tag").
+		Columns("user_id", "tag")
+
+	for _, tag := range tags {
+		queryBuilder = queryBuilder.Values(userID, tag)
+	}
+
+	query, args, _ := queryBuilder.Suffix("ON CONFLICT (user_id, tag) DO NOTHING").ToSql()
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgerrcode.ForeignKeyViolation {
+				logrus.Warnf("UserRepository.AddTags: user %s not found", userID)
+				return repository.ErrUserNotFound
+			}
+		}
+		logrus.Errorf("UserRepository.AddTags: failed to add tags to user %s: %v", userID, err)
+		return err
+	}
+
+	logrus.Infof("UserRepository.AddTags: tags added to user %s", userID)
+	return nil
+}
+
+func (r *Repository) RemoveTags(ctx context.Context, userID string, tags []string) error {
+	logrus.Infof("UserRepository.RemoveTags: removing tags %+v from user %s", tags, userID)
+
+	query, args, _ := r.Builder.Delete("user_tag").
+		Where("user_id = ?", userID).
+		Where(squirrel.Eq{"tag": tags}).
+		ToSql()
+
+	_, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("UserRepository.RemoveTags: failed to remove tags from user %s: %v", userID, err)
+		return err
+	}
+
+	logrus.Infof("UserRepository.RemoveTags: tags removed from user %s", userID)
+	return nil
+}
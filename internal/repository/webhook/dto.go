@@ -0,0 +1,62 @@
+package repo_webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type RowSubscription struct {
+	ID         uuid.UUID `db:"id"`
+	URL        string    `db:"url"`
+	Secret     string    `db:"secret"`
+	EventTypes []string  `db:"event_types"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+type RowDelivery struct {
+	ID             uuid.UUID       `db:"id"`
+	EventID        uuid.UUID       `db:"event_id"`
+	EventType      string          `db:"event_type"`
+	Payload        json.RawMessage `db:"payload"`
+	EventCreatedAt time.Time       `db:"event_created_at"`
+	SubscriptionID uuid.UUID       `db:"subscription_id"`
+	URL            string          `db:"url"`
+	Secret         string          `db:"secret"`
+	Status         string          `db:"status"`
+	Attempts       int             `db:"attempts"`
+	NextAttemptAt  time.Time       `db:"next_attempt_at"`
+	LastError      string          `db:"last_error"`
+	CreatedAt      time.Time       `db:"created_at"`
+}
+
+func (r *RowSubscription) ToEntity() entity.WebhookSubscription {
+	return entity.WebhookSubscription{
+		ID:         r.ID,
+		URL:        r.URL,
+		Secret:     r.Secret,
+		EventTypes: lo.Map(r.EventTypes, func(t string, _ int) entity.WebhookEventType { return entity.WebhookEventType(t) }),
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+func (r *RowDelivery) ToEntity() entity.WebhookDelivery {
+	return entity.WebhookDelivery{
+		ID:             r.ID,
+		EventID:        r.EventID,
+		EventType:      entity.WebhookEventType(r.EventType),
+		Payload:        r.Payload,
+		EventCreatedAt: r.EventCreatedAt,
+		SubscriptionID: r.SubscriptionID,
+		URL:            r.URL,
+		Secret:         r.Secret,
+		Status:         entity.WebhookDeliveryStatus(r.Status),
+		Attempts:       r.Attempts,
+		NextAttemptAt:  r.NextAttemptAt,
+		LastError:      r.LastError,
+		CreatedAt:      r.CreatedAt,
+	}
+}
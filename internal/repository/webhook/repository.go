@@ -0,0 +1,314 @@
+package repo_webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/4udiwe/avito-pr-service/pkg/postgres"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+type Repository struct {
+	*postgres.Postgres
+}
+
+func New(pg *postgres.Postgres) *Repository {
+	return &Repository{pg}
+}
+
+func (r *Repository) CreateSubscription(
+	ctx context.Context,
+	url, secret string,
+	eventTypes []entity.WebhookEventType,
+) (entity.WebhookSubscription, error) {
+	logrus.Infof("WebhookRepository.CreateSubscription: subscribing %s", url)
+
+	query, args, _ := r.Builder.Insert("webhook_subscription").
+		Columns("url", "secret", "event_types").
+		Values(url, secret, lo.Map(eventTypes, func(t entity.WebhookEventType, _ int) string { return string(t) })).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	subscription := entity.WebhookSubscription{
+		URL:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+	}
+
+	err := r.GetTxManager(ctx).QueryRow(ctx, query, args...).Scan(
+		&subscription.ID,
+		&subscription.CreatedAt,
+	)
+	if err != nil {
+		logrus.Errorf("WebhookRepository.CreateSubscription: failed to create subscription: %v", err)
+		return entity.WebhookSubscription{}, err
+	}
+
+	logrus.Infof("WebhookRepository.CreateSubscription: subscription %s created", subscription.ID)
+	return subscription, nil
+}
+
+func (r *Repository) GetSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	logrus.Info("WebhookRepository.GetSubscriptions: getting all subscriptions")
+
+	query, args, _ := r.Builder.
+		Select("id", "url", "secret", "event_types", "created_at").
+		From("webhook_subscription").
+		OrderBy("created_at ASC").
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("WebhookRepository.GetSubscriptions: failed to query subscriptions: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsSubscriptions, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowSubscription])
+	if err != nil {
+		logrus.Errorf("WebhookRepository.GetSubscriptions: failed to scan subscriptions: %v", err)
+		return nil, err
+	}
+
+	subscriptions := lo.Map(rowsSubscriptions, func(r RowSubscription, _ int) entity.WebhookSubscription { return r.ToEntity() })
+
+	logrus.Infof("WebhookRepository.GetSubscriptions: found %d subscriptions", len(subscriptions))
+	return subscriptions, nil
+}
+
+// Deletes subscription together with its pending and dead deliveries
+func (r *Repository) DeleteSubscription(ctx context.Context, ID uuid.UUID) error {
+	logrus.Infof("WebhookRepository.DeleteSubscription: deleting subscription %s", ID)
+
+	query, args, _ := r.Builder.Delete("webhook_subscription").
+		Where("id = ?", ID).
+		ToSql()
+
+	tag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("WebhookRepository.DeleteSubscription: failed to delete subscription %s: %v", ID, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		logrus.Warnf("WebhookRepository.DeleteSubscription: subscription %s not found", ID)
+		return repository.ErrWebhookNotFound
+	}
+
+	logrus.Infof("WebhookRepository.DeleteSubscription: subscription %s deleted", ID)
+	return nil
+}
+
+// Stores events with a pending delivery for every matching subscription.
+// Already stored events are skipped, so relaying the same outbox message again is harmless
+func (r *Repository) Enqueue(ctx context.Context, events []entity.WebhookEvent) error {
+	query := `
+		WITH event AS (
+			INSERT INTO webhook_event (id, type, payload, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (id) DO NOTHING
+			RETURNING id
+		)
+		INSERT INTO webhook_delivery (event_id, subscription_id)
+		SELECT event.id, s.id
+		FROM event, webhook_subscription AS s
+		WHERE cardinality(s.event_types) = 0 OR $2 = ANY(s.event_types);
+	`
+
+	for _, event := range events {
+		payload, err := json.Marshal(event.Payload)
+		if err != nil {
+			return fmt.Errorf("marshal %s payload: %w", event.Type, err)
+		}
+
+		if _, err := r.GetTxManager(ctx).Exec(ctx, query, event.ID, string(event.Type), payload, event.CreatedAt); err != nil {
+			logrus.Errorf("WebhookRepository.Enqueue: failed to enqueue %s event: %v", event.Type, err)
+			return err
+		}
+	}
+
+	logrus.Infof("WebhookRepository.Enqueue: enqueued %d events", len(events))
+	return nil
+}
+
+// Takes up to limit due pending deliveries and postpones them by lease, so that other
+// dispatchers skip them while they are being sent. Concurrent callers never get the same delivery
+func (r *Repository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	query := postgres.ClaimQuery("webhook_delivery", "status = 'PENDING' AND next_attempt_at <= now()", "next_attempt_at") + `
+		SELECT
+			d.id,
+			d.event_id,
+			e.type AS event_type,
+			e.payload,
+			e.created_at AS event_created_at,
+			d.subscription_id,
+			s.url,
+			s.secret,
+			d.status,
+			d.attempts,
+			d.next_attempt_at,
+			d.last_error,
+			d.created_at
+		FROM claimed AS d
+		JOIN webhook_event AS e ON e.id = d.event_id
+		JOIN webhook_subscription AS s ON s.id = d.subscription_id
+		ORDER BY d.created_at;
+	`
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		logrus.Errorf("WebhookRepository.ClaimDueDeliveries: failed to claim deliveries: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsDeliveries, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowDelivery])
+	if err != nil {
+		logrus.Errorf("WebhookRepository.ClaimDueDeliveries: failed to scan deliveries: %v", err)
+		return nil, err
+	}
+
+	return lo.Map(rowsDeliveries, func(r RowDelivery, _ int) entity.WebhookDelivery { return r.ToEntity() }), nil
+}
+
+func (r *Repository) MarkDelivered(ctx context.Context, ID uuid.UUID, attempts int) error {
+	query, args, _ := r.Builder.Update("webhook_delivery").
+		Set("status", string(entity.WebhookDeliveryDelivered)).
+		Set("attempts", attempts).
+		Set("last_error", "").
+		Set("delivered_at", squirrel.Expr("now()")).
+		Where("id = ?", ID).
+		ToSql()
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		logrus.Errorf("WebhookRepository.MarkDelivered: failed to update delivery %s: %v", ID, err)
+		return err
+	}
+	return nil
+}
+
+// Records failed attempt. Delivery is retried at nextAttemptAt, or moved to the dead-letter list when it is nil
+func (r *Repository) MarkFailed(ctx context.Context, ID uuid.UUID, attempts int, nextAttemptAt *time.Time, lastError string) error {
+	builder := r.Builder.Update("webhook_delivery").
+		Set("attempts", attempts).
+		Set("last_error", lastError).
+		Where("id = ?", ID)
+
+	if nextAttemptAt != nil {
+		builder = builder.Set("next_attempt_at", *nextAttemptAt)
+	} else {
+		builder = builder.Set("status", string(entity.WebhookDeliveryDead))
+	}
+
+	query, args, _ := builder.ToSql()
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		logrus.Errorf("WebhookRepository.MarkFailed: failed to update delivery %s: %v", ID, err)
+		return err
+	}
+	return nil
+}
+
+// Deletes deliveries delivered before the given moment, then events created before it that have no deliveries left.
+// Dead and pending deliveries are kept together with their events. Returns number of deleted deliveries
+func (r *Repository) DeleteDelivered(ctx context.Context, before time.Time) (int, error) {
+	query, args, _ := r.Builder.Delete("webhook_delivery").
+		Where("status = ? AND delivered_at < ?", string(entity.WebhookDeliveryDelivered), before).
+		ToSql()
+
+	tag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("WebhookRepository.DeleteDelivered: failed to delete deliveries: %v", err)
+		return 0, err
+	}
+
+	query, args, _ = r.Builder.Delete("webhook_event AS e").
+		Where("e.created_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM webhook_delivery AS d WHERE d.event_id = e.id)").
+		ToSql()
+
+	if _, err := r.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		logrus.Errorf("WebhookRepository.DeleteDelivered: failed to delete events: %v", err)
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// Dead deliveries, the latest first
+func (r *Repository) GetDeadDeliveries(ctx context.Context, limit int) ([]entity.WebhookDelivery, error) {
+	logrus.Info("WebhookRepository.GetDeadDeliveries: getting dead deliveries")
+
+	query, args, _ := r.Builder.
+		Select(
+			"d.id",
+			"d.event_id",
+			"e.type AS event_type",
+			"e.payload",
+			"e.created_at AS event_created_at",
+			"d.subscription_id",
+			"s.url",
+			"s.secret",
+			"d.status",
+			"d.attempts",
+			"d.next_attempt_at",
+			"d.last_error",
+			"d.created_at",
+		).
+		From("webhook_delivery AS d").
+		Join("webhook_event AS e ON e.id = d.event_id").
+		Join("webhook_subscription AS s ON s.id = d.subscription_id").
+		Where("d.status = ?", string(entity.WebhookDeliveryDead)).
+		OrderBy("d.created_at DESC").
+		Limit(uint64(limit)).
+		ToSql()
+
+	rows, err := r.GetTxManager(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("WebhookRepository.GetDeadDeliveries: failed to query deliveries: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rowsDeliveries, err := pgx.CollectRows(rows, pgx.RowToStructByName[RowDelivery])
+	if err != nil {
+		logrus.Errorf("WebhookRepository.GetDeadDeliveries: failed to scan deliveries: %v", err)
+		return nil, err
+	}
+
+	deliveries := lo.Map(rowsDeliveries, func(r RowDelivery, _ int) entity.WebhookDelivery { return r.ToEntity() })
+
+	logrus.Infof("WebhookRepository.GetDeadDeliveries: found %d dead deliveries", len(deliveries))
+	return deliveries, nil
+}
+
+// Moves dead delivery back to pending with attempts reset
+func (r *Repository) RetryDelivery(ctx context.Context, ID uuid.UUID) error {
+	logrus.Infof("WebhookRepository.RetryDelivery: retrying delivery %s", ID)
+
+	query, args, _ := r.Builder.Update("webhook_delivery").
+		Set("status", string(entity.WebhookDeliveryPending)).
+		Set("attempts", 0).
+		Set("next_attempt_at", squirrel.Expr("now()")).
+		Where("id = ? AND status = ?", ID, string(entity.WebhookDeliveryDead)).
+		ToSql()
+
+	tag, err := r.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		logrus.Errorf("WebhookRepository.RetryDelivery: failed to retry delivery %s: %v", ID, err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		logrus.Warnf("WebhookRepository.RetryDelivery: dead delivery %s not found", ID)
+		return repository.ErrWebhookDeliveryNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package codeowner
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
+type CodeOwnerRepo interface {
+	Create(ctx context.Context, pattern, userID string, teamID uuid.UUID) (entity.CodeOwner, error)
+	GetAll(ctx context.Context) ([]entity.CodeOwner, error)
+	Delete(ctx context.Context, ID uuid.UUID) error
+}
+
+type TeamRepo interface {
+	GetByName(ctx context.Context, name string) (entity.Team, error)
+}
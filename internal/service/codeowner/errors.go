@@ -0,0 +1,13 @@
+package codeowner
+
+import "errors"
+
+var (
+	ErrInvalidCodeOwner      = errors.New("code owner must have a pattern and exactly one of user or team")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrTeamNotFound          = errors.New("team not found")
+	ErrCodeOwnerNotFound     = errors.New("code owner not found")
+	ErrCannotAddCodeOwner    = errors.New("cannot add code owner")
+	ErrCannotGetCodeOwners   = errors.New("cannot get code owners")
+	ErrCannotDeleteCodeOwner = errors.New("cannot delete code owner")
+)
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contracts.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/4udiwe/avito-pr-service/internal/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCodeOwnerRepo is a mock of CodeOwnerRepo interface.
+type MockCodeOwnerRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCodeOwnerRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockCodeOwnerRepoMockRecorder is the mock recorder for MockCodeOwnerRepo.
+type MockCodeOwnerRepoMockRecorder struct {
+	mock *MockCodeOwnerRepo
+}
+
+// NewMockCodeOwnerRepo creates a new mock instance.
+func NewMockCodeOwnerRepo(ctrl *gomock.Controller) *MockCodeOwnerRepo {
+	mock := &MockCodeOwnerRepo{ctrl: ctrl}
+	mock.recorder = &MockCodeOwnerRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCodeOwnerRepo) EXPECT() *MockCodeOwnerRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCodeOwnerRepo) Create(ctx context.Context, pattern, userID string, teamID uuid.UUID) (entity.CodeOwner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, pattern, userID, teamID)
+	ret0, _ := ret[0].(entity.CodeOwner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCodeOwnerRepoMockRecorder) Create(ctx, pattern, userID, teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCodeOwnerRepo)(nil).Create), ctx, pattern, userID, teamID)
+}
+
+// Delete mocks base method.
+func (m *MockCodeOwnerRepo) Delete(ctx context.Context, ID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCodeOwnerRepoMockRecorder) Delete(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCodeOwnerRepo)(nil).Delete), ctx, ID)
+}
+
+// GetAll mocks base method.
+func (m *MockCodeOwnerRepo) GetAll(ctx context.Context) ([]entity.CodeOwner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]entity.CodeOwner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCodeOwnerRepoMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCodeOwnerRepo)(nil).GetAll), ctx)
+}
+
+// MockTeamRepo is a mock of TeamRepo interface.
+type MockTeamRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockTeamRepoMockRecorder is the mock recorder for MockTeamRepo.
+type MockTeamRepoMockRecorder struct {
+	mock *MockTeamRepo
+}
+
+// NewMockTeamRepo creates a new mock instance.
+func NewMockTeamRepo(ctrl *gomock.Controller) *MockTeamRepo {
+	mock := &MockTeamRepo{ctrl: ctrl}
+	mock.recorder = &MockTeamRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamRepo) EXPECT() *MockTeamRepoMockRecorder {
+	return m.recorder
+}
+
+// GetByName mocks base method.
+func (m *MockTeamRepo) GetByName(ctx context.Context, name string) (entity.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, name)
+	ret0, _ := ret[0].(entity.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockTeamRepoMockRecorder) GetByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockTeamRepo)(nil).GetByName), ctx, name)
+}
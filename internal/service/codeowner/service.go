@@ -0,0 +1,92 @@
+package codeowner
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type Service struct {
+	codeOwnerRepo CodeOwnerRepo
+	teamRepo      TeamRepo
+}
+
+func New(codeOwnerRepo CodeOwnerRepo, teamRepo TeamRepo) *Service {
+	return &Service{
+		codeOwnerRepo: codeOwnerRepo,
+		teamRepo:      teamRepo,
+	}
+}
+
+// Registers a path pattern owned by a user or by a team (exactly one of userID and teamName)
+func (s *Service) AddCodeOwner(ctx context.Context, pattern, userID, teamName string) (entity.CodeOwner, error) {
+	logrus.Infof("CodeOwnerService.AddCodeOwner: adding owner for pattern %s", pattern)
+
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" || (userID == "") == (teamName == "") {
+		return entity.CodeOwner{}, ErrInvalidCodeOwner
+	}
+
+	var teamID uuid.UUID
+	if teamName != "" {
+		team, err := s.teamRepo.GetByName(ctx, teamName)
+		if err != nil {
+			if errors.Is(err, repository.ErrTeamNotFound) {
+				return entity.CodeOwner{}, ErrTeamNotFound
+			}
+			logrus.Errorf("CodeOwnerService.AddCodeOwner: failed to get team %s: %v", teamName, err)
+			return entity.CodeOwner{}, ErrCannotAddCodeOwner
+		}
+		teamID = team.ID
+	}
+
+	owner, err := s.codeOwnerRepo.Create(ctx, pattern, userID, teamID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return entity.CodeOwner{}, ErrUserNotFound
+		}
+		if errors.Is(err, repository.ErrTeamNotFound) {
+			return entity.CodeOwner{}, ErrTeamNotFound
+		}
+		logrus.Errorf("CodeOwnerService.AddCodeOwner: failed to add owner for pattern %s: %v", pattern, err)
+		return entity.CodeOwner{}, ErrCannotAddCodeOwner
+	}
+	owner.TeamName = teamName
+
+	logrus.Infof("CodeOwnerService.AddCodeOwner: code owner %s added for pattern %s", owner.ID, pattern)
+	return owner, nil
+}
+
+func (s *Service) GetCodeOwners(ctx context.Context) ([]entity.CodeOwner, error) {
+	logrus.Info("CodeOwnerService.GetCodeOwners: fetching code owners")
+
+	owners, err := s.codeOwnerRepo.GetAll(ctx)
+	if err != nil {
+		logrus.Errorf("CodeOwnerService.GetCodeOwners: failed to fetch code owners: %v", err)
+		return nil, ErrCannotGetCodeOwners
+	}
+
+	logrus.Infof("CodeOwnerService.GetCodeOwners: fetched %d code owners", len(owners))
+	return owners, nil
+}
+
+func (s *Service) DeleteCodeOwner(ctx context.Context, ID uuid.UUID) error {
+	logrus.Infof("CodeOwnerService.DeleteCodeOwner: deleting code owner %s", ID)
+
+	err := s.codeOwnerRepo.Delete(ctx, ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrCodeOwnerNotFound) {
+			return ErrCodeOwnerNotFound
+		}
+		logrus.Errorf("CodeOwnerService.DeleteCodeOwner: failed to delete code owner %s: %v", ID, err)
+		return ErrCannotDeleteCodeOwner
+	}
+
+	logrus.Infof("CodeOwnerService.DeleteCodeOwner: code owner %s deleted", ID)
+	return nil
+}
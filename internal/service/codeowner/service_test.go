@@ -0,0 +1,104 @@
+package codeowner_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	service "github.com/4udiwe/avito-pr-service/internal/service/codeowner"
+	"github.com/4udiwe/avito-pr-service/internal/service/codeowner/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAddCodeOwner(t *testing.T) {
+	ctx := context.Background()
+	team := entity.Team{ID: uuid.New(), Name: "infra"}
+	ownerID := uuid.New()
+
+	tests := []struct {
+		name        string
+		pattern     string
+		userID      string
+		teamName    string
+		setup       func(c *mocks.MockCodeOwnerRepo, tr *mocks.MockTeamRepo)
+		expected    entity.CodeOwner
+		expectedErr error
+	}{
+		{
+			name:        "empty pattern",
+			pattern:     " ",
+			userID:      "u1",
+			setup:       func(c *mocks.MockCodeOwnerRepo, tr *mocks.MockTeamRepo) {},
+			expectedErr: service.ErrInvalidCodeOwner,
+		},
+		{
+			name:        "both user and team",
+			pattern:     "*.go",
+			userID:      "u1",
+			teamName:    team.Name,
+			setup:       func(c *mocks.MockCodeOwnerRepo, tr *mocks.MockTeamRepo) {},
+			expectedErr: service.ErrInvalidCodeOwner,
+		},
+		{
+			name:     "team not found",
+			pattern:  "deploy/",
+			teamName: team.Name,
+			setup: func(c *mocks.MockCodeOwnerRepo, tr *mocks.MockTeamRepo) {
+				tr.EXPECT().GetByName(gomock.Any(), team.Name).Return(entity.Team{}, repository.ErrTeamNotFound)
+			},
+			expectedErr: service.ErrTeamNotFound,
+		},
+		{
+			name:    "user not found",
+			pattern: "*.go",
+			userID:  "u1",
+			setup: func(c *mocks.MockCodeOwnerRepo, tr *mocks.MockTeamRepo) {
+				c.EXPECT().Create(gomock.Any(), "*.go", "u1", uuid.Nil).Return(entity.CodeOwner{}, repository.ErrUserNotFound)
+			},
+			expectedErr: service.ErrUserNotFound,
+		},
+		{
+			name:    "repo error",
+			pattern: "*.go",
+			userID:  "u1",
+			setup: func(c *mocks.MockCodeOwnerRepo, tr *mocks.MockTeamRepo) {
+				c.EXPECT().Create(gomock.Any(), "*.go", "u1", uuid.Nil).Return(entity.CodeOwner{}, errors.New("db"))
+			},
+			expectedErr: service.ErrCannotAddCodeOwner,
+		},
+		{
+			name:     "team owner",
+			pattern:  "deploy/",
+			teamName: team.Name,
+			setup: func(c *mocks.MockCodeOwnerRepo, tr *mocks.MockTeamRepo) {
+				tr.EXPECT().GetByName(gomock.Any(), team.Name).Return(team, nil)
+				c.EXPECT().Create(gomock.Any(), "deploy/", "", team.ID).
+					Return(entity.CodeOwner{ID: ownerID, Pattern: "deploy/", TeamID: team.ID}, nil)
+			},
+			expected: entity.CodeOwner{ID: ownerID, Pattern: "deploy/", TeamID: team.ID, TeamName: team.Name},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCodeOwnerRepo := mocks.NewMockCodeOwnerRepo(ctrl)
+			mockTeamRepo := mocks.NewMockTeamRepo(ctrl)
+
+			tt.setup(mockCodeOwnerRepo, mockTeamRepo)
+
+			s := service.New(mockCodeOwnerRepo, mockTeamRepo)
+
+			out, err := s.AddCodeOwner(ctx, tt.pattern, tt.userID, tt.teamName)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
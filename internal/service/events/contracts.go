@@ -0,0 +1,18 @@
+package events
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
+type HistoryRepo interface {
+	Create(ctx context.Context, events []entity.AssignmentEvent) error
+}
+
+// Outbox of domain events
+type Publisher interface {
+	Publish(ctx context.Context, events ...entity.DomainEvent) error
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contracts.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/4udiwe/avito-pr-service/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHistoryRepo is a mock of HistoryRepo interface.
+type MockHistoryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockHistoryRepoMockRecorder is the mock recorder for MockHistoryRepo.
+type MockHistoryRepoMockRecorder struct {
+	mock *MockHistoryRepo
+}
+
+// NewMockHistoryRepo creates a new mock instance.
+func NewMockHistoryRepo(ctrl *gomock.Controller) *MockHistoryRepo {
+	mock := &MockHistoryRepo{ctrl: ctrl}
+	mock.recorder = &MockHistoryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryRepo) EXPECT() *MockHistoryRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockHistoryRepo) Create(ctx context.Context, events []entity.AssignmentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHistoryRepoMockRecorder) Create(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHistoryRepo)(nil).Create), ctx, events)
+}
+
+// MockPublisher is a mock of Publisher interface.
+type MockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockPublisherMockRecorder is the mock recorder for MockPublisher.
+type MockPublisherMockRecorder struct {
+	mock *MockPublisher
+}
+
+// NewMockPublisher creates a new mock instance.
+func NewMockPublisher(ctrl *gomock.Controller) *MockPublisher {
+	mock := &MockPublisher{ctrl: ctrl}
+	mock.recorder = &MockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisher) EXPECT() *MockPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockPublisher) Publish(ctx context.Context, events ...entity.DomainEvent) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Publish", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPublisherMockRecorder) Publish(ctx any, events ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPublisher)(nil).Publish), varargs...)
+}
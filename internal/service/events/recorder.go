@@ -0,0 +1,39 @@
+package events
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+// Recorder writes reviewer assignment history and domain events of a change in its transaction.
+// Shared by the services that change PRs, teams and users
+type Recorder struct {
+	historyRepo HistoryRepo
+
+	// Nil when domain events are disabled
+	publisher Publisher
+}
+
+func NewRecorder(historyRepo HistoryRepo, publisher Publisher) *Recorder {
+	return &Recorder{
+		historyRepo: historyRepo,
+		publisher:   publisher,
+	}
+}
+
+// Writes domain events to the outbox, does nothing when events are disabled. Must be called inside a transaction
+func (r *Recorder) Publish(ctx context.Context, events ...entity.DomainEvent) error {
+	if r.publisher == nil || len(events) == 0 {
+		return nil
+	}
+	return r.publisher.Publish(ctx, events...)
+}
+
+// Writes assignment events to history and publishes them as domain events in the same transaction
+func (r *Recorder) RecordAssignments(ctx context.Context, events []entity.AssignmentEvent) error {
+	if err := r.historyRepo.Create(ctx, events); err != nil {
+		return err
+	}
+	return r.Publish(ctx, entity.AssignmentDomainEvents(events)...)
+}
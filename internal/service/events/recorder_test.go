@@ -0,0 +1,84 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/service/events"
+	"github.com/4udiwe/avito-pr-service/internal/service/events/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRecorder_RecordAssignments(t *testing.T) {
+	ctx := context.Background()
+	assignments := []entity.AssignmentEvent{
+		{PRID: "pr1", Type: entity.AssignmentEventAssign, ReviewerID: "u1", Actor: "alice"},
+	}
+
+	tests := []struct {
+		name        string
+		withEvents  bool
+		setup       func(h *mocks.MockHistoryRepo, p *mocks.MockPublisher)
+		expectedErr error
+	}{
+		{
+			name: "events disabled",
+			setup: func(h *mocks.MockHistoryRepo, p *mocks.MockPublisher) {
+				h.EXPECT().Create(gomock.Any(), assignments).Return(nil)
+			},
+		},
+		{
+			name:       "history and events written",
+			withEvents: true,
+			setup: func(h *mocks.MockHistoryRepo, p *mocks.MockPublisher) {
+				h.EXPECT().Create(gomock.Any(), assignments).Return(nil)
+				p.EXPECT().Publish(gomock.Any(), entity.AssignmentDomainEvents(assignments)[0]).Return(nil)
+			},
+		},
+		{
+			name:       "history error",
+			withEvents: true,
+			setup: func(h *mocks.MockHistoryRepo, p *mocks.MockPublisher) {
+				h.EXPECT().Create(gomock.Any(), assignments).Return(errors.New("db"))
+			},
+			expectedErr: errors.New("db"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			history := mocks.NewMockHistoryRepo(ctrl)
+			publisher := mocks.NewMockPublisher(ctrl)
+			tt.setup(history, publisher)
+
+			var recorder *events.Recorder
+			if tt.withEvents {
+				recorder = events.NewRecorder(history, publisher)
+			} else {
+				recorder = events.NewRecorder(history, nil)
+			}
+
+			err := recorder.RecordAssignments(ctx, assignments)
+			if (err == nil) != (tt.expectedErr == nil) {
+				t.Fatalf("expected: %v, got: %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRecorder_Publish_NoEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Publisher is not called without events
+	recorder := events.NewRecorder(nil, mocks.NewMockPublisher(ctrl))
+
+	if err := recorder.Publish(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
+type IdempotencyRepo interface {
+	Reserve(ctx context.Context, key, scope, requestHash string, ttl, lockTimeout time.Duration) (uuid.UUID, bool, error)
+	Get(ctx context.Context, key, scope string) (entity.IdempotencyRecord, error)
+	Complete(ctx context.Context, key, scope string, token uuid.UUID, statusCode int, contentType string, body []byte) error
+	Delete(ctx context.Context, key, scope string, token uuid.UUID) error
+	DeleteExpired(ctx context.Context) (int, error)
+}
@@ -0,0 +1,12 @@
+package idempotency
+
+import "errors"
+
+var (
+	ErrInvalidKey         = errors.New("idempotency key must be 1 to 255 characters long")
+	ErrKeyReused          = errors.New("idempotency key was already used with a different request")
+	ErrRequestInProgress  = errors.New("request with this idempotency key is still in progress")
+	ErrCannotCheckKey     = errors.New("cannot check idempotency key")
+	ErrCannotSaveResponse = errors.New("cannot save idempotent response")
+	ErrCannotDeleteKeys   = errors.New("cannot delete expired idempotency keys")
+)
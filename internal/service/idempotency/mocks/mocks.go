@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contracts.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/4udiwe/avito-pr-service/internal/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIdempotencyRepo is a mock of IdempotencyRepo interface.
+type MockIdempotencyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockIdempotencyRepoMockRecorder is the mock recorder for MockIdempotencyRepo.
+type MockIdempotencyRepoMockRecorder struct {
+	mock *MockIdempotencyRepo
+}
+
+// NewMockIdempotencyRepo creates a new mock instance.
+func NewMockIdempotencyRepo(ctrl *gomock.Controller) *MockIdempotencyRepo {
+	mock := &MockIdempotencyRepo{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyRepo) EXPECT() *MockIdempotencyRepoMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockIdempotencyRepo) Complete(ctx context.Context, key, scope string, token uuid.UUID, statusCode int, contentType string, body []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, key, scope, token, statusCode, contentType, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIdempotencyRepoMockRecorder) Complete(ctx, key, scope, token, statusCode, contentType, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdempotencyRepo)(nil).Complete), ctx, key, scope, token, statusCode, contentType, body)
+}
+
+// Delete mocks base method.
+func (m *MockIdempotencyRepo) Delete(ctx context.Context, key, scope string, token uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key, scope, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIdempotencyRepoMockRecorder) Delete(ctx, key, scope, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIdempotencyRepo)(nil).Delete), ctx, key, scope, token)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockIdempotencyRepo) DeleteExpired(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockIdempotencyRepoMockRecorder) DeleteExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockIdempotencyRepo)(nil).DeleteExpired), ctx)
+}
+
+// Get mocks base method.
+func (m *MockIdempotencyRepo) Get(ctx context.Context, key, scope string) (entity.IdempotencyRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key, scope)
+	ret0, _ := ret[0].(entity.IdempotencyRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIdempotencyRepoMockRecorder) Get(ctx, key, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIdempotencyRepo)(nil).Get), ctx, key, scope)
+}
+
+// Reserve mocks base method.
+func (m *MockIdempotencyRepo) Reserve(ctx context.Context, key, scope, requestHash string, ttl, lockTimeout time.Duration) (uuid.UUID, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, key, scope, requestHash, ttl, lockTimeout)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockIdempotencyRepoMockRecorder) Reserve(ctx, key, scope, requestHash, ttl, lockTimeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockIdempotencyRepo)(nil).Reserve), ctx, key, scope, requestHash, ttl, lockTimeout)
+}
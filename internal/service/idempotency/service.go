@@ -0,0 +1,124 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	maxKeyLength = 255
+
+	defaultTTL         = 24 * time.Hour
+	defaultLockTimeout = time.Minute
+)
+
+type Service struct {
+	repo IdempotencyRepo
+
+	// How long responses are replayed
+	ttl time.Duration
+	// After this time a request that never completed (e.g. the replica crashed) no longer holds the key,
+	// so it has to be longer than any handler runs: a retry after the timeout is executed again
+	lockTimeout time.Duration
+}
+
+func New(repo IdempotencyRepo, ttl, lockTimeout time.Duration) *Service {
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	if lockTimeout <= 0 {
+		lockTimeout = defaultLockTimeout
+	}
+	return &Service{
+		repo:        repo,
+		ttl:         ttl,
+		lockTimeout: lockTimeout,
+	}
+}
+
+// Begin reserves the key for the request and returns the reservation, its token is passed to Complete or Release.
+// When the key was already used with the same request its stored response is returned with replay = true.
+// The same key with a different request is rejected, as well as a duplicate that arrives while the first request is still running
+func (s *Service) Begin(ctx context.Context, key, scope, requestHash string) (entity.IdempotencyRecord, bool, error) {
+	if key == "" || len(key) > maxKeyLength {
+		return entity.IdempotencyRecord{}, false, ErrInvalidKey
+	}
+
+	token, reserved, err := s.repo.Reserve(ctx, key, scope, requestHash, s.ttl, s.lockTimeout)
+	if err != nil {
+		logrus.Errorf("IdempotencyService.Begin: failed to reserve key %s for %s: %v", key, scope, err)
+		return entity.IdempotencyRecord{}, false, ErrCannotCheckKey
+	}
+	if reserved {
+		return entity.IdempotencyRecord{Key: key, Scope: scope, RequestHash: requestHash, Token: token}, false, nil
+	}
+
+	record, err := s.repo.Get(ctx, key, scope)
+	if err != nil {
+		if errors.Is(err, repository.ErrIdempotencyKeyNotFound) {
+			// Released by the first request right now, the client may retry
+			return entity.IdempotencyRecord{}, false, ErrRequestInProgress
+		}
+		logrus.Errorf("IdempotencyService.Begin: failed to get key %s for %s: %v", key, scope, err)
+		return entity.IdempotencyRecord{}, false, ErrCannotCheckKey
+	}
+
+	if record.RequestHash != requestHash {
+		logrus.Warnf("IdempotencyService.Begin: key %s for %s reused with a different request", key, scope)
+		return entity.IdempotencyRecord{}, false, ErrKeyReused
+	}
+	if !record.Completed() {
+		return entity.IdempotencyRecord{}, false, ErrRequestInProgress
+	}
+
+	logrus.Infof("IdempotencyService.Begin: replaying response %d for key %s of %s", record.StatusCode, key, scope)
+	return record, true, nil
+}
+
+// Complete stores response of the request that holds the reservation with the token. Server errors are not stored,
+// the key is released instead, so that the retry is executed again. Response of a request whose reservation
+// has been taken over after the lock timeout is not stored
+func (s *Service) Complete(ctx context.Context, key, scope string, token uuid.UUID, statusCode int, contentType string, body []byte) error {
+	if statusCode >= http.StatusInternalServerError {
+		return s.Release(ctx, key, scope, token)
+	}
+
+	if err := s.repo.Complete(ctx, key, scope, token, statusCode, contentType, body); err != nil {
+		if errors.Is(err, repository.ErrIdempotencyKeyNotFound) {
+			logrus.Warnf("IdempotencyService.Complete: reservation of key %s of %s has been taken over, response is not stored", key, scope)
+			return ErrCannotSaveResponse
+		}
+		logrus.Errorf("IdempotencyService.Complete: failed to save response for key %s of %s: %v", key, scope, err)
+		return ErrCannotSaveResponse
+	}
+	return nil
+}
+
+// Release frees the key without storing a response. A newer reservation of the key is kept
+func (s *Service) Release(ctx context.Context, key, scope string, token uuid.UUID) error {
+	if err := s.repo.Delete(ctx, key, scope, token); err != nil {
+		logrus.Errorf("IdempotencyService.Release: failed to release key %s of %s: %v", key, scope, err)
+		return ErrCannotSaveResponse
+	}
+	return nil
+}
+
+// DeleteExpired removes records whose TTL has passed, returns the number of removed records
+func (s *Service) DeleteExpired(ctx context.Context) (int, error) {
+	count, err := s.repo.DeleteExpired(ctx)
+	if err != nil {
+		logrus.Errorf("IdempotencyService.DeleteExpired: %v", err)
+		return 0, ErrCannotDeleteKeys
+	}
+	if count > 0 {
+		logrus.Infof("IdempotencyService.DeleteExpired: deleted %d expired keys", count)
+	}
+	return count, nil
+}
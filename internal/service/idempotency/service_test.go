@@ -0,0 +1,194 @@
+package idempotency_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	service "github.com/4udiwe/avito-pr-service/internal/service/idempotency"
+	"github.com/4udiwe/avito-pr-service/internal/service/idempotency/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	key   = "key-1"
+	scope = "POST /pullRequest/create"
+	hash  = "hash"
+)
+
+var token = uuid.MustParse("7f1c2e4a-9d3b-4c5e-8a6f-0b1d2c3e4f50")
+
+func TestBegin(t *testing.T) {
+	ctx := context.Background()
+
+	completed := entity.IdempotencyRecord{
+		Key:         key,
+		Scope:       scope,
+		RequestHash: hash,
+		StatusCode:  201,
+		ContentType: "application/json",
+		Body:        []byte(`{"ok":true}`),
+	}
+
+	tests := []struct {
+		name           string
+		key            string
+		setup          func(r *mocks.MockIdempotencyRepo)
+		expectedRecord entity.IdempotencyRecord
+		expectedReplay bool
+		expectedErr    error
+	}{
+		{
+			name:        "empty key",
+			key:         "",
+			setup:       func(r *mocks.MockIdempotencyRepo) {},
+			expectedErr: service.ErrInvalidKey,
+		},
+		{
+			name:        "too long key",
+			key:         strings.Repeat("k", 256),
+			setup:       func(r *mocks.MockIdempotencyRepo) {},
+			expectedErr: service.ErrInvalidKey,
+		},
+		{
+			name: "first request",
+			key:  key,
+			setup: func(r *mocks.MockIdempotencyRepo) {
+				r.EXPECT().Reserve(gomock.Any(), key, scope, hash, time.Hour, time.Minute).Return(token, true, nil)
+			},
+			expectedRecord: entity.IdempotencyRecord{Key: key, Scope: scope, RequestHash: hash, Token: token},
+		},
+		{
+			name: "replay",
+			key:  key,
+			setup: func(r *mocks.MockIdempotencyRepo) {
+				r.EXPECT().Reserve(gomock.Any(), key, scope, hash, time.Hour, time.Minute).Return(uuid.Nil, false, nil)
+				r.EXPECT().Get(gomock.Any(), key, scope).Return(completed, nil)
+			},
+			expectedRecord: completed,
+			expectedReplay: true,
+		},
+		{
+			name: "key reused with different request",
+			key:  key,
+			setup: func(r *mocks.MockIdempotencyRepo) {
+				r.EXPECT().Reserve(gomock.Any(), key, scope, hash, time.Hour, time.Minute).Return(uuid.Nil, false, nil)
+				r.EXPECT().Get(gomock.Any(), key, scope).Return(entity.IdempotencyRecord{RequestHash: "other", StatusCode: 201}, nil)
+			},
+			expectedErr: service.ErrKeyReused,
+		},
+		{
+			name: "first request in progress",
+			key:  key,
+			setup: func(r *mocks.MockIdempotencyRepo) {
+				r.EXPECT().Reserve(gomock.Any(), key, scope, hash, time.Hour, time.Minute).Return(uuid.Nil, false, nil)
+				r.EXPECT().Get(gomock.Any(), key, scope).Return(entity.IdempotencyRecord{RequestHash: hash}, nil)
+			},
+			expectedErr: service.ErrRequestInProgress,
+		},
+		{
+			name: "key released concurrently",
+			key:  key,
+			setup: func(r *mocks.MockIdempotencyRepo) {
+				r.EXPECT().Reserve(gomock.Any(), key, scope, hash, time.Hour, time.Minute).Return(uuid.Nil, false, nil)
+				r.EXPECT().Get(gomock.Any(), key, scope).Return(entity.IdempotencyRecord{}, repository.ErrIdempotencyKeyNotFound)
+			},
+			expectedErr: service.ErrRequestInProgress,
+		},
+		{
+			name: "reserve error",
+			key:  key,
+			setup: func(r *mocks.MockIdempotencyRepo) {
+				r.EXPECT().Reserve(gomock.Any(), key, scope, hash, time.Hour, time.Minute).Return(uuid.Nil, false, errors.New("db"))
+			},
+			expectedErr: service.ErrCannotCheckKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockIdempotencyRepo(ctrl)
+			tt.setup(repo)
+
+			svc := service.New(repo, time.Hour, time.Minute)
+			record, replay, err := svc.Begin(ctx, tt.key, scope, hash)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedReplay, replay)
+			assert.Equal(t, tt.expectedRecord, record)
+		})
+	}
+}
+
+func TestComplete(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		statusCode  int
+		setup       func(r *mocks.MockIdempotencyRepo)
+		expectedErr error
+	}{
+		{
+			name:       "response stored",
+			statusCode: 201,
+			setup: func(r *mocks.MockIdempotencyRepo) {
+				r.EXPECT().Complete(gomock.Any(), key, scope, token, 201, "application/json", []byte("{}")).Return(nil)
+			},
+		},
+		{
+			name:       "client error stored",
+			statusCode: 404,
+			setup: func(r *mocks.MockIdempotencyRepo) {
+				r.EXPECT().Complete(gomock.Any(), key, scope, token, 404, "application/json", []byte("{}")).Return(nil)
+			},
+		},
+		{
+			name:       "server error releases key",
+			statusCode: 500,
+			setup: func(r *mocks.MockIdempotencyRepo) {
+				r.EXPECT().Delete(gomock.Any(), key, scope, token).Return(nil)
+			},
+		},
+		{
+			name:       "repo error",
+			statusCode: 200,
+			setup: func(r *mocks.MockIdempotencyRepo) {
+				r.EXPECT().Complete(gomock.Any(), key, scope, token, 200, "application/json", []byte("{}")).Return(errors.New("db"))
+			},
+			expectedErr: service.ErrCannotSaveResponse,
+		},
+		{
+			name:       "reservation taken over",
+			statusCode: 201,
+			setup: func(r *mocks.MockIdempotencyRepo) {
+				r.EXPECT().Complete(gomock.Any(), key, scope, token, 201, "application/json", []byte("{}")).Return(repository.ErrIdempotencyKeyNotFound)
+			},
+			expectedErr: service.ErrCannotSaveResponse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockIdempotencyRepo(ctrl)
+			tt.setup(repo)
+
+			svc := service.New(repo, time.Hour, time.Minute)
+			err := svc.Complete(ctx, key, scope, token, tt.statusCode, "application/json", []byte("{}"))
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package pr
+
+import (
+	"context"
+	"slices"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/samber/lo"
+)
+
+// Reviewers picked for a PR by the assignment rules
+type pickedReviewers struct {
+	ownerIDs    []string // Subset of reviewerIDs owning the changed files
+	reviewerIDs []string
+	fallbackIDs []string // Picked from buddy teams
+	needMore    bool
+}
+
+func (p pickedReviewers) all() []string {
+	return append(slices.Clone(p.reviewerIDs), p.fallbackIDs...)
+}
+
+// Picks reviewers for author's PR: owners of the changed files go first, then author's teammates
+// (preferring ones whose tags match labels), then members of buddy teams
+func (s *Service) pickReviewers(ctx context.Context, author entity.User, changedFiles, labels []string) (pickedReviewers, error) {
+	// Get owners of the changed files (limit = required reviewers of the team). Exclude authorID
+	requiredReviewers := author.Team.ReviewersRequired()
+	candidates, err := s.selectCodeOwners(ctx, changedFiles, requiredReviewers, author.ID)
+	if err != nil {
+		return pickedReviewers{}, err
+	}
+	ownerIDs := lo.Map(candidates, func(e entity.User, _ int) string { return e.ID })
+
+	// Fill the rest with author`s teammates. Exclude authorID and picked owners
+	if len(candidates) < requiredReviewers {
+		excludeIDs := append([]string{author.ID}, ownerIDs...)
+		teammates, err := s.selectReviewers(ctx, author.Team, requiredReviewers-len(candidates), labels, excludeIDs...)
+		if err != nil {
+			return pickedReviewers{}, err
+		}
+		candidates = append(candidates, teammates...)
+	}
+
+	reviewerIDs := lo.Map(candidates, func(e entity.User, _ int) string { return e.ID })
+
+	// Fill the rest from buddy teams, if they are configured for author`s team
+	excludeIDs := append([]string{author.ID}, reviewerIDs...)
+	fallbackIDs, err := s.selectFallbackReviewers(ctx, author.Team, requiredReviewers-len(reviewerIDs), excludeIDs...)
+	if err != nil {
+		return pickedReviewers{}, err
+	}
+
+	return pickedReviewers{
+		ownerIDs:    ownerIDs,
+		reviewerIDs: reviewerIDs,
+		fallbackIDs: fallbackIDs,
+		// If amount of reviewers < required, PR needs more reviewers
+		needMore: len(reviewerIDs)+len(fallbackIDs) < requiredReviewers,
+	}, nil
+}
+
+// Assigns picked reviewers to PR and records assignments in the audit trail.
+// reason is used for teammates, owners and buddy team members get their own reasons
+func (s *Service) assignPicked(ctx context.Context, prID string, picked pickedReviewers, reason string) error {
+	if len(picked.reviewerIDs) > 0 {
+		if err := s.PRRepo.AssignReviewers(ctx, prID, picked.reviewerIDs); err != nil {
+			return err
+		}
+	}
+
+	if len(picked.fallbackIDs) > 0 {
+		if err := s.PRRepo.AssignFallbackReviewers(ctx, prID, picked.fallbackIDs); err != nil {
+			return err
+		}
+	}
+
+	events := lo.Map(picked.all(), func(reviewerID string, _ int) entity.AssignmentEvent {
+		eventReason := reason
+		switch {
+		case slices.Contains(picked.ownerIDs, reviewerID):
+			eventReason = "code owner of changed files"
+		case slices.Contains(picked.fallbackIDs, reviewerID):
+			eventReason = "buddy team fallback"
+		}
+		return newAssignmentEvent(ctx, prID, entity.AssignmentEventAssign, reviewerID, "", eventReason)
+	})
+	if len(events) == 0 {
+		return nil
+	}
+	return s.events.RecordAssignments(ctx, events)
+}
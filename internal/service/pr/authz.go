@@ -0,0 +1,50 @@
+package pr
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/pkg/auth"
+	"github.com/sirupsen/logrus"
+)
+
+// Merge is allowed to the PR author and admins, force merge only to admins.
+// Without authenticated caller (auth disabled, background jobs) everything is allowed
+func authorizeMerge(ctx context.Context, pr entity.PullRequest, force bool) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok || principal.HasRole(auth.RoleAdmin) {
+		return nil
+	}
+
+	if force {
+		logrus.Warnf("PRService.MergePR: %s (%s) is not allowed to force merge PR %s", principal.Subject, principal.Role, pr.ID)
+		return ErrForceMergeForbidden
+	}
+	if principal.Subject != pr.AuthorID {
+		logrus.Warnf("PRService.MergePR: %s is not the author of PR %s", principal.Subject, pr.ID)
+		return ErrMergeForbidden
+	}
+	return nil
+}
+
+// Reassign is allowed to the reviewer being replaced, admins and leads of the reviewer's team.
+// Lead without a team in the token can reassign nobody
+func (s *Service) authorizeReassign(ctx context.Context, prID, oldReviewerID string) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok || principal.HasRole(auth.RoleAdmin) || principal.Subject == oldReviewerID {
+		return nil
+	}
+
+	if principal.HasRole(auth.RoleTeamLead) && principal.Team != "" {
+		oldReviewer, err := s.UserRepo.GetByID(ctx, oldReviewerID)
+		if err != nil {
+			return err
+		}
+		if oldReviewer.Team.Name == principal.Team {
+			return nil
+		}
+	}
+
+	logrus.Warnf("PRService.ReassignReviewer: %s (%s) is not allowed to reassign %s on PR %s", principal.Subject, principal.Role, oldReviewerID, prID)
+	return ErrReassignForbidden
+}
@@ -0,0 +1,82 @@
+package pr
+
+import (
+	"context"
+	"slices"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// Picks up to limit reviewers who own the changed files. For each file the last matching rule wins,
+// owners go in order of files. Team rules are resolved to one member using the team's strategy
+func (s *Service) selectCodeOwners(ctx context.Context, changedFiles []string, limit int, excludeIDs ...string) ([]entity.User, error) {
+	if len(changedFiles) == 0 || limit <= 0 {
+		return nil, nil
+	}
+
+	rules, err := s.CodeOwnerRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]entity.CodeOwner, 0, len(changedFiles))
+	seen := make(map[uuid.UUID]struct{})
+	for _, file := range changedFiles {
+		rule, _, ok := lo.FindLastIndexOf(rules, func(r entity.CodeOwner) bool { return r.Matches(file) })
+		if !ok {
+			continue
+		}
+		if _, ok := seen[rule.ID]; ok {
+			continue
+		}
+		seen[rule.ID] = struct{}{}
+		matched = append(matched, rule)
+	}
+	if len(matched) == 0 {
+		return nil, nil
+	}
+
+	// Owners who are inactive or away are skipped
+	available := make(map[string]entity.User)
+	userIDs := lo.FilterMap(matched, func(r entity.CodeOwner, _ int) (string, bool) { return r.UserID, r.UserID != "" })
+	if len(userIDs) > 0 {
+		users, err := s.UserRepo.GetAvailableByIDs(ctx, userIDs)
+		if err != nil {
+			return nil, err
+		}
+		available = lo.KeyBy(users, func(u entity.User) string { return u.ID })
+	}
+
+	exclude := slices.Clone(excludeIDs)
+	owners := make([]entity.User, 0, limit)
+
+	for _, rule := range matched {
+		if len(owners) == limit {
+			break
+		}
+
+		if rule.UserID != "" {
+			user, ok := available[rule.UserID]
+			if !ok || lo.Contains(exclude, user.ID) {
+				continue
+			}
+			owners = append(owners, user)
+			exclude = append(exclude, user.ID)
+			continue
+		}
+
+		members, err := s.selectTeammates(ctx, entity.Team{ID: rule.TeamID, Name: rule.TeamName}, 1, exclude...)
+		if err != nil {
+			return nil, err
+		}
+		if len(members) == 0 {
+			continue
+		}
+		owners = append(owners, members[0])
+		exclude = append(exclude, members[0].ID)
+	}
+
+	return owners, nil
+}
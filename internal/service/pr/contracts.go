@@ -14,17 +14,63 @@ type PRRepo interface {
 	Create(ctx context.Context, ID, title, authorID, statusName string, needMoreReviewers bool) (entity.PullRequest, error)
 	GetAll(ctx context.Context, limit int, offset int) (PRs []entity.PullRequest, total int, err error)
 	AssignReviewers(ctx context.Context, prID string, reviewerIDs []string) error
-	ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error
+	ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string, isFallback bool) error
 	GetByID(ctx context.Context, ID string) (entity.PullRequest, error)
+	LockByID(ctx context.Context, ID string) error
 	UpdateStatus(ctx context.Context, ID string, statusID int, mergedAt time.Time) error
 	GetReviewersByPR(ctx context.Context, prID string) ([]entity.PRReviewer, error)
 	GetPRStatuses(ctx context.Context) ([]entity.Status, error)
 	GetStatusByStatusID(ctx context.Context, statusID int) (entity.Status, error)
 	AssignReviewer(ctx context.Context, prID string, reviewerID string) error
 	UpdateNeedMoreReviewers(ctx context.Context, ID string) error
+	AddLabels(ctx context.Context, prID string, labels []string) error
+	AssignFallbackReviewers(ctx context.Context, prID string, reviewerIDs []string) error
+	AddMergeOverride(ctx context.Context, prID, actor string, unmetConditions []string) error
+	SetStatus(ctx context.Context, ID string, statusID int, closedAt *time.Time) error
+	SetNeedMoreReviewers(ctx context.Context, ID string, needMoreReviewers bool) error
+	RemoveReviewers(ctx context.Context, prID string) ([]string, error)
+	RemoveReviewer(ctx context.Context, prID, reviewerID string) error
+	UpdateTitle(ctx context.Context, ID, title string) error
+	UpdateDescription(ctx context.Context, ID, description string) error
+	UpdateAuthor(ctx context.Context, ID, authorID string) error
+	ListPendingReviewsPastSLA(ctx context.Context) ([]entity.OverdueReview, error)
+	ListStaleReviews(ctx context.Context, assignedBefore time.Time, limit int) ([]entity.PRReviewer, error)
+	DeferStaleCheck(ctx context.Context, prID, reviewerID string, until time.Time) error
 }
 
 type UserRepo interface {
 	GetByID(ctx context.Context, ID string) (entity.User, error)
 	GetRandomActiveTeammates(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error)
+	GetLeastLoadedActiveTeammates(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error)
+	GetRoundRobinActiveTeammates(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error)
+	GetWeightedActiveTeammates(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error)
+	GetAvailableByIDs(ctx context.Context, IDs []string) ([]entity.User, error)
+	GetRandomActiveUsersByTeams(ctx context.Context, teamNames []string, limit int, excludeIDs ...string) ([]entity.User, error)
+	GetRandomActiveUsers(ctx context.Context, limit int, excludeIDs ...string) ([]entity.User, error)
+	GetTagMatchedActiveTeammates(ctx context.Context, teamID uuid.UUID, tags []string, limit int, excludeIDs ...string) ([]entity.User, error)
+}
+
+type CodeOwnerRepo interface {
+	GetAll(ctx context.Context) ([]entity.CodeOwner, error)
+}
+
+type HistoryRepo interface {
+	Create(ctx context.Context, events []entity.AssignmentEvent) error
+	ListByPR(ctx context.Context, prID string) ([]entity.AssignmentEvent, error)
+}
+
+// Outbox of webhook events, enqueued in the transaction of the change
+// Outbox of domain events
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...entity.DomainEvent) error
+}
+
+type ReviewRepo interface {
+	Create(ctx context.Context, prID, reviewerID string, state entity.ReviewState, comment string) (entity.Review, error)
+	GetLatestByPRs(ctx context.Context, prIDs []string) ([]entity.Review, error)
+}
+
+// ReviewerSelector picks up to limit active members of the team to review a PR
+type ReviewerSelector interface {
+	Select(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error)
 }
@@ -1,6 +1,12 @@
 package pr
 
-import "errors"
+import (
+	"errors"
+	"strings"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/samber/lo"
+)
 
 var (
 	ErrPRAlreadyExists = errors.New("PR already exists")
@@ -14,10 +20,49 @@ var (
 	ErrStatusNotFound    = errors.New("status not found")
 	ErrCannotFetchStatus = errors.New("cannot fetch status")
 
-	ErrReviewerNotFound                  = errors.New("reviewer not found")
-	ErrCannotAssignReviewer              = errors.New("cannot assign reviewer")
-	ErrCannotReassignReviewerForMergedPR = errors.New("cannot reassign reviewer for merged PR")
-	ErrReviewerAlreadyAssigned           = errors.New("reviewer already assigned to PR")
-	ErrNoMoreReviewersToReassign         = errors.New("no more reviewers to reassign")
-	ErrPRAlreadyHas2Reviewers            = errors.New("PR already has 2 reviewers")
+	ErrReviewerNotFound          = errors.New("reviewer not found")
+	ErrCannotAssignReviewer      = errors.New("cannot assign reviewer")
+	ErrReviewerAlreadyAssigned   = errors.New("reviewer already assigned to PR")
+	ErrNoMoreReviewersToReassign = errors.New("no more reviewers to reassign")
+	ErrCandidateIsAuthor         = errors.New("new reviewer is the PR author")
+	ErrCandidateInactive         = errors.New("new reviewer is not active")
+	ErrPRHasEnoughReviewers      = errors.New("PR already has enough reviewers")
+	ErrCannotUnassignReviewer    = errors.New("cannot unassign reviewer")
+
+	ErrCannotFetchHistory = errors.New("cannot fetch assignment history")
+	ErrCannotFetchOverdue = errors.New("cannot fetch overdue reviews")
+	ErrCannotFetchStale   = errors.New("cannot fetch stale reviews")
+
+	ErrInvalidReviewState  = errors.New("invalid review state")
+	ErrReviewerNotAssigned = errors.New("reviewer is not assigned to PR")
+	ErrCannotSubmitReview  = errors.New("cannot submit review")
+
+	ErrMergeBlocked = errors.New("merge blocked by policy")
+
+	ErrNothingToUpdate = errors.New("nothing to update")
+	ErrInvalidTitle    = errors.New("title must not be empty")
+	ErrCannotUpdatePR  = errors.New("cannot update PR")
+
+	ErrForceMergeForbidden = errors.New("force merge is allowed to admins only")
+	ErrMergeForbidden      = errors.New("only the PR author or an admin can merge")
+	ErrReassignForbidden   = errors.New("only the affected reviewer or a team lead can reassign")
+
+	// Returned by the PR state machine
+	ErrPRMerged       = entity.ErrPRMerged
+	ErrInvalidPRState = entity.ErrInvalidPRState
+
+	ErrCannotChangeStatus = errors.New("cannot change PR status")
 )
+
+// Returned by MergePR when the PR does not satisfy the merge policy. Matches ErrMergeBlocked
+type MergeBlockedError struct {
+	Unmet []entity.MergeCondition
+}
+
+func (e *MergeBlockedError) Error() string {
+	return ErrMergeBlocked.Error() + ": " + strings.Join(lo.Map(e.Unmet, func(c entity.MergeCondition, _ int) string { return string(c) }), ", ")
+}
+
+func (e *MergeBlockedError) Unwrap() error {
+	return ErrMergeBlocked
+}
@@ -0,0 +1,56 @@
+package pr
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/4udiwe/avito-pr-service/pkg/actor"
+	"github.com/sirupsen/logrus"
+)
+
+// Returns reviewer assignment events of the PR in chronological order
+func (s *Service) GetHistory(ctx context.Context, prID string) ([]entity.AssignmentEvent, error) {
+	logrus.Infof("PRService.GetHistory: fetching assignment history for PR %s", prID)
+
+	var events []entity.AssignmentEvent
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Check if PR exists
+		if _, err := s.PRRepo.GetByID(ctx, prID); err != nil {
+			return err
+		}
+
+		var err error
+		events, err = s.HistoryRepo.ListByPR(ctx, prID)
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, repository.ErrPRNotFound) {
+			return nil, ErrPRNotFound
+		}
+		logrus.Errorf("PRService.GetHistory: failed to fetch history for PR %s: %v", prID, err)
+		return nil, ErrCannotFetchHistory
+	}
+
+	logrus.Infof("PRService.GetHistory: fetched %d events for PR %s", len(events), prID)
+	return events, nil
+}
+
+func newAssignmentEvent(
+	ctx context.Context,
+	prID string,
+	eventType entity.AssignmentEventType,
+	reviewerID, previousReviewerID, reason string,
+) entity.AssignmentEvent {
+	return entity.AssignmentEvent{
+		PRID:               prID,
+		Type:               eventType,
+		ReviewerID:         reviewerID,
+		PreviousReviewerID: previousReviewerID,
+		Actor:              actor.FromContext(ctx),
+		Reason:             reason,
+	}
+}
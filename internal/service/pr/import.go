@@ -0,0 +1,113 @@
+package pr
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultImportBatchSize = 50
+
+	// Extra candidates fetched to skip the ones already loaded by the import
+	maxImportLoadCandidates = 10
+)
+
+// ImportPRs creates PRs the same way as CreatePR, in transactions of importBatchSize PRs. Every PR runs
+// in its own savepoint, so a failed one doesn't roll back the rest of the batch. Reviewers are spread
+// across the import: teammates already picked for imported PRs go after the others. Reviewers of PRs
+// that were rolled back are not counted
+func (s *Service) ImportPRs(ctx context.Context, items []entity.PRImport) []entity.PRImportResult {
+	logrus.Infof("PRService.ImportPRs: importing %d PRs", len(items))
+
+	results := make([]entity.PRImportResult, len(items))
+	load := importLoad{}
+
+	for start := 0; start < len(items); start += s.importBatchSize {
+		end := min(start+s.importBatchSize, len(items))
+
+		// Applied to the import load only when the batch is committed
+		batchLoad := maps.Clone(load)
+
+		err := s.txManager.WithinTransaction(withImportLoad(ctx, batchLoad), func(ctx context.Context) error {
+			for i := start; i < end; i++ {
+				item := items[i]
+				var pr entity.PullRequest
+				err := s.txManager.WithinSavepoint(ctx, func(ctx context.Context) error {
+					var err error
+					pr, err = s.CreatePR(ctx, item.ID, item.Title, item.AuthorID, item.ChangedFiles, item.Labels, item.Draft)
+					return err
+				})
+				if err == nil {
+					batchLoad.add(pr.Reviewers)
+				}
+				results[i] = entity.PRImportResult{Index: i, PR: pr, Err: err}
+			}
+			return nil
+		})
+
+		if err != nil {
+			logrus.Errorf("PRService.ImportPRs: failed to commit PRs %d-%d: %v", start, end-1, err)
+			for i := start; i < end; i++ {
+				results[i] = entity.PRImportResult{Index: i, Err: ErrCannotCreatePR}
+			}
+			continue
+		}
+		load = batchLoad
+	}
+
+	created := 0
+	for _, result := range results {
+		if result.Err == nil {
+			created++
+		}
+	}
+
+	logrus.Infof("PRService.ImportPRs: created %d of %d PRs", created, len(items))
+	return results
+}
+
+type importLoadKey struct{}
+
+// Reviews assigned to users by the current import (user ID -> count)
+type importLoad map[string]int
+
+func withImportLoad(ctx context.Context, load importLoad) context.Context {
+	return context.WithValue(ctx, importLoadKey{}, load)
+}
+
+func importLoadFrom(ctx context.Context) (importLoad, bool) {
+	load, ok := ctx.Value(importLoadKey{}).(importLoad)
+	return load, ok
+}
+
+// Counts reviewers of a PR created by the import
+func (l importLoad) add(reviewerIDs []string) {
+	for _, ID := range reviewerIDs {
+		l[ID]++
+	}
+}
+
+// Picks limit candidates preferring ones with fewer reviews from the current import.
+// Order of selectFn breaks ties, so the strategy of the team still applies. Outside of the import
+// selectFn is used as is
+func spreadImportLoad(ctx context.Context, limit int, selectFn func(limit int) ([]entity.User, error)) ([]entity.User, error) {
+	load, ok := importLoadFrom(ctx)
+	if !ok || len(load) == 0 || limit <= 0 {
+		return selectFn(limit)
+	}
+
+	// At most len(load) candidates are loaded, so extra ones leave room for the unloaded.
+	// Capped, so a large import doesn't load the whole team for every PR
+	candidates, err := selectFn(limit + min(len(load), maxImportLoadCandidates))
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(candidates, func(a, b entity.User) int { return cmp.Compare(load[a.ID], load[b.ID]) })
+	return candidates[:min(limit, len(candidates))], nil
+}
@@ -0,0 +1,215 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/4udiwe/avito-pr-service/pkg/actor"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// Moves draft PR to OPEN and assigns reviewers by the usual rules
+func (s *Service) MarkReady(ctx context.Context, prID string, changedFiles []string) (entity.PullRequest, error) {
+	logrus.Infof("PRService.MarkReady: marking PR %s ready for review", prID)
+
+	pullRequest, err := s.openWithReviewers(ctx, prID, entity.ActionReady, changedFiles, "assigned when PR marked ready")
+	if err != nil {
+		logrus.Errorf("PRService.MarkReady: failed to mark PR %s ready: %v", prID, err)
+		return entity.PullRequest{}, mapTransitionError(err)
+	}
+
+	logrus.Infof("PRService.MarkReady: PR %s is ready for review", prID)
+	return pullRequest, nil
+}
+
+// Moves closed PR back to OPEN. Reviewers were released on close, so they are assigned again by the usual rules
+func (s *Service) ReopenPR(ctx context.Context, prID string, changedFiles []string) (entity.PullRequest, error) {
+	logrus.Infof("PRService.ReopenPR: reopening PR %s", prID)
+
+	pullRequest, err := s.openWithReviewers(ctx, prID, entity.ActionReopen, changedFiles, "assigned on PR reopen")
+	if err != nil {
+		logrus.Errorf("PRService.ReopenPR: failed to reopen PR %s: %v", prID, err)
+		return entity.PullRequest{}, mapTransitionError(err)
+	}
+
+	logrus.Infof("PRService.ReopenPR: PR %s reopened", prID)
+	return pullRequest, nil
+}
+
+// Closes draft or open PR without merge and releases its reviewers
+func (s *Service) ClosePR(ctx context.Context, prID string) (entity.PullRequest, error) {
+	logrus.Infof("PRService.ClosePR: closing PR %s", prID)
+
+	var pullRequest entity.PullRequest
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Status of the PR can't change concurrently, e.g. by merge
+		if err := s.PRRepo.LockByID(ctx, prID); err != nil {
+			return err
+		}
+
+		pr, err := s.PRRepo.GetByID(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		statusID, err := s.targetStatusID(ctx, pr, entity.ActionClose)
+		if err != nil {
+			return err
+		}
+
+		if err := s.PRRepo.SetStatus(ctx, prID, statusID, lo.ToPtr(time.Now())); err != nil {
+			return err
+		}
+
+		// Release reviewers
+		reviewerIDs, err := s.PRRepo.RemoveReviewers(ctx, prID)
+		if err != nil {
+			return err
+		}
+		if err := s.PRRepo.SetNeedMoreReviewers(ctx, prID, false); err != nil {
+			return err
+		}
+
+		events := lo.Map(reviewerIDs, func(reviewerID string, _ int) entity.AssignmentEvent {
+			return newAssignmentEvent(ctx, prID, entity.AssignmentEventUnassign, "", reviewerID, "PR closed")
+		})
+		if len(events) > 0 {
+			if err := s.events.RecordAssignments(ctx, events); err != nil {
+				return err
+			}
+		}
+
+		pullRequest, err = s.PRRepo.GetByID(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, statusChanged(ctx, pr, pullRequest, entity.ActionClose))
+	})
+
+	if err != nil {
+		logrus.Errorf("PRService.ClosePR: failed to close PR %s: %v", prID, err)
+		return entity.PullRequest{}, mapTransitionError(err)
+	}
+
+	logrus.Infof("PRService.ClosePR: PR %s closed", prID)
+	return pullRequest, nil
+}
+
+// Applies action that moves PR to OPEN status and assigns reviewers to the PR
+func (s *Service) openWithReviewers(
+	ctx context.Context,
+	prID string,
+	action entity.PRAction,
+	changedFiles []string,
+	reason string,
+) (entity.PullRequest, error) {
+	var pullRequest entity.PullRequest
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Status of the PR can't change concurrently, e.g. by merge
+		if err := s.PRRepo.LockByID(ctx, prID); err != nil {
+			return err
+		}
+
+		pr, err := s.PRRepo.GetByID(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		statusID, err := s.targetStatusID(ctx, pr, action)
+		if err != nil {
+			return err
+		}
+
+		author, err := s.UserRepo.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+
+		picked, err := s.pickReviewers(ctx, author, changedFiles, pr.Labels)
+		if err != nil {
+			return err
+		}
+
+		if err := s.PRRepo.SetStatus(ctx, prID, statusID, nil); err != nil {
+			return err
+		}
+		if err := s.PRRepo.SetNeedMoreReviewers(ctx, prID, picked.needMore); err != nil {
+			return err
+		}
+		if err := s.assignPicked(ctx, prID, picked, reason); err != nil {
+			return err
+		}
+
+		pullRequest, err = s.PRRepo.GetByID(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, statusChanged(ctx, pr, pullRequest, action))
+	})
+
+	return pullRequest, err
+}
+
+// Validates action with the PR state machine. Every mutation of PR goes through it
+func checkAction(pr entity.PullRequest, action entity.PRAction) (entity.PRStatusName, error) {
+	target, err := action.Apply(pr.Status.Name)
+	if err != nil {
+		logrus.Warnf("PRService: action %s is not allowed for PR %s in status %s", action, pr.ID, pr.Status.Name)
+		return "", err
+	}
+	return target, nil
+}
+
+// Validates action that changes PR status and returns ID of the target status
+func (s *Service) targetStatusID(ctx context.Context, pr entity.PullRequest, action entity.PRAction) (int, error) {
+	target, err := checkAction(pr, action)
+	if err != nil {
+		return 0, err
+	}
+
+	statuses, err := s.PRRepo.GetPRStatuses(ctx)
+	if err != nil {
+		return 0, err
+	}
+	status, ok := lo.Find(statuses, func(s entity.Status) bool { return s.Name == target })
+	if !ok {
+		return 0, ErrStatusNotFound
+	}
+
+	return status.ID, nil
+}
+
+func mapTransitionError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrPRNotFound):
+		return ErrPRNotFound
+	case errors.Is(err, ErrPRMerged):
+		return ErrPRMerged
+	case errors.Is(err, ErrInvalidPRState):
+		return ErrInvalidPRState
+	case errors.Is(err, ErrStatusNotFound):
+		return ErrStatusNotFound
+	case errors.Is(err, repository.ErrReviewerAlreadyAssigned):
+		return ErrReviewerAlreadyAssigned
+	default:
+		return ErrCannotChangeStatus
+	}
+}
+
+func statusChanged(ctx context.Context, before, after entity.PullRequest, action entity.PRAction) entity.PRStatusChanged {
+	return entity.PRStatusChanged{
+		PRID:   after.ID,
+		From:   before.Status.Name,
+		To:     after.Status.Name,
+		Action: action,
+		Actor:  actor.FromContext(ctx),
+	}
+}
@@ -0,0 +1,73 @@
+package pr
+
+import (
+	"context"
+	"slices"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/samber/lo"
+)
+
+// Returns merge policy conditions the PR does not satisfy.
+// Only latest decisions of current reviewers are taken into account
+func (s *Service) checkMergePolicy(ctx context.Context, pr entity.PullRequest) ([]entity.MergeCondition, error) {
+	if s.mergePolicy.IsEmpty() {
+		return nil, nil
+	}
+
+	reviews, err := s.ReviewRepo.GetLatestByPRs(ctx, []string{pr.ID})
+	if err != nil {
+		return nil, err
+	}
+	reviews = lo.Filter(reviews, func(r entity.Review, _ int) bool { return slices.Contains(pr.Reviewers, r.ReviewerID) })
+
+	approverIDs := lo.FilterMap(reviews, func(r entity.Review, _ int) (string, bool) {
+		return r.ReviewerID, r.State == entity.ReviewApproved
+	})
+
+	var unmet []entity.MergeCondition
+
+	if len(approverIDs) < s.mergePolicy.MinApprovals {
+		unmet = append(unmet, entity.MergeConditionMinApprovals)
+	}
+
+	if s.mergePolicy.BlockOnChangesRequested &&
+		lo.ContainsBy(reviews, func(r entity.Review) bool { return r.State == entity.ReviewChangesRequested }) {
+		unmet = append(unmet, entity.MergeConditionNoChangesRequested)
+	}
+
+	if s.mergePolicy.RequireTeamApproval {
+		approved, err := s.hasTeamApproval(ctx, pr.AuthorID, approverIDs)
+		if err != nil {
+			return nil, err
+		}
+		if !approved {
+			unmet = append(unmet, entity.MergeConditionTeamApproval)
+		}
+	}
+
+	return unmet, nil
+}
+
+func (s *Service) hasTeamApproval(ctx context.Context, authorID string, approverIDs []string) (bool, error) {
+	if len(approverIDs) == 0 {
+		return false, nil
+	}
+
+	author, err := s.UserRepo.GetByID(ctx, authorID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, approverID := range approverIDs {
+		approver, err := s.UserRepo.GetByID(ctx, approverID)
+		if err != nil {
+			return false, err
+		}
+		if approver.Team.ID == author.Team.ID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,177 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/4udiwe/avito-pr-service/pkg/actor"
+	"github.com/sirupsen/logrus"
+)
+
+// Changes title and description of the PR. Nil fields are left as is
+func (s *Service) UpdatePR(ctx context.Context, prID string, title, description *string) (entity.PullRequest, error) {
+	logrus.Infof("PRService.UpdatePR: updating PR %s", prID)
+
+	if title == nil && description == nil {
+		return entity.PullRequest{}, ErrNothingToUpdate
+	}
+	if title != nil {
+		trimmed := strings.TrimSpace(*title)
+		if trimmed == "" {
+			return entity.PullRequest{}, ErrInvalidTitle
+		}
+		title = &trimmed
+	}
+
+	var pullRequest entity.PullRequest
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		pr, err := s.PRRepo.GetByID(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := checkAction(pr, entity.ActionUpdateMetadata); err != nil {
+			return err
+		}
+
+		if title != nil {
+			if err := s.PRRepo.UpdateTitle(ctx, prID, *title); err != nil {
+				return err
+			}
+			pr.Title = *title
+		}
+		if description != nil {
+			if err := s.PRRepo.UpdateDescription(ctx, prID, *description); err != nil {
+				return err
+			}
+			pr.Description = *description
+		}
+
+		pullRequest = pr
+		return s.events.Publish(ctx, entity.PRUpdated{
+			PRID:        prID,
+			Title:       pr.Title,
+			Description: pr.Description,
+			Actor:       actor.FromContext(ctx),
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, repository.ErrPRNotFound) {
+			return entity.PullRequest{}, ErrPRNotFound
+		}
+		if errors.Is(err, ErrPRMerged) || errors.Is(err, ErrInvalidPRState) {
+			return entity.PullRequest{}, err
+		}
+		logrus.Errorf("PRService.UpdatePR: failed to update PR %s: %v", prID, err)
+		return entity.PullRequest{}, ErrCannotUpdatePR
+	}
+
+	logrus.Infof("PRService.UpdatePR: PR %s updated", prID)
+	return pullRequest, nil
+}
+
+// Transfers authorship of the PR. If the new author reviews this PR,
+// they are replaced with a teammate, or unassigned when nobody is available
+func (s *Service) TransferAuthor(ctx context.Context, prID, newAuthorID string) (entity.PullRequest, error) {
+	logrus.Infof("PRService.TransferAuthor: transferring PR %s to %s", prID, newAuthorID)
+
+	var pullRequest entity.PullRequest
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		pr, err := s.PRRepo.GetByID(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := checkAction(pr, entity.ActionTransferAuthor); err != nil {
+			return err
+		}
+
+		if pr.AuthorID == newAuthorID {
+			pullRequest = pr
+			return nil
+		}
+
+		newAuthor, err := s.UserRepo.GetByID(ctx, newAuthorID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.PRRepo.UpdateAuthor(ctx, prID, newAuthorID); err != nil {
+			return err
+		}
+
+		// Author can't review own PR
+		if slices.Contains(pr.Reviewers, newAuthorID) {
+			if err := s.replaceAuthorReviewer(ctx, pr, newAuthor); err != nil {
+				return err
+			}
+		}
+
+		pullRequest, err = s.PRRepo.GetByID(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, entity.PRAuthorChanged{
+			PRID:             prID,
+			AuthorID:         newAuthorID,
+			PreviousAuthorID: pr.AuthorID,
+			Actor:            actor.FromContext(ctx),
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, repository.ErrPRNotFound) {
+			return entity.PullRequest{}, ErrPRNotFound
+		}
+		if errors.Is(err, repository.ErrUserNotFound) || errors.Is(err, repository.ErrAuthorNotFound) {
+			return entity.PullRequest{}, ErrAuthorNotFound
+		}
+		if errors.Is(err, ErrPRMerged) || errors.Is(err, ErrInvalidPRState) {
+			return entity.PullRequest{}, err
+		}
+		logrus.Errorf("PRService.TransferAuthor: failed to transfer PR %s: %v", prID, err)
+		return entity.PullRequest{}, ErrCannotUpdatePR
+	}
+
+	logrus.Infof("PRService.TransferAuthor: PR %s transferred to %s", prID, newAuthorID)
+	return pullRequest, nil
+}
+
+// Replaces new author in the reviewers of the PR with a teammate preferring ones matching PR labels.
+// Without candidates the reviewer is just removed and PR is marked as needing more reviewers
+func (s *Service) replaceAuthorReviewer(ctx context.Context, pr entity.PullRequest, author entity.User) error {
+	const reason = "reviewer became PR author"
+
+	candidates, err := s.selectReviewers(ctx, author.Team, 1, pr.Labels, pr.Reviewers...)
+	if err != nil {
+		return err
+	}
+
+	if len(candidates) > 0 {
+		// Candidate is a teammate of the author
+		if err := s.PRRepo.ReassignReviewer(ctx, pr.ID, author.ID, candidates[0].ID, false); err != nil {
+			return err
+		}
+		return s.events.RecordAssignments(ctx, []entity.AssignmentEvent{
+			newAssignmentEvent(ctx, pr.ID, entity.AssignmentEventReassign, candidates[0].ID, author.ID, reason),
+		})
+	}
+
+	if err := s.PRRepo.RemoveReviewer(ctx, pr.ID, author.ID); err != nil {
+		return err
+	}
+	if err := s.PRRepo.SetNeedMoreReviewers(ctx, pr.ID, true); err != nil {
+		return err
+	}
+	return s.events.RecordAssignments(ctx, []entity.AssignmentEvent{
+		newAssignmentEvent(ctx, pr.ID, entity.AssignmentEventUnassign, "", author.ID, reason),
+	})
+}
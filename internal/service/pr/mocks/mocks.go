@@ -43,6 +43,48 @@ func (m *MockPRRepo) EXPECT() *MockPRRepoMockRecorder {
 	return m.recorder
 }
 
+// AddLabels mocks base method.
+func (m *MockPRRepo) AddLabels(ctx context.Context, prID string, labels []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLabels", ctx, prID, labels)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLabels indicates an expected call of AddLabels.
+func (mr *MockPRRepoMockRecorder) AddLabels(ctx, prID, labels any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLabels", reflect.TypeOf((*MockPRRepo)(nil).AddLabels), ctx, prID, labels)
+}
+
+// AddMergeOverride mocks base method.
+func (m *MockPRRepo) AddMergeOverride(ctx context.Context, prID, actor string, unmetConditions []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMergeOverride", ctx, prID, actor, unmetConditions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMergeOverride indicates an expected call of AddMergeOverride.
+func (mr *MockPRRepoMockRecorder) AddMergeOverride(ctx, prID, actor, unmetConditions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMergeOverride", reflect.TypeOf((*MockPRRepo)(nil).AddMergeOverride), ctx, prID, actor, unmetConditions)
+}
+
+// AssignFallbackReviewers mocks base method.
+func (m *MockPRRepo) AssignFallbackReviewers(ctx context.Context, prID string, reviewerIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignFallbackReviewers", ctx, prID, reviewerIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignFallbackReviewers indicates an expected call of AssignFallbackReviewers.
+func (mr *MockPRRepoMockRecorder) AssignFallbackReviewers(ctx, prID, reviewerIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignFallbackReviewers", reflect.TypeOf((*MockPRRepo)(nil).AssignFallbackReviewers), ctx, prID, reviewerIDs)
+}
+
 // AssignReviewer mocks base method.
 func (m *MockPRRepo) AssignReviewer(ctx context.Context, prID, reviewerID string) error {
 	m.ctrl.T.Helper()
@@ -86,6 +128,20 @@ func (mr *MockPRRepoMockRecorder) Create(ctx, ID, title, authorID, statusName, n
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPRRepo)(nil).Create), ctx, ID, title, authorID, statusName, needMoreReviewers)
 }
 
+// DeferStaleCheck mocks base method.
+func (m *MockPRRepo) DeferStaleCheck(ctx context.Context, prID, reviewerID string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeferStaleCheck", ctx, prID, reviewerID, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeferStaleCheck indicates an expected call of DeferStaleCheck.
+func (mr *MockPRRepoMockRecorder) DeferStaleCheck(ctx, prID, reviewerID, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeferStaleCheck", reflect.TypeOf((*MockPRRepo)(nil).DeferStaleCheck), ctx, prID, reviewerID, until)
+}
+
 // GetAll mocks base method.
 func (m *MockPRRepo) GetAll(ctx context.Context, limit, offset int) ([]entity.PullRequest, int, error) {
 	m.ctrl.T.Helper()
@@ -162,18 +218,147 @@ func (mr *MockPRRepoMockRecorder) GetStatusByStatusID(ctx, statusID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusByStatusID", reflect.TypeOf((*MockPRRepo)(nil).GetStatusByStatusID), ctx, statusID)
 }
 
+// ListPendingReviewsPastSLA mocks base method.
+func (m *MockPRRepo) ListPendingReviewsPastSLA(ctx context.Context) ([]entity.OverdueReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingReviewsPastSLA", ctx)
+	ret0, _ := ret[0].([]entity.OverdueReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingReviewsPastSLA indicates an expected call of ListPendingReviewsPastSLA.
+func (mr *MockPRRepoMockRecorder) ListPendingReviewsPastSLA(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingReviewsPastSLA", reflect.TypeOf((*MockPRRepo)(nil).ListPendingReviewsPastSLA), ctx)
+}
+
+// ListStaleReviews mocks base method.
+func (m *MockPRRepo) ListStaleReviews(ctx context.Context, assignedBefore time.Time, limit int) ([]entity.PRReviewer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStaleReviews", ctx, assignedBefore, limit)
+	ret0, _ := ret[0].([]entity.PRReviewer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStaleReviews indicates an expected call of ListStaleReviews.
+func (mr *MockPRRepoMockRecorder) ListStaleReviews(ctx, assignedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStaleReviews", reflect.TypeOf((*MockPRRepo)(nil).ListStaleReviews), ctx, assignedBefore, limit)
+}
+
+// LockByID mocks base method.
+func (m *MockPRRepo) LockByID(ctx context.Context, ID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockByID", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockByID indicates an expected call of LockByID.
+func (mr *MockPRRepoMockRecorder) LockByID(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockByID", reflect.TypeOf((*MockPRRepo)(nil).LockByID), ctx, ID)
+}
+
 // ReassignReviewer mocks base method.
-func (m *MockPRRepo) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error {
+func (m *MockPRRepo) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string, isFallback bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReassignReviewer", ctx, prID, oldReviewerID, newReviewerID)
+	ret := m.ctrl.Call(m, "ReassignReviewer", ctx, prID, oldReviewerID, newReviewerID, isFallback)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ReassignReviewer indicates an expected call of ReassignReviewer.
-func (mr *MockPRRepoMockRecorder) ReassignReviewer(ctx, prID, oldReviewerID, newReviewerID any) *gomock.Call {
+func (mr *MockPRRepoMockRecorder) ReassignReviewer(ctx, prID, oldReviewerID, newReviewerID, isFallback any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignReviewer", reflect.TypeOf((*MockPRRepo)(nil).ReassignReviewer), ctx, prID, oldReviewerID, newReviewerID, isFallback)
+}
+
+// RemoveReviewer mocks base method.
+func (m *MockPRRepo) RemoveReviewer(ctx context.Context, prID, reviewerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReviewer", ctx, prID, reviewerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReviewer indicates an expected call of RemoveReviewer.
+func (mr *MockPRRepoMockRecorder) RemoveReviewer(ctx, prID, reviewerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReviewer", reflect.TypeOf((*MockPRRepo)(nil).RemoveReviewer), ctx, prID, reviewerID)
+}
+
+// RemoveReviewers mocks base method.
+func (m *MockPRRepo) RemoveReviewers(ctx context.Context, prID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReviewers", ctx, prID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveReviewers indicates an expected call of RemoveReviewers.
+func (mr *MockPRRepoMockRecorder) RemoveReviewers(ctx, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReviewers", reflect.TypeOf((*MockPRRepo)(nil).RemoveReviewers), ctx, prID)
+}
+
+// SetNeedMoreReviewers mocks base method.
+func (m *MockPRRepo) SetNeedMoreReviewers(ctx context.Context, ID string, needMoreReviewers bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNeedMoreReviewers", ctx, ID, needMoreReviewers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNeedMoreReviewers indicates an expected call of SetNeedMoreReviewers.
+func (mr *MockPRRepoMockRecorder) SetNeedMoreReviewers(ctx, ID, needMoreReviewers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNeedMoreReviewers", reflect.TypeOf((*MockPRRepo)(nil).SetNeedMoreReviewers), ctx, ID, needMoreReviewers)
+}
+
+// SetStatus mocks base method.
+func (m *MockPRRepo) SetStatus(ctx context.Context, ID string, statusID int, closedAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, ID, statusID, closedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockPRRepoMockRecorder) SetStatus(ctx, ID, statusID, closedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockPRRepo)(nil).SetStatus), ctx, ID, statusID, closedAt)
+}
+
+// UpdateAuthor mocks base method.
+func (m *MockPRRepo) UpdateAuthor(ctx context.Context, ID, authorID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuthor", ctx, ID, authorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAuthor indicates an expected call of UpdateAuthor.
+func (mr *MockPRRepoMockRecorder) UpdateAuthor(ctx, ID, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthor", reflect.TypeOf((*MockPRRepo)(nil).UpdateAuthor), ctx, ID, authorID)
+}
+
+// UpdateDescription mocks base method.
+func (m *MockPRRepo) UpdateDescription(ctx context.Context, ID, description string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDescription", ctx, ID, description)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDescription indicates an expected call of UpdateDescription.
+func (mr *MockPRRepoMockRecorder) UpdateDescription(ctx, ID, description any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignReviewer", reflect.TypeOf((*MockPRRepo)(nil).ReassignReviewer), ctx, prID, oldReviewerID, newReviewerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDescription", reflect.TypeOf((*MockPRRepo)(nil).UpdateDescription), ctx, ID, description)
 }
 
 // UpdateNeedMoreReviewers mocks base method.
@@ -204,6 +389,20 @@ func (mr *MockPRRepoMockRecorder) UpdateStatus(ctx, ID, statusID, mergedAt any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockPRRepo)(nil).UpdateStatus), ctx, ID, statusID, mergedAt)
 }
 
+// UpdateTitle mocks base method.
+func (m *MockPRRepo) UpdateTitle(ctx context.Context, ID, title string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTitle", ctx, ID, title)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTitle indicates an expected call of UpdateTitle.
+func (mr *MockPRRepoMockRecorder) UpdateTitle(ctx, ID, title any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTitle", reflect.TypeOf((*MockPRRepo)(nil).UpdateTitle), ctx, ID, title)
+}
+
 // MockUserRepo is a mock of UserRepo interface.
 type MockUserRepo struct {
 	ctrl     *gomock.Controller
@@ -228,6 +427,21 @@ func (m *MockUserRepo) EXPECT() *MockUserRepoMockRecorder {
 	return m.recorder
 }
 
+// GetAvailableByIDs mocks base method.
+func (m *MockUserRepo) GetAvailableByIDs(ctx context.Context, IDs []string) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailableByIDs", ctx, IDs)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailableByIDs indicates an expected call of GetAvailableByIDs.
+func (mr *MockUserRepoMockRecorder) GetAvailableByIDs(ctx, IDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableByIDs", reflect.TypeOf((*MockUserRepo)(nil).GetAvailableByIDs), ctx, IDs)
+}
+
 // GetByID mocks base method.
 func (m *MockUserRepo) GetByID(ctx context.Context, ID string) (entity.User, error) {
 	m.ctrl.T.Helper()
@@ -243,6 +457,26 @@ func (mr *MockUserRepoMockRecorder) GetByID(ctx, ID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepo)(nil).GetByID), ctx, ID)
 }
 
+// GetLeastLoadedActiveTeammates mocks base method.
+func (m *MockUserRepo) GetLeastLoadedActiveTeammates(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, teamID, limit}
+	for _, a := range excludeIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLeastLoadedActiveTeammates", varargs...)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeastLoadedActiveTeammates indicates an expected call of GetLeastLoadedActiveTeammates.
+func (mr *MockUserRepoMockRecorder) GetLeastLoadedActiveTeammates(ctx, teamID, limit any, excludeIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, teamID, limit}, excludeIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeastLoadedActiveTeammates", reflect.TypeOf((*MockUserRepo)(nil).GetLeastLoadedActiveTeammates), varargs...)
+}
+
 // GetRandomActiveTeammates mocks base method.
 func (m *MockUserRepo) GetRandomActiveTeammates(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error) {
 	m.ctrl.T.Helper()
@@ -262,3 +496,336 @@ func (mr *MockUserRepoMockRecorder) GetRandomActiveTeammates(ctx, teamID, limit
 	varargs := append([]any{ctx, teamID, limit}, excludeIDs...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRandomActiveTeammates", reflect.TypeOf((*MockUserRepo)(nil).GetRandomActiveTeammates), varargs...)
 }
+
+// GetRandomActiveUsers mocks base method.
+func (m *MockUserRepo) GetRandomActiveUsers(ctx context.Context, limit int, excludeIDs ...string) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, limit}
+	for _, a := range excludeIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRandomActiveUsers", varargs...)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRandomActiveUsers indicates an expected call of GetRandomActiveUsers.
+func (mr *MockUserRepoMockRecorder) GetRandomActiveUsers(ctx, limit any, excludeIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, limit}, excludeIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRandomActiveUsers", reflect.TypeOf((*MockUserRepo)(nil).GetRandomActiveUsers), varargs...)
+}
+
+// GetRandomActiveUsersByTeams mocks base method.
+func (m *MockUserRepo) GetRandomActiveUsersByTeams(ctx context.Context, teamNames []string, limit int, excludeIDs ...string) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, teamNames, limit}
+	for _, a := range excludeIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRandomActiveUsersByTeams", varargs...)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRandomActiveUsersByTeams indicates an expected call of GetRandomActiveUsersByTeams.
+func (mr *MockUserRepoMockRecorder) GetRandomActiveUsersByTeams(ctx, teamNames, limit any, excludeIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, teamNames, limit}, excludeIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRandomActiveUsersByTeams", reflect.TypeOf((*MockUserRepo)(nil).GetRandomActiveUsersByTeams), varargs...)
+}
+
+// GetRoundRobinActiveTeammates mocks base method.
+func (m *MockUserRepo) GetRoundRobinActiveTeammates(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, teamID, limit}
+	for _, a := range excludeIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRoundRobinActiveTeammates", varargs...)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoundRobinActiveTeammates indicates an expected call of GetRoundRobinActiveTeammates.
+func (mr *MockUserRepoMockRecorder) GetRoundRobinActiveTeammates(ctx, teamID, limit any, excludeIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, teamID, limit}, excludeIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoundRobinActiveTeammates", reflect.TypeOf((*MockUserRepo)(nil).GetRoundRobinActiveTeammates), varargs...)
+}
+
+// GetTagMatchedActiveTeammates mocks base method.
+func (m *MockUserRepo) GetTagMatchedActiveTeammates(ctx context.Context, teamID uuid.UUID, tags []string, limit int, excludeIDs ...string) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, teamID, tags, limit}
+	for _, a := range excludeIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTagMatchedActiveTeammates", varargs...)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagMatchedActiveTeammates indicates an expected call of GetTagMatchedActiveTeammates.
+func (mr *MockUserRepoMockRecorder) GetTagMatchedActiveTeammates(ctx, teamID, tags, limit any, excludeIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, teamID, tags, limit}, excludeIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagMatchedActiveTeammates", reflect.TypeOf((*MockUserRepo)(nil).GetTagMatchedActiveTeammates), varargs...)
+}
+
+// GetWeightedActiveTeammates mocks base method.
+func (m *MockUserRepo) GetWeightedActiveTeammates(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, teamID, limit}
+	for _, a := range excludeIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetWeightedActiveTeammates", varargs...)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWeightedActiveTeammates indicates an expected call of GetWeightedActiveTeammates.
+func (mr *MockUserRepoMockRecorder) GetWeightedActiveTeammates(ctx, teamID, limit any, excludeIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, teamID, limit}, excludeIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWeightedActiveTeammates", reflect.TypeOf((*MockUserRepo)(nil).GetWeightedActiveTeammates), varargs...)
+}
+
+// MockCodeOwnerRepo is a mock of CodeOwnerRepo interface.
+type MockCodeOwnerRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCodeOwnerRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockCodeOwnerRepoMockRecorder is the mock recorder for MockCodeOwnerRepo.
+type MockCodeOwnerRepoMockRecorder struct {
+	mock *MockCodeOwnerRepo
+}
+
+// NewMockCodeOwnerRepo creates a new mock instance.
+func NewMockCodeOwnerRepo(ctrl *gomock.Controller) *MockCodeOwnerRepo {
+	mock := &MockCodeOwnerRepo{ctrl: ctrl}
+	mock.recorder = &MockCodeOwnerRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCodeOwnerRepo) EXPECT() *MockCodeOwnerRepoMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockCodeOwnerRepo) GetAll(ctx context.Context) ([]entity.CodeOwner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]entity.CodeOwner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCodeOwnerRepoMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCodeOwnerRepo)(nil).GetAll), ctx)
+}
+
+// MockHistoryRepo is a mock of HistoryRepo interface.
+type MockHistoryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockHistoryRepoMockRecorder is the mock recorder for MockHistoryRepo.
+type MockHistoryRepoMockRecorder struct {
+	mock *MockHistoryRepo
+}
+
+// NewMockHistoryRepo creates a new mock instance.
+func NewMockHistoryRepo(ctrl *gomock.Controller) *MockHistoryRepo {
+	mock := &MockHistoryRepo{ctrl: ctrl}
+	mock.recorder = &MockHistoryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryRepo) EXPECT() *MockHistoryRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockHistoryRepo) Create(ctx context.Context, events []entity.AssignmentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHistoryRepoMockRecorder) Create(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHistoryRepo)(nil).Create), ctx, events)
+}
+
+// ListByPR mocks base method.
+func (m *MockHistoryRepo) ListByPR(ctx context.Context, prID string) ([]entity.AssignmentEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByPR", ctx, prID)
+	ret0, _ := ret[0].([]entity.AssignmentEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByPR indicates an expected call of ListByPR.
+func (mr *MockHistoryRepoMockRecorder) ListByPR(ctx, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPR", reflect.TypeOf((*MockHistoryRepo)(nil).ListByPR), ctx, prID)
+}
+
+// MockEventPublisher is a mock of EventPublisher interface.
+type MockEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockEventPublisherMockRecorder is the mock recorder for MockEventPublisher.
+type MockEventPublisherMockRecorder struct {
+	mock *MockEventPublisher
+}
+
+// NewMockEventPublisher creates a new mock instance.
+func NewMockEventPublisher(ctrl *gomock.Controller) *MockEventPublisher {
+	mock := &MockEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPublisher) EXPECT() *MockEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventPublisher) Publish(ctx context.Context, events ...entity.DomainEvent) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Publish", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventPublisherMockRecorder) Publish(ctx any, events ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventPublisher)(nil).Publish), varargs...)
+}
+
+// MockReviewRepo is a mock of ReviewRepo interface.
+type MockReviewRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockReviewRepoMockRecorder is the mock recorder for MockReviewRepo.
+type MockReviewRepoMockRecorder struct {
+	mock *MockReviewRepo
+}
+
+// NewMockReviewRepo creates a new mock instance.
+func NewMockReviewRepo(ctrl *gomock.Controller) *MockReviewRepo {
+	mock := &MockReviewRepo{ctrl: ctrl}
+	mock.recorder = &MockReviewRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewRepo) EXPECT() *MockReviewRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReviewRepo) Create(ctx context.Context, prID, reviewerID string, state entity.ReviewState, comment string) (entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, prID, reviewerID, state, comment)
+	ret0, _ := ret[0].(entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReviewRepoMockRecorder) Create(ctx, prID, reviewerID, state, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReviewRepo)(nil).Create), ctx, prID, reviewerID, state, comment)
+}
+
+// GetLatestByPRs mocks base method.
+func (m *MockReviewRepo) GetLatestByPRs(ctx context.Context, prIDs []string) ([]entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestByPRs", ctx, prIDs)
+	ret0, _ := ret[0].([]entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestByPRs indicates an expected call of GetLatestByPRs.
+func (mr *MockReviewRepoMockRecorder) GetLatestByPRs(ctx, prIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestByPRs", reflect.TypeOf((*MockReviewRepo)(nil).GetLatestByPRs), ctx, prIDs)
+}
+
+// MockReviewerSelector is a mock of ReviewerSelector interface.
+type MockReviewerSelector struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewerSelectorMockRecorder
+	isgomock struct{}
+}
+
+// MockReviewerSelectorMockRecorder is the mock recorder for MockReviewerSelector.
+type MockReviewerSelectorMockRecorder struct {
+	mock *MockReviewerSelector
+}
+
+// NewMockReviewerSelector creates a new mock instance.
+func NewMockReviewerSelector(ctrl *gomock.Controller) *MockReviewerSelector {
+	mock := &MockReviewerSelector{ctrl: ctrl}
+	mock.recorder = &MockReviewerSelectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewerSelector) EXPECT() *MockReviewerSelectorMockRecorder {
+	return m.recorder
+}
+
+// Select mocks base method.
+func (m *MockReviewerSelector) Select(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, teamID, limit}
+	for _, a := range excludeIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Select", varargs...)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Select indicates an expected call of Select.
+func (mr *MockReviewerSelectorMockRecorder) Select(ctx, teamID, limit any, excludeIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, teamID, limit}, excludeIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Select", reflect.TypeOf((*MockReviewerSelector)(nil).Select), varargs...)
+}
@@ -0,0 +1,59 @@
+package pr
+
+import (
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+)
+
+type Option func(*Service)
+
+// WithStrategy sets the default reviewer strategy for all teams
+func WithStrategy(strategy entity.ReviewerStrategy) Option {
+	return func(s *Service) {
+		s.strategy = strategy
+	}
+}
+
+// WithTeamStrategies overrides the default reviewer strategy for the given teams (team name -> strategy)
+func WithTeamStrategies(teamStrategies map[string]entity.ReviewerStrategy) Option {
+	return func(s *Service) {
+		s.teamStrategies = teamStrategies
+	}
+}
+
+// WithSelector registers a custom selector or replaces a built-in one
+func WithSelector(strategy entity.ReviewerStrategy, selector ReviewerSelector) Option {
+	return func(s *Service) {
+		s.selectors[strategy] = selector
+	}
+}
+
+// WithBuddyTeams enables cross-team fallback: when a team has too few active reviewers,
+// missing ones are picked from its buddy teams (team name -> buddy team names)
+func WithBuddyTeams(buddyTeams map[string][]string) Option {
+	return func(s *Service) {
+		s.buddyTeams = buddyTeams
+	}
+}
+
+// WithMergePolicy sets conditions that PR has to satisfy before MergePR
+func WithMergePolicy(policy entity.MergePolicy) Option {
+	return func(s *Service) {
+		s.mergePolicy = policy
+	}
+}
+
+// WithEvents enables publishing of domain events for every change of PRs and their reviewers
+func WithEvents(publisher EventPublisher) Option {
+	return func(s *Service) {
+		s.publisher = publisher
+	}
+}
+
+// WithImportBatchSize sets how many PRs of a bulk import are created in one transaction
+func WithImportBatchSize(batchSize int) Option {
+	return func(s *Service) {
+		if batchSize > 0 {
+			s.importBatchSize = batchSize
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package pr
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/sirupsen/logrus"
+)
+
+// Returns reviews of open PRs which passed the author team's review SLA without a decision, the most overdue first.
+// SLA is counted in working time, weekends are skipped
+func (s *Service) GetOverdueReviews(ctx context.Context) ([]entity.OverdueReview, error) {
+	logrus.Info("PRService.GetOverdueReviews: fetching overdue reviews")
+
+	pending, err := s.PRRepo.ListPendingReviewsPastSLA(ctx)
+	if err != nil {
+		logrus.Errorf("PRService.GetOverdueReviews: failed to fetch pending reviews: %v", err)
+		return nil, ErrCannotFetchOverdue
+	}
+
+	now := time.Now()
+	overdue := make([]entity.OverdueReview, 0, len(pending))
+	for _, review := range pending {
+		spent := entity.WorkingTime(review.AssignedAt, now)
+		if spent <= review.ReviewSLA {
+			continue
+		}
+		review.Overdue = spent - review.ReviewSLA
+		overdue = append(overdue, review)
+	}
+
+	slices.SortStableFunc(overdue, func(a, b entity.OverdueReview) int {
+		return cmp.Compare(b.Overdue, a.Overdue)
+	})
+
+	logrus.Infof("PRService.GetOverdueReviews: found %d overdue reviews", len(overdue))
+	return overdue, nil
+}
@@ -0,0 +1,94 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/4udiwe/avito-pr-service/pkg/actor"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// Saves reviewer's decision on the PR. Only assigned reviewers of an open PR can submit it
+func (s *Service) SubmitReview(
+	ctx context.Context,
+	prID, reviewerID string,
+	state entity.ReviewState,
+	comment string,
+) (entity.Review, error) {
+	logrus.Infof("PRService.SubmitReview: reviewer %s submits %s for PR %s", reviewerID, state, prID)
+
+	if !state.IsValid() {
+		return entity.Review{}, ErrInvalidReviewState
+	}
+
+	var review entity.Review
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Review can't be added to the PR being merged or closed
+		if err := s.PRRepo.LockByID(ctx, prID); err != nil {
+			return err
+		}
+
+		pr, err := s.PRRepo.GetByID(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := checkAction(pr, entity.ActionSubmitReview); err != nil {
+			return err
+		}
+
+		if !slices.Contains(pr.Reviewers, reviewerID) {
+			return ErrReviewerNotAssigned
+		}
+
+		review, err = s.ReviewRepo.Create(ctx, prID, reviewerID, state, comment)
+		if err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, entity.ReviewSubmitted{
+			ReviewID:   review.ID,
+			PRID:       prID,
+			ReviewerID: reviewerID,
+			State:      state,
+			Actor:      actor.FromContext(ctx),
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, repository.ErrPRNotFound) {
+			return entity.Review{}, ErrPRNotFound
+		}
+		if errors.Is(err, ErrPRMerged) || errors.Is(err, ErrInvalidPRState) {
+			return entity.Review{}, err
+		}
+		if errors.Is(err, ErrReviewerNotAssigned) {
+			return entity.Review{}, ErrReviewerNotAssigned
+		}
+		logrus.Errorf("PRService.SubmitReview: failed to submit review for PR %s: %v", prID, err)
+		return entity.Review{}, ErrCannotSubmitReview
+	}
+
+	logrus.Infof("PRService.SubmitReview: review %s saved for PR %s", review.ID, prID)
+	return review, nil
+}
+
+// Fills Reviews of each PR with the latest decision of its reviewers
+func (s *Service) attachReviews(ctx context.Context, PRs []entity.PullRequest) error {
+	reviews, err := s.ReviewRepo.GetLatestByPRs(ctx, lo.Map(PRs, func(pr entity.PullRequest, _ int) string { return pr.ID }))
+	if err != nil {
+		return err
+	}
+
+	byPR := lo.GroupBy(reviews, func(r entity.Review) string { return r.PRID })
+	for i := range PRs {
+		PRs[i].Reviews = byPR[PRs[i].ID]
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package pr
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+// Built-in selectors. Each one delegates ordering of candidates to the user repository
+
+type randomSelector struct {
+	userRepo UserRepo
+}
+
+func NewRandomSelector(userRepo UserRepo) ReviewerSelector {
+	return &randomSelector{userRepo: userRepo}
+}
+
+func (s *randomSelector) Select(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error) {
+	return s.userRepo.GetRandomActiveTeammates(ctx, teamID, limit, excludeIDs...)
+}
+
+type leastLoadedSelector struct {
+	userRepo UserRepo
+}
+
+func NewLeastLoadedSelector(userRepo UserRepo) ReviewerSelector {
+	return &leastLoadedSelector{userRepo: userRepo}
+}
+
+func (s *leastLoadedSelector) Select(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error) {
+	return s.userRepo.GetLeastLoadedActiveTeammates(ctx, teamID, limit, excludeIDs...)
+}
+
+type roundRobinSelector struct {
+	userRepo UserRepo
+}
+
+func NewRoundRobinSelector(userRepo UserRepo) ReviewerSelector {
+	return &roundRobinSelector{userRepo: userRepo}
+}
+
+func (s *roundRobinSelector) Select(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error) {
+	return s.userRepo.GetRoundRobinActiveTeammates(ctx, teamID, limit, excludeIDs...)
+}
+
+type weightedSelector struct {
+	userRepo UserRepo
+}
+
+func NewWeightedSelector(userRepo UserRepo) ReviewerSelector {
+	return &weightedSelector{userRepo: userRepo}
+}
+
+func (s *weightedSelector) Select(ctx context.Context, teamID uuid.UUID, limit int, excludeIDs ...string) ([]entity.User, error) {
+	return s.userRepo.GetWeightedActiveTeammates(ctx, teamID, limit, excludeIDs...)
+}
@@ -3,34 +3,94 @@ package pr
 import (
 	"context"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/4udiwe/avito-pr-service/internal/entity"
 	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/4udiwe/avito-pr-service/internal/service/events"
+	"github.com/4udiwe/avito-pr-service/pkg/actor"
 	"github.com/4udiwe/avito-pr-service/pkg/transactor"
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 )
 
 type Service struct {
-	PRRepo    PRRepo
-	UserRepo  UserRepo
-	txManager transactor.Transactor
+	PRRepo        PRRepo
+	UserRepo      UserRepo
+	CodeOwnerRepo CodeOwnerRepo
+	HistoryRepo   HistoryRepo
+	ReviewRepo    ReviewRepo
+	txManager     transactor.Transactor
+
+	// Reviewer selection
+	selectors      map[entity.ReviewerStrategy]ReviewerSelector
+	strategy       entity.ReviewerStrategy
+	teamStrategies map[string]entity.ReviewerStrategy
+
+	// Fallback teams for teams with too few active reviewers (team name -> buddy team names)
+	buddyTeams map[string][]string
+
+	mergePolicy entity.MergePolicy
+
+	// PRs of a bulk import created in one transaction
+	importBatchSize int
+
+	// Optional outbox of domain events
+	publisher EventPublisher
+	// Writes assignment history and domain events
+	events *events.Recorder
 }
 
-func New(prRepo PRRepo, userRepo UserRepo, txManager transactor.Transactor) *Service {
-	return &Service{
-		PRRepo:    prRepo,
-		UserRepo:  userRepo,
-		txManager: txManager,
+func New(
+	prRepo PRRepo,
+	userRepo UserRepo,
+	codeOwnerRepo CodeOwnerRepo,
+	historyRepo HistoryRepo,
+	reviewRepo ReviewRepo,
+	txManager transactor.Transactor,
+	opts ...Option,
+) *Service {
+	s := &Service{
+		PRRepo:        prRepo,
+		UserRepo:      userRepo,
+		CodeOwnerRepo: codeOwnerRepo,
+		HistoryRepo:   historyRepo,
+		ReviewRepo:    reviewRepo,
+		txManager:     txManager,
+		selectors: map[entity.ReviewerStrategy]ReviewerSelector{
+			entity.StrategyRandom:      NewRandomSelector(userRepo),
+			entity.StrategyLeastLoaded: NewLeastLoadedSelector(userRepo),
+			entity.StrategyRoundRobin:  NewRoundRobinSelector(userRepo),
+			entity.StrategyWeighted:    NewWeightedSelector(userRepo),
+		},
+		strategy:        entity.StrategyRandom,
+		importBatchSize: defaultImportBatchSize,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.events = events.NewRecorder(historyRepo, s.publisher)
+
+	return s
 }
 
-func (s *Service) CreatePR(ctx context.Context, pullRequestID, title, authorID string) (entity.PullRequest, error) {
+// Creates PR and assigns reviewers: owners of the changed files go first, the rest is filled from author's team.
+// Teammates whose tags match PR labels are preferred. Draft PR is created without reviewers
+func (s *Service) CreatePR(
+	ctx context.Context,
+	pullRequestID, title, authorID string,
+	changedFiles, labels []string,
+	draft bool,
+) (entity.PullRequest, error) {
 	logrus.Infof("PRService.CreatePR: creating PR with title %s", title)
 
 	var pullRequest entity.PullRequest
 
+	labels = entity.NormalizeTags(labels)
+
 	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
 		// Get PR author
 		author, err := s.UserRepo.GetByID(ctx, authorID)
@@ -38,36 +98,51 @@ func (s *Service) CreatePR(ctx context.Context, pullRequestID, title, authorID s
 			return err
 		}
 
-		// Get 2 random author`s teammates (limit = 2). Exclude authorID
-		candidates, err := s.UserRepo.GetRandomActiveTeammates(ctx, author.Team.ID, entity.MinAmountOfReviewers, authorID)
-		if err != nil {
-			return err
-		}
+		status := entity.StatusDRAFT
+		picked := pickedReviewers{reviewerIDs: []string{}}
 
-		reviewerIDs := lo.Map(candidates, func(e entity.User, _ int) string { return e.ID })
-
-		// Check amount of reviewers, if < 2, set needMoreReviewers = true
-		var needMoreReviewersStatus bool
-		if len(reviewerIDs) < entity.MinAmountOfReviewers {
-			needMoreReviewersStatus = true
+		if !draft {
+			status = entity.StatusOPEN
+			picked, err = s.pickReviewers(ctx, author, changedFiles, labels)
+			if err != nil {
+				return err
+			}
 		}
 
 		// Create the PR
-		pr, err := s.PRRepo.Create(ctx, pullRequestID, title, authorID, string(entity.StatusOPEN), needMoreReviewersStatus)
+		pr, err := s.PRRepo.Create(ctx, pullRequestID, title, authorID, string(status), picked.needMore)
 		if err != nil {
 			return err
 		}
 
 		pullRequest = pr
 
+		if len(labels) > 0 {
+			if err := s.PRRepo.AddLabels(ctx, pullRequestID, labels); err != nil {
+				return err
+			}
+			pullRequest.Labels = labels
+		}
+
 		// Assign reviewers
-		err = s.PRRepo.AssignReviewers(ctx, pullRequestID, reviewerIDs)
-		if err != nil {
+		if err := s.assignPicked(ctx, pullRequestID, picked, "assigned on PR creation"); err != nil {
 			return err
 		}
 
-		pullRequest.Reviewers = reviewerIDs
-		return nil
+		pullRequest.Reviewers = picked.all()
+		if len(picked.fallbackIDs) > 0 {
+			pullRequest.FallbackReviewers = picked.fallbackIDs
+		}
+
+		return s.events.Publish(ctx, entity.PRCreated{
+			PRID:      pullRequest.ID,
+			Title:     pullRequest.Title,
+			AuthorID:  pullRequest.AuthorID,
+			Status:    status,
+			Reviewers: pullRequest.Reviewers,
+			Labels:    labels,
+			Actor:     actor.FromContext(ctx),
+		})
 	})
 
 	if err != nil {
@@ -103,16 +178,60 @@ func (s *Service) GetAllPRs(ctx context.Context, page, pageSize int) ([]entity.P
 		return nil, 0, ErrCannotFetchPRs
 	}
 
+	if err := s.attachReviews(ctx, PRs); err != nil {
+		logrus.Errorf("PRService.GetAllPRs: failed to fetch reviews %v", err)
+		return nil, 0, ErrCannotFetchPRs
+	}
+
 	logrus.Infof("PRService.GetAllPRs: fetched %d PRs", len(PRs))
 	return PRs, total, nil
 }
 
-func (s *Service) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (entity.PullRequest, string, error) {
+// Replaces reviewer of the PR. Empty newReviewerID means a teammate of the old reviewer is picked automatically
+func (s *Service) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) (entity.PullRequest, string, error) {
 	logrus.Infof("PRService.ReassignReviewer: reassigning reviewer for PR %s", prID)
 
+	if err := s.authorizeReassign(ctx, prID, oldReviewerID); err != nil {
+		if errors.Is(err, ErrReassignForbidden) {
+			return entity.PullRequest{}, "", err
+		}
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return entity.PullRequest{}, "", ErrReviewerNotFound
+		}
+		logrus.Errorf("PRService.ReassignReviewer: failed to authorize reassignment: %v", err)
+		return entity.PullRequest{}, "", ErrCannotAssignReviewer
+	}
+
+	return s.reassignReviewer(ctx, prID, oldReviewerID, newReviewerID, false, entity.AssignmentEventReassign, "manual reassignment")
+}
+
+// HandOverReview gives the review of a reviewer leaving its team to a teammate from that team, picked
+// the same way as on automatic reassignment. With otherTeams a reviewer without free teammates is replaced
+// by a member of buddy teams of the author's team, then by any active user. Joins the transaction from ctx.
+// Returns empty ID when nobody can take the review or reviewers of the PR can't be changed anymore, the review is kept then
+func (s *Service) HandOverReview(ctx context.Context, prID, reviewerID, reason string, otherTeams bool) (string, error) {
+	_, newReviewerID, err := s.reassignReviewer(ctx, prID, reviewerID, "", otherTeams, entity.AssignmentEventAutoReassign, reason)
+	if errors.Is(err, ErrNoMoreReviewersToReassign) || errors.Is(err, ErrPRMerged) || errors.Is(err, ErrInvalidPRState) {
+		logrus.Warnf("PRService.HandOverReview: review of %s on PR %s is kept: %v", reviewerID, prID, err)
+		return "", nil
+	}
+	return newReviewerID, err
+}
+
+// Replaces old reviewer with newReviewerID or, when it is empty, with a teammate of the old reviewer
+// (with otherTeams - with a reviewer from other teams, if no teammate is free).
+// Change is recorded in history with the given event type and reason
+func (s *Service) reassignReviewer(
+	ctx context.Context,
+	prID, oldReviewerID, newReviewerID string,
+	otherTeams bool,
+	eventType entity.AssignmentEventType,
+	reason string,
+) (entity.PullRequest, string, error) {
 	var pullRequest entity.PullRequest
 	var reviewers []entity.PRReviewer
 	var newReviewer entity.User
+	var newReviewerTeam string
 
 	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
 		// Check if PR exists
@@ -124,34 +243,75 @@ func (s *Service) ReassignReviewer(ctx context.Context, prID, oldReviewerID stri
 		pullRequest = pr
 
 		// Check status of the PR
-		if pullRequest.Status.Name == entity.StatusMERGED {
-			return ErrCannotReassignReviewerForMergedPR
+		if _, err := checkAction(pr, entity.ActionReassignReviewer); err != nil {
+			return err
+		}
+
+		if !slices.Contains(pr.Reviewers, oldReviewerID) {
+			return ErrReviewerNotAssigned
+		}
+
+		if newReviewerID != "" {
+			newReviewer, err = s.checkReassignCandidate(ctx, pr, newReviewerID)
+			if err != nil {
+				return err
+			}
+			newReviewerTeam = newReviewer.Team.Name
+		} else {
+			// Get old reviewer with team
+			oldReviewer, err := s.UserRepo.GetByID(ctx, oldReviewerID)
+			if err != nil {
+				return err
+			}
+
+			// Get teammate (limit = 1), preferring ones matching PR labels. Exclude author and current reviewers, old one included
+			excludeIDs := append([]string{pullRequest.AuthorID}, pr.Reviewers...)
+			reviewers, err := s.selectReviewers(ctx, oldReviewer.Team, 1, pullRequest.Labels, excludeIDs...)
+			if err != nil {
+				return err
+			}
+			newReviewerTeam = oldReviewer.Team.Name
+			if len(reviewers) == 0 && otherTeams {
+				reviewers, err = s.selectOtherTeamReviewer(ctx, pr, excludeIDs...)
+				if err != nil {
+					return err
+				}
+				if len(reviewers) > 0 {
+					newReviewerTeam = reviewers[0].Team.Name
+				}
+			}
+			if len(reviewers) == 0 {
+				return ErrNoMoreReviewersToReassign
+			}
+			newReviewer = reviewers[0]
 		}
 
-		// Get old reviewer with team
-		oldReviewer, err := s.UserRepo.GetByID(ctx, oldReviewerID)
+		isFallback, err := s.isFallbackTeam(ctx, pr, newReviewerTeam)
 		if err != nil {
 			return err
 		}
 
-		// Get random teammate (limit = 1). Exclude authorID and oldReviewerID
-		reviewers, err := s.UserRepo.GetRandomActiveTeammates(ctx, oldReviewer.Team.ID, 1, pullRequest.AuthorID, oldReviewerID)
+		// Reassign reviewer
+		err = s.PRRepo.ReassignReviewer(ctx, prID, oldReviewerID, newReviewer.ID, isFallback)
 		if err != nil {
 			return err
 		}
-		if len(reviewers) == 0 {
-			return ErrNoMoreReviewersToReassign
-		}
-		newReviewer = reviewers[0]
 
-		// Reassign reviewer
-		return s.PRRepo.ReassignReviewer(ctx, prID, oldReviewerID, newReviewer.ID)
+		return s.events.RecordAssignments(ctx, []entity.AssignmentEvent{
+			newAssignmentEvent(ctx, prID, eventType, newReviewer.ID, oldReviewerID, reason),
+		})
 	})
 
 	if err != nil {
 		if errors.Is(err, ErrNoMoreReviewersToReassign) {
 			return entity.PullRequest{}, "", ErrNoMoreReviewersToReassign
 		}
+		if errors.Is(err, ErrPRMerged) || errors.Is(err, ErrInvalidPRState) || errors.Is(err, ErrReviewerNotAssigned) {
+			return entity.PullRequest{}, "", err
+		}
+		if errors.Is(err, ErrCandidateIsAuthor) || errors.Is(err, ErrCandidateInactive) || errors.Is(err, ErrReviewerAlreadyAssigned) {
+			return entity.PullRequest{}, "", err
+		}
 		if errors.Is(err, repository.ErrPRNotFound) {
 			return entity.PullRequest{}, "", ErrPRNotFound
 		}
@@ -178,46 +338,123 @@ func (s *Service) ReassignReviewer(ctx context.Context, prID, oldReviewerID stri
 	return pullRequest, newReviewer.ID, nil
 }
 
-func (s *Service) MergePR(ctx context.Context, prID string) (entity.PullRequest, error) {
+// Checks that manually chosen user can take over the review: user exists, is active,
+// is not the author and does not review this PR yet
+func (s *Service) checkReassignCandidate(ctx context.Context, pr entity.PullRequest, candidateID string) (entity.User, error) {
+	if candidateID == pr.AuthorID {
+		return entity.User{}, ErrCandidateIsAuthor
+	}
+	if slices.Contains(pr.Reviewers, candidateID) {
+		return entity.User{}, ErrReviewerAlreadyAssigned
+	}
+
+	candidate, err := s.UserRepo.GetByID(ctx, candidateID)
+	if err != nil {
+		return entity.User{}, err
+	}
+	if !candidate.IsActive {
+		return entity.User{}, ErrCandidateInactive
+	}
+
+	return candidate, nil
+}
+
+// Merges open PR if it satisfies the merge policy. With force the policy is skipped
+// and unmet conditions are recorded together with the actor
+func (s *Service) MergePR(ctx context.Context, prID string, force bool) (entity.PullRequest, error) {
 	logrus.Infof("PRService.MergePR: merging PR %s", prID)
 
 	var pullRequest entity.PullRequest
+	var alreadyMerged bool
 
-	// Check if PR is already MERGED
-	pullRequest, err := s.PRRepo.GetByID(ctx, prID)
-	if err != nil {
-		if errors.Is(err, repository.ErrPRNotFound) {
-			return entity.PullRequest{}, ErrPRNotFound
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Status and reviews of the PR can't change until the merge is committed
+		if err := s.PRRepo.LockByID(ctx, prID); err != nil {
+			return err
 		}
-		logrus.Errorf("PRService.MergePR: failed to PR %s: %v", prID, err)
-		return entity.PullRequest{}, ErrCannotMergePR
-	}
 
-	if pullRequest.Status.Name == entity.StatusMERGED {
-		return pullRequest, nil
-	}
+		pr, err := s.PRRepo.GetByID(ctx, prID)
+		if err != nil {
+			return err
+		}
 
-	// Get ID of MERGED status
-	statuses, err := s.PRRepo.GetPRStatuses(ctx)
-	if err != nil {
-		logrus.Errorf("PRService.MergePR: failed to get PR statuses for PR %s: %v", prID, err)
-		return entity.PullRequest{}, ErrCannotFetchStatus
-	}
-	status, ok := lo.Find(statuses, func(s entity.Status) bool { return s.Name == entity.StatusMERGED })
-	if !ok {
-		return entity.PullRequest{}, ErrStatusNotFound
-	}
-	mergedStatusID := status.ID
+		pullRequest = pr
+
+		if err := authorizeMerge(ctx, pr, force); err != nil {
+			return err
+		}
+
+		// Merge is idempotent
+		if pr.Status.Name == entity.StatusMERGED {
+			alreadyMerged = true
+			return nil
+		}
+
+		if _, err := checkAction(pr, entity.ActionMerge); err != nil {
+			return err
+		}
+
+		// Check merge policy
+		unmet, err := s.checkMergePolicy(ctx, pr)
+		if err != nil {
+			logrus.Errorf("PRService.MergePR: failed to check merge policy for PR %s: %v", prID, err)
+			return ErrCannotMergePR
+		}
+		if len(unmet) > 0 && !force {
+			logrus.Warnf("PRService.MergePR: merge of PR %s blocked, unmet conditions: %v", prID, unmet)
+			return &MergeBlockedError{Unmet: unmet}
+		}
+
+		// Get ID of MERGED status
+		statuses, err := s.PRRepo.GetPRStatuses(ctx)
+		if err != nil {
+			logrus.Errorf("PRService.MergePR: failed to get PR statuses for PR %s: %v", prID, err)
+			return ErrCannotFetchStatus
+		}
+		status, ok := lo.Find(statuses, func(s entity.Status) bool { return s.Name == entity.StatusMERGED })
+		if !ok {
+			return ErrStatusNotFound
+		}
 
-	// Update PR status to MERGED
-	err = s.PRRepo.UpdateStatus(ctx, prID, mergedStatusID, time.Now())
+		// Audit forced merge
+		if len(unmet) > 0 {
+			conditions := lo.Map(unmet, func(c entity.MergeCondition, _ int) string { return string(c) })
+			if err := s.PRRepo.AddMergeOverride(ctx, prID, actor.FromContext(ctx), conditions); err != nil {
+				return err
+			}
+		}
+
+		// Update PR status to MERGED
+		mergedAt := time.Now()
+		if err := s.PRRepo.UpdateStatus(ctx, prID, status.ID, mergedAt); err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, entity.PRMerged{
+			PRID:     prID,
+			Title:    pr.Title,
+			AuthorID: pr.AuthorID,
+			MergedAt: mergedAt,
+			Actor:    actor.FromContext(ctx),
+			Forced:   len(unmet) > 0,
+		})
+	})
 	if err != nil {
 		if errors.Is(err, repository.ErrPRNotFound) {
 			logrus.Warnf("PRService.MergePR: PR with ID %s not found", prID)
 			return entity.PullRequest{}, ErrPRNotFound
 		}
+		if errors.Is(err, ErrMergeForbidden) || errors.Is(err, ErrForceMergeForbidden) || errors.Is(err, ErrMergeBlocked) ||
+			errors.Is(err, ErrPRMerged) || errors.Is(err, ErrInvalidPRState) ||
+			errors.Is(err, ErrCannotMergePR) || errors.Is(err, ErrCannotFetchStatus) || errors.Is(err, ErrStatusNotFound) {
+			return entity.PullRequest{}, err
+		}
 		logrus.Errorf("PRService.MergePR: failed to update status for PR %s: %v", prID, err)
-		return entity.PullRequest{}, ErrCannotCreatePR
+		return entity.PullRequest{}, ErrCannotMergePR
+	}
+
+	if alreadyMerged {
+		return pullRequest, nil
 	}
 
 	// Get updated PR with reviewers
@@ -245,9 +482,14 @@ func (s *Service) AssignReviewer(ctx context.Context, prID, newReviewerID string
 
 		pullRequest = pr
 
+		// Check status of the PR
+		if _, err := checkAction(pr, entity.ActionAssignReviewer); err != nil {
+			return err
+		}
+
 		// Check need_more_reviewers flag on PR
 		if !pr.NeedMoreReviewers {
-			return ErrPRAlreadyHas2Reviewers
+			return ErrPRHasEnoughReviewers
 		}
 
 		// Assign new reviewer
@@ -256,10 +498,23 @@ func (s *Service) AssignReviewer(ctx context.Context, prID, newReviewerID string
 			return err
 		}
 
-		// Check old amount of reviewers:
-		// if was 1 (+ 1 new = 2) -> change need_more_reviewers to FALSE
-		// if was 0 -> do nothing
-		if len(pr.Reviewers) > 0 {
+		err = s.events.RecordAssignments(ctx, []entity.AssignmentEvent{
+			newAssignmentEvent(ctx, prID, entity.AssignmentEventAssign, newReviewerID, "", "manual assignment"),
+		})
+		if err != nil {
+			return err
+		}
+
+		// Get required amount of reviewers from author`s team
+		author, err := s.UserRepo.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+
+		// Check new amount of reviewers:
+		// if old + 1 new reaches required -> change need_more_reviewers to FALSE
+		// otherwise -> do nothing
+		if len(pr.Reviewers)+1 >= author.Team.ReviewersRequired() {
 			err = s.PRRepo.UpdateNeedMoreReviewers(ctx, prID)
 		}
 
@@ -267,8 +522,11 @@ func (s *Service) AssignReviewer(ctx context.Context, prID, newReviewerID string
 	})
 
 	if err != nil {
-		if errors.Is(err, ErrPRAlreadyHas2Reviewers) {
-			return entity.PullRequest{}, ErrPRAlreadyHas2Reviewers
+		if errors.Is(err, ErrPRHasEnoughReviewers) {
+			return entity.PullRequest{}, ErrPRHasEnoughReviewers
+		}
+		if errors.Is(err, ErrPRMerged) || errors.Is(err, ErrInvalidPRState) {
+			return entity.PullRequest{}, err
 		}
 		if errors.Is(err, repository.ErrPRNotFound) {
 			return entity.PullRequest{}, ErrPRNotFound
@@ -294,3 +552,102 @@ func (s *Service) AssignReviewer(ctx context.Context, prID, newReviewerID string
 
 	return pullRequest, nil
 }
+
+// Picks up to limit active teammates with the strategy configured for the team
+func (s *Service) selectTeammates(ctx context.Context, team entity.Team, limit int, excludeIDs ...string) ([]entity.User, error) {
+	return spreadImportLoad(ctx, limit, func(limit int) ([]entity.User, error) {
+		return s.selectorFor(team.Name).Select(ctx, team.ID, limit, excludeIDs...)
+	})
+}
+
+// Teammates whose tags match PR labels go first, the rest is picked by the team's strategy
+func (s *Service) selectReviewers(ctx context.Context, team entity.Team, limit int, labels []string, excludeIDs ...string) ([]entity.User, error) {
+	if len(labels) == 0 {
+		return s.selectTeammates(ctx, team, limit, excludeIDs...)
+	}
+
+	matched, err := spreadImportLoad(ctx, limit, func(limit int) ([]entity.User, error) {
+		return s.UserRepo.GetTagMatchedActiveTeammates(ctx, team.ID, labels, limit, excludeIDs...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(matched) >= limit {
+		return matched, nil
+	}
+
+	excludeIDs = append(slices.Clone(excludeIDs), lo.Map(matched, func(e entity.User, _ int) string { return e.ID })...)
+	rest, err := s.selectTeammates(ctx, team, limit-len(matched), excludeIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(matched, rest...), nil
+}
+
+// Picks up to limit random active members of the team's buddy teams. Returns nothing if buddies are not configured
+func (s *Service) selectFallbackReviewers(ctx context.Context, team entity.Team, limit int, excludeIDs ...string) ([]string, error) {
+	buddies := s.buddyTeams[team.Name]
+	if len(buddies) == 0 || limit <= 0 {
+		return nil, nil
+	}
+
+	users, err := spreadImportLoad(ctx, limit, func(limit int) ([]entity.User, error) {
+		return s.UserRepo.GetRandomActiveUsersByTeams(ctx, buddies, limit, excludeIDs...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) > 0 {
+		logrus.Infof("PRService: %d reviewers picked from buddy teams %+v of team %s", len(users), buddies, team.Name)
+	}
+	return lo.Map(users, func(e entity.User, _ int) string { return e.ID }), nil
+}
+
+// Picks a free reviewer outside of the old reviewer's team: a member of buddy teams of the author's team
+// (the same as on assignment), then any active user
+func (s *Service) selectOtherTeamReviewer(ctx context.Context, pr entity.PullRequest, excludeIDs ...string) ([]entity.User, error) {
+	if len(s.buddyTeams) > 0 {
+		author, err := s.UserRepo.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return nil, err
+		}
+		if buddies := s.buddyTeams[author.Team.Name]; len(buddies) > 0 {
+			users, err := s.UserRepo.GetRandomActiveUsersByTeams(ctx, buddies, 1, excludeIDs...)
+			if err != nil || len(users) > 0 {
+				return users, err
+			}
+		}
+	}
+
+	return s.UserRepo.GetRandomActiveUsers(ctx, 1, excludeIDs...)
+}
+
+// Reviewers of the PR from a buddy team of the author's team are fallback ones
+func (s *Service) isFallbackTeam(ctx context.Context, pr entity.PullRequest, teamName string) (bool, error) {
+	if len(s.buddyTeams) == 0 {
+		return false, nil
+	}
+
+	author, err := s.UserRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(s.buddyTeams[author.Team.Name], teamName), nil
+}
+
+// Team override goes first, then the default strategy. Unknown strategies fall back to random
+func (s *Service) selectorFor(teamName string) ReviewerSelector {
+	strategy := s.strategy
+	if teamStrategy, ok := s.teamStrategies[teamName]; ok {
+		strategy = teamStrategy
+	}
+
+	selector, ok := s.selectors[strategy]
+	if !ok {
+		logrus.Warnf("PRService: unknown reviewer strategy %q for team %s, using %q", strategy, teamName, entity.StrategyRandom)
+		return s.selectors[entity.StrategyRandom]
+	}
+	return selector
+}
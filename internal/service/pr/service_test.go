@@ -3,14 +3,20 @@ package pr_test
 import (
 	"context"
 	"errors"
+	"reflect"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/4udiwe/avito-pr-service/internal/entity"
 	mock_transactor "github.com/4udiwe/avito-pr-service/internal/mocks"
 	"github.com/4udiwe/avito-pr-service/internal/repository"
 	service "github.com/4udiwe/avito-pr-service/internal/service/pr"
 	"github.com/4udiwe/avito-pr-service/internal/service/pr/mocks"
+	"github.com/4udiwe/avito-pr-service/pkg/actor"
+	"github.com/4udiwe/avito-pr-service/pkg/auth"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"go.uber.org/mock/gomock"
 )
 
@@ -168,12 +174,14 @@ func TestService_CreatePR(t *testing.T) {
 			pr := mocks.NewMockPRRepo(ctrl)
 			u := mocks.NewMockUserRepo(ctrl)
 			tx := mock_transactor.NewMockTransactor(ctrl)
+			history := mocks.NewMockHistoryRepo(ctrl)
+			history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
-			svc := service.New(pr, u, tx)
+			svc := service.New(pr, u, nil, history, nil, tx)
 
 			tt.setup(pr, u, tx)
 
-			_, err := svc.CreatePR(ctx, "pr1", "title", "author1")
+			_, err := svc.CreatePR(ctx, "pr1", "title", "author1", nil, nil, false)
 			if !errors.Is(err, tt.expectedErr) {
 				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
 			}
@@ -181,17 +189,85 @@ func TestService_CreatePR(t *testing.T) {
 	}
 }
 
+func TestService_CreatePR_TeamRequiredReviewers(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		requiredReviewers int
+		candidates        []entity.User
+		needMoreReviewers bool
+	}{
+		{
+			name:              "docs team needs 1 reviewer",
+			requiredReviewers: 1,
+			candidates:        []entity.User{{ID: "r1"}},
+			needMoreReviewers: false,
+		},
+		{
+			name:              "platform team needs 3 reviewers, got 2",
+			requiredReviewers: 3,
+			candidates:        []entity.User{{ID: "r1"}, {ID: "r2"}},
+			needMoreReviewers: true,
+		},
+		{
+			name:              "platform team needs 3 reviewers, got 3",
+			requiredReviewers: 3,
+			candidates:        []entity.User{{ID: "r1"}, {ID: "r2"}, {ID: "r3"}},
+			needMoreReviewers: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			author := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New(), RequiredReviewers: tt.requiredReviewers}}
+			reviewerIDs := make([]string, 0, len(tt.candidates))
+			for _, c := range tt.candidates {
+				reviewerIDs = append(reviewerIDs, c.ID)
+			}
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			uRepo := mocks.NewMockUserRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			history := mocks.NewMockHistoryRepo(ctrl)
+			history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
+			uRepo.EXPECT().GetByID(gomock.Any(), author.ID).Return(author, nil)
+			uRepo.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, tt.requiredReviewers, author.ID).
+				Return(tt.candidates, nil)
+			prRepo.EXPECT().Create(gomock.Any(), "pr1", "title", author.ID, "OPEN", tt.needMoreReviewers).
+				Return(entity.PullRequest{ID: "pr1"}, nil)
+			prRepo.EXPECT().AssignReviewers(gomock.Any(), "pr1", reviewerIDs).Return(nil)
+
+			svc := service.New(prRepo, uRepo, nil, history, nil, tx)
+
+			if _, err := svc.CreatePR(ctx, "pr1", "title", author.ID, nil, nil, false); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestService_GetAllPRs(t *testing.T) {
 	ctx := context.Background()
 
+	approved := entity.Review{PRID: "pr1", ReviewerID: "u1", State: entity.ReviewApproved}
+
 	tests := []struct {
 		name        string
-		setup       func(pr *mocks.MockPRRepo)
+		setup       func(pr *mocks.MockPRRepo, r *mocks.MockReviewRepo)
+		wantReviews [][]entity.Review
 		expectedErr error
 	}{
 		{
 			name: "repo error",
-			setup: func(pr *mocks.MockPRRepo) {
+			setup: func(pr *mocks.MockPRRepo, r *mocks.MockReviewRepo) {
 				pr.EXPECT().
 					GetAll(gomock.Any(), 10, 0).
 					Return(nil, 0, errors.New("db"))
@@ -201,16 +277,32 @@ func TestService_GetAllPRs(t *testing.T) {
 
 		{
 			name: "success",
-			setup: func(pr *mocks.MockPRRepo) {
+			setup: func(pr *mocks.MockPRRepo, r *mocks.MockReviewRepo) {
 				pr.EXPECT().
 					GetAll(gomock.Any(), 10, 0).
 					Return([]entity.PullRequest{
 						{ID: "pr1", Title: "one"},
 						{ID: "pr2", Title: "two"},
 					}, 2, nil)
+				r.EXPECT().
+					GetLatestByPRs(gomock.Any(), []string{"pr1", "pr2"}).
+					Return([]entity.Review{approved}, nil)
 			},
+			wantReviews: [][]entity.Review{{approved}, nil},
 			expectedErr: nil,
 		},
+		{
+			name: "reviews error",
+			setup: func(pr *mocks.MockPRRepo, r *mocks.MockReviewRepo) {
+				pr.EXPECT().
+					GetAll(gomock.Any(), 10, 0).
+					Return([]entity.PullRequest{{ID: "pr1"}}, 1, nil)
+				r.EXPECT().
+					GetLatestByPRs(gomock.Any(), []string{"pr1"}).
+					Return(nil, errors.New("db"))
+			},
+			expectedErr: service.ErrCannotFetchPRs,
+		},
 	}
 
 	for _, tt := range tests {
@@ -220,16 +312,22 @@ func TestService_GetAllPRs(t *testing.T) {
 
 			prRepo := mocks.NewMockPRRepo(ctrl)
 			u := mocks.NewMockUserRepo(ctrl)
+			r := mocks.NewMockReviewRepo(ctrl)
 
-			svc := service.New(prRepo, u, nil)
+			svc := service.New(prRepo, u, nil, nil, r, nil)
 
-			tt.setup(prRepo)
+			tt.setup(prRepo, r)
 
-			_, _, err := svc.GetAllPRs(ctx, 1, 10)
+			PRs, _, err := svc.GetAllPRs(ctx, 1, 10)
 
 			if !errors.Is(err, tt.expectedErr) {
 				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
 			}
+			for i, want := range tt.wantReviews {
+				if !reflect.DeepEqual(PRs[i].Reviews, want) {
+					t.Fatalf("expected reviews %v for %s, got %v", want, PRs[i].ID, PRs[i].Reviews)
+				}
+			}
 		})
 	}
 }
@@ -244,9 +342,10 @@ func TestService_ReassignReviewer(t *testing.T) {
 	openStatus := entity.Status{ID: 1, Name: entity.StatusOPEN}
 
 	tests := []struct {
-		name        string
-		setup       func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, tx *mock_transactor.MockTransactor)
-		expectedErr error
+		name          string
+		newReviewerID string
+		setup         func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, tx *mock_transactor.MockTransactor)
+		expectedErr   error
 	}{
 		{
 			name: "PR not found",
@@ -266,7 +365,7 @@ func TestService_ReassignReviewer(t *testing.T) {
 					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
 				)
 
-				pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: author.ID}, nil)
+				pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: author.ID, Reviewers: []string{oldReviewerID}}, nil)
 				u.EXPECT().GetByID(gomock.Any(), oldReviewerID).Return(entity.User{}, repository.ErrUserNotFound)
 			},
 			expectedErr: service.ErrReviewerNotFound,
@@ -278,7 +377,7 @@ func TestService_ReassignReviewer(t *testing.T) {
 					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
 				)
 
-				PR := entity.PullRequest{ID: prID, Status: openStatus, AuthorID: author.ID}
+				PR := entity.PullRequest{ID: prID, Status: openStatus, AuthorID: author.ID, Reviewers: []string{oldReviewerID}}
 				emptyUsers := []entity.User{}
 
 				pr.EXPECT().GetByID(gomock.Any(), prID).Return(PR, nil)
@@ -294,11 +393,11 @@ func TestService_ReassignReviewer(t *testing.T) {
 					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
 				)
 
-				pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: author.ID}, nil)
+				pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: author.ID, Reviewers: []string{oldReviewerID}}, nil)
 				u.EXPECT().GetByID(gomock.Any(), oldReviewerID).Return(oldReviewer, nil)
 				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), oldReviewer.Team.ID, 1, author.ID, oldReviewerID).
 					Return([]entity.User{{ID: "newRev"}}, nil)
-				pr.EXPECT().ReassignReviewer(gomock.Any(), prID, oldReviewerID, "newRev").Return(errors.New("db"))
+				pr.EXPECT().ReassignReviewer(gomock.Any(), prID, oldReviewerID, "newRev", false).Return(errors.New("db"))
 			},
 			expectedErr: service.ErrCannotAssignReviewer,
 		},
@@ -309,15 +408,91 @@ func TestService_ReassignReviewer(t *testing.T) {
 					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
 				)
 
-				pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: author.ID}, nil)
+				pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: author.ID, Reviewers: []string{oldReviewerID}}, nil)
 				u.EXPECT().GetByID(gomock.Any(), oldReviewerID).Return(oldReviewer, nil)
 				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), oldReviewer.Team.ID, 1, author.ID, oldReviewerID).
 					Return([]entity.User{{ID: "newRev"}}, nil)
-				pr.EXPECT().ReassignReviewer(gomock.Any(), prID, oldReviewerID, "newRev").Return(nil)
+				pr.EXPECT().ReassignReviewer(gomock.Any(), prID, oldReviewerID, "newRev", false).Return(nil)
 				pr.EXPECT().GetReviewersByPR(gomock.Any(), prID).Return([]entity.PRReviewer{{PRID: prID, ReviewerID: "newRev"}}, nil)
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "old reviewer not assigned",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, tx *mock_transactor.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
+
+				pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: author.ID, Reviewers: []string{"other"}}, nil)
+			},
+			expectedErr: service.ErrReviewerNotAssigned,
+		},
+		{
+			name:          "manual pick is the author",
+			newReviewerID: author.ID,
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, tx *mock_transactor.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
+
+				pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: author.ID, Reviewers: []string{oldReviewerID}}, nil)
+			},
+			expectedErr: service.ErrCandidateIsAuthor,
+		},
+		{
+			name:          "manual pick is already assigned",
+			newReviewerID: "rev2",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, tx *mock_transactor.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
+
+				pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: author.ID, Reviewers: []string{oldReviewerID, "rev2"}}, nil)
+			},
+			expectedErr: service.ErrReviewerAlreadyAssigned,
+		},
+		{
+			name:          "manual pick not found",
+			newReviewerID: "ghost",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, tx *mock_transactor.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
+
+				pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: author.ID, Reviewers: []string{oldReviewerID}}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "ghost").Return(entity.User{}, repository.ErrUserNotFound)
+			},
+			expectedErr: service.ErrReviewerNotFound,
+		},
+		{
+			name:          "manual pick is inactive",
+			newReviewerID: "rev2",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, tx *mock_transactor.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
+
+				pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: author.ID, Reviewers: []string{oldReviewerID}}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "rev2").Return(entity.User{ID: "rev2", IsActive: false}, nil)
+			},
+			expectedErr: service.ErrCandidateInactive,
+		},
+		{
+			name:          "manual pick success",
+			newReviewerID: "rev2",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, tx *mock_transactor.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
+
+				pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: author.ID, Reviewers: []string{oldReviewerID}}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "rev2").Return(entity.User{ID: "rev2", IsActive: true}, nil)
+				pr.EXPECT().ReassignReviewer(gomock.Any(), prID, oldReviewerID, "rev2", false).Return(nil)
+				pr.EXPECT().GetReviewersByPR(gomock.Any(), prID).Return([]entity.PRReviewer{{PRID: prID, ReviewerID: "rev2"}}, nil)
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -328,15 +503,135 @@ func TestService_ReassignReviewer(t *testing.T) {
 			prRepo := mocks.NewMockPRRepo(ctrl)
 			uRepo := mocks.NewMockUserRepo(ctrl)
 			tx := mock_transactor.NewMockTransactor(ctrl)
+			history := mocks.NewMockHistoryRepo(ctrl)
+			history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
-			svc := service.New(prRepo, uRepo, tx)
+			svc := service.New(prRepo, uRepo, nil, history, nil, tx)
 
 			tt.setup(prRepo, uRepo, tx)
 
-			_, _, err := svc.ReassignReviewer(ctx, prID, oldReviewerID)
+			_, _, err := svc.ReassignReviewer(ctx, prID, oldReviewerID, tt.newReviewerID)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestService_HandOverReview(t *testing.T) {
+	ctx := context.Background()
+	prID := "pr1"
+	reviewerID := "rev1"
+
+	team := entity.Team{ID: uuid.New()}
+	reviewer := entity.User{ID: reviewerID, Team: team}
+	openStatus := entity.Status{ID: 1, Name: entity.StatusOPEN}
+	mergedStatus := entity.Status{ID: 2, Name: entity.StatusMERGED}
+
+	tests := []struct {
+		name        string
+		otherTeams  bool
+		opts        []service.Option
+		setup       func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo)
+		expectedID  string
+		expectedErr error
+	}{
+		{
+			name: "handed over to teammate",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), prID).
+					Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: "author1", Reviewers: []string{reviewerID, "rev2"}}, nil)
+				u.EXPECT().GetByID(gomock.Any(), reviewerID).Return(reviewer, nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), team.ID, 1, "author1", reviewerID, "rev2").
+					Return([]entity.User{{ID: "rev3"}}, nil)
+				pr.EXPECT().ReassignReviewer(gomock.Any(), prID, reviewerID, "rev3", false).Return(nil)
+				pr.EXPECT().GetReviewersByPR(gomock.Any(), prID).
+					Return([]entity.PRReviewer{{PRID: prID, ReviewerID: "rev3"}, {PRID: prID, ReviewerID: "rev2"}}, nil)
+			},
+			expectedID: "rev3",
+		},
+		{
+			name: "kept when nobody can take it",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), prID).
+					Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: "author1", Reviewers: []string{reviewerID}}, nil)
+				u.EXPECT().GetByID(gomock.Any(), reviewerID).Return(reviewer, nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), team.ID, 1, "author1", reviewerID).Return(nil, nil)
+			},
+		},
+		{
+			name:       "handed over to other team",
+			otherTeams: true,
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), prID).
+					Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: "author1", Reviewers: []string{reviewerID}}, nil)
+				u.EXPECT().GetByID(gomock.Any(), reviewerID).Return(reviewer, nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), team.ID, 1, "author1", reviewerID).Return(nil, nil)
+				u.EXPECT().GetRandomActiveUsers(gomock.Any(), 1, "author1", reviewerID).
+					Return([]entity.User{{ID: "rev3", Team: entity.Team{Name: "frontend"}}}, nil)
+				pr.EXPECT().ReassignReviewer(gomock.Any(), prID, reviewerID, "rev3", false).Return(nil)
+				pr.EXPECT().GetReviewersByPR(gomock.Any(), prID).Return([]entity.PRReviewer{{PRID: prID, ReviewerID: "rev3"}}, nil)
+			},
+			expectedID: "rev3",
+		},
+		{
+			name:       "handed over to buddy team",
+			otherTeams: true,
+			opts:       []service.Option{service.WithBuddyTeams(map[string][]string{"backend": {"platform"}})},
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), prID).
+					Return(entity.PullRequest{ID: prID, Status: openStatus, AuthorID: "author1", Reviewers: []string{reviewerID}}, nil)
+				u.EXPECT().GetByID(gomock.Any(), reviewerID).Return(reviewer, nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), team.ID, 1, "author1", reviewerID).Return(nil, nil)
+				u.EXPECT().GetByID(gomock.Any(), "author1").Return(entity.User{ID: "author1", Team: entity.Team{Name: "backend"}}, nil).Times(2)
+				u.EXPECT().GetRandomActiveUsersByTeams(gomock.Any(), []string{"platform"}, 1, "author1", reviewerID).
+					Return([]entity.User{{ID: "rev3", Team: entity.Team{Name: "platform"}}}, nil)
+				pr.EXPECT().ReassignReviewer(gomock.Any(), prID, reviewerID, "rev3", true).Return(nil)
+				pr.EXPECT().GetReviewersByPR(gomock.Any(), prID).Return([]entity.PRReviewer{{PRID: prID, ReviewerID: "rev3", IsFallback: true}}, nil)
+			},
+			expectedID: "rev3",
+		},
+		{
+			name: "kept on merged PR",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), prID).
+					Return(entity.PullRequest{ID: prID, Status: mergedStatus, AuthorID: "author1", Reviewers: []string{reviewerID}}, nil)
+			},
+		},
+		{
+			name: "PR not found",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{}, repository.ErrPRNotFound)
+			},
+			expectedErr: service.ErrPRNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			uRepo := mocks.NewMockUserRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
+			history := mocks.NewMockHistoryRepo(ctrl)
+			history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			svc := service.New(prRepo, uRepo, nil, history, nil, tx, tt.opts...)
+
+			tt.setup(prRepo, uRepo)
+
+			newReviewerID, err := svc.HandOverReview(ctx, prID, reviewerID, "moved to team frontend", tt.otherTeams)
 			if !errors.Is(err, tt.expectedErr) {
 				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
 			}
+			if newReviewerID != tt.expectedID {
+				t.Fatalf("expected new reviewer %q, got %q", tt.expectedID, newReviewerID)
+			}
 		})
 	}
 }
@@ -356,6 +651,7 @@ func TestService_MergePR(t *testing.T) {
         {
             name: "PR not found",
             setup: func(pr *mocks.MockPRRepo) {
+                pr.EXPECT().LockByID(gomock.Any(), prID).Return(nil)
                 pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{}, repository.ErrPRNotFound)
             },
             expectedErr: service.ErrPRNotFound,
@@ -363,13 +659,23 @@ func TestService_MergePR(t *testing.T) {
         {
             name: "PR already merged",
             setup: func(pr *mocks.MockPRRepo) {
+                pr.EXPECT().LockByID(gomock.Any(), prID).Return(nil)
                 pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: mergedStatus}, nil)
             },
             expectedErr: nil,
         },
+        {
+            name: "PR closed before the lock",
+            setup: func(pr *mocks.MockPRRepo) {
+                pr.EXPECT().LockByID(gomock.Any(), prID).Return(nil)
+                pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: entity.Status{ID: 3, Name: entity.StatusCLOSED}}, nil)
+            },
+            expectedErr: service.ErrInvalidPRState,
+        },
         {
             name: "GetPRStatuses fails",
             setup: func(pr *mocks.MockPRRepo) {
+                pr.EXPECT().LockByID(gomock.Any(), prID).Return(nil)
                 pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus}, nil)
                 pr.EXPECT().GetPRStatuses(gomock.Any()).Return(nil, errors.New("db"))
             },
@@ -378,19 +684,24 @@ func TestService_MergePR(t *testing.T) {
         {
             name: "UpdateStatus fails",
             setup: func(pr *mocks.MockPRRepo) {
+                pr.EXPECT().LockByID(gomock.Any(), prID).Return(nil)
                 pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus}, nil)
                 pr.EXPECT().GetPRStatuses(gomock.Any()).Return([]entity.Status{mergedStatus}, nil)
                 pr.EXPECT().UpdateStatus(gomock.Any(), prID, mergedStatus.ID, gomock.Any()).Return(errors.New("db"))
             },
-            expectedErr: service.ErrCannotCreatePR,
+            expectedErr: service.ErrCannotMergePR,
         },
         {
             name: "success",
             setup: func(pr *mocks.MockPRRepo) {
-                pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus}, nil)
-                pr.EXPECT().GetPRStatuses(gomock.Any()).Return([]entity.Status{mergedStatus}, nil)
-                pr.EXPECT().UpdateStatus(gomock.Any(), prID, mergedStatus.ID, gomock.Any()).Return(nil)
-                pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: mergedStatus}, nil)
+                // PR is read and checked only after it is locked
+                gomock.InOrder(
+                    pr.EXPECT().LockByID(gomock.Any(), prID).Return(nil),
+                    pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: openStatus}, nil),
+                    pr.EXPECT().GetPRStatuses(gomock.Any()).Return([]entity.Status{mergedStatus}, nil),
+                    pr.EXPECT().UpdateStatus(gomock.Any(), prID, mergedStatus.ID, gomock.Any()).Return(nil),
+                    pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: mergedStatus}, nil),
+                )
             },
             expectedErr: nil,
         },
@@ -403,12 +714,16 @@ func TestService_MergePR(t *testing.T) {
 
             prRepo := mocks.NewMockPRRepo(ctrl)
             uRepo := mocks.NewMockUserRepo(ctrl)
+            tx := mock_transactor.NewMockTransactor(ctrl)
+            tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+                func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+            ).AnyTimes()
 
-            svc := service.New(prRepo, uRepo, nil)
+            svc := service.New(prRepo, uRepo, nil, nil, nil, tx)
 
             tt.setup(prRepo)
 
-            _, err := svc.MergePR(ctx, prID)
+            _, err := svc.MergePR(ctx, prID, false)
             if !errors.Is(err, tt.expectedErr) {
                 t.Fatalf("expected %v, got %v", tt.expectedErr, err)
             }
@@ -423,9 +738,13 @@ func TestService_AssignReviewer(t *testing.T) {
 
     openPR := entity.PullRequest{
         ID:        prID,
+        AuthorID:  "author1",
+        Status:    entity.Status{Name: entity.StatusOPEN},
         NeedMoreReviewers: true,
         Reviewers: []string{"rev1"},
     }
+    author := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New()}}
+    bigTeamAuthor := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New(), RequiredReviewers: 3}}
 
     tests := []struct {
         name        string
@@ -443,14 +762,14 @@ func TestService_AssignReviewer(t *testing.T) {
             expectedErr: service.ErrPRNotFound,
         },
         {
-            name: "PR already has 2 reviewers",
+            name: "PR already has enough reviewers",
             setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, tx *mock_transactor.MockTransactor) {
                 tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
                     func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
                 )
-                pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, NeedMoreReviewers: false}, nil)
+                pr.EXPECT().GetByID(gomock.Any(), prID).Return(entity.PullRequest{ID: prID, Status: entity.Status{Name: entity.StatusOPEN}, NeedMoreReviewers: false}, nil)
             },
-            expectedErr: service.ErrPRAlreadyHas2Reviewers,
+            expectedErr: service.ErrPRHasEnoughReviewers,
         },
         {
             name: "AssignReviewer fails ErrReviewerNotFound",
@@ -482,6 +801,7 @@ func TestService_AssignReviewer(t *testing.T) {
                 )
                 pr.EXPECT().GetByID(gomock.Any(), prID).Return(openPR, nil)
                 pr.EXPECT().AssignReviewer(gomock.Any(), prID, newReviewerID).Return(nil)
+                u.EXPECT().GetByID(gomock.Any(), "author1").Return(author, nil)
                 pr.EXPECT().UpdateNeedMoreReviewers(gomock.Any(), prID).Return(errors.New("db"))
             },
             expectedErr: service.ErrCannotAssignReviewer,
@@ -494,11 +814,25 @@ func TestService_AssignReviewer(t *testing.T) {
                 )
                 pr.EXPECT().GetByID(gomock.Any(), prID).Return(openPR, nil)
                 pr.EXPECT().AssignReviewer(gomock.Any(), prID, newReviewerID).Return(nil)
+                u.EXPECT().GetByID(gomock.Any(), "author1").Return(author, nil)
                 pr.EXPECT().UpdateNeedMoreReviewers(gomock.Any(), prID).Return(nil)
                 pr.EXPECT().GetReviewersByPR(gomock.Any(), prID).Return([]entity.PRReviewer{{PRID: prID, ReviewerID: "rev1"}, {PRID: prID, ReviewerID: newReviewerID}}, nil)
             },
             expectedErr: nil,
         },
+        {
+            name: "success, team requires more reviewers (flag stays)",
+            setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, tx *mock_transactor.MockTransactor) {
+                tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+                    func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+                )
+                pr.EXPECT().GetByID(gomock.Any(), prID).Return(openPR, nil)
+                pr.EXPECT().AssignReviewer(gomock.Any(), prID, newReviewerID).Return(nil)
+                u.EXPECT().GetByID(gomock.Any(), "author1").Return(bigTeamAuthor, nil)
+                pr.EXPECT().GetReviewersByPR(gomock.Any(), prID).Return([]entity.PRReviewer{{PRID: prID, ReviewerID: "rev1"}, {PRID: prID, ReviewerID: newReviewerID}}, nil)
+            },
+            expectedErr: nil,
+        },
     }
 
     for _, tt := range tests {
@@ -509,8 +843,10 @@ func TestService_AssignReviewer(t *testing.T) {
             prRepo := mocks.NewMockPRRepo(ctrl)
             uRepo := mocks.NewMockUserRepo(ctrl)
             tx := mock_transactor.NewMockTransactor(ctrl)
+            history := mocks.NewMockHistoryRepo(ctrl)
+            history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
-            svc := service.New(prRepo, uRepo, tx)
+            svc := service.New(prRepo, uRepo, nil, history, nil, tx)
 
             tt.setup(prRepo, uRepo, tx)
 
@@ -521,3 +857,1946 @@ func TestService_AssignReviewer(t *testing.T) {
         })
     }
 }
+
+func TestService_LeastLoadedStrategy(t *testing.T) {
+	ctx := context.Background()
+
+	author := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New()}}
+	oldReviewer := entity.User{ID: "rev1", Team: author.Team}
+	openStatus := entity.Status{ID: 1, Name: entity.StatusOPEN}
+
+	t.Run("CreatePR picks least loaded teammates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prRepo := mocks.NewMockPRRepo(ctrl)
+		uRepo := mocks.NewMockUserRepo(ctrl)
+		tx := mock_transactor.NewMockTransactor(ctrl)
+		history := mocks.NewMockHistoryRepo(ctrl)
+		history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+		uRepo.EXPECT().GetByID(gomock.Any(), author.ID).Return(author, nil)
+		uRepo.EXPECT().GetLeastLoadedActiveTeammates(gomock.Any(), author.Team.ID, 2, author.ID).
+			Return([]entity.User{{ID: "r1"}, {ID: "r2"}}, nil)
+		prRepo.EXPECT().Create(gomock.Any(), "pr1", "title", author.ID, "OPEN", false).
+			Return(entity.PullRequest{ID: "pr1"}, nil)
+		prRepo.EXPECT().AssignReviewers(gomock.Any(), "pr1", []string{"r1", "r2"}).Return(nil)
+
+		svc := service.New(prRepo, uRepo, nil, history, nil, tx, service.WithStrategy(entity.StrategyLeastLoaded))
+
+		pr, err := svc.CreatePR(ctx, "pr1", "title", author.ID, nil, nil, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(pr.Reviewers) != 2 {
+			t.Fatalf("expected 2 reviewers, got %v", pr.Reviewers)
+		}
+	})
+
+	t.Run("ReassignReviewer picks least loaded teammate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prRepo := mocks.NewMockPRRepo(ctrl)
+		uRepo := mocks.NewMockUserRepo(ctrl)
+		tx := mock_transactor.NewMockTransactor(ctrl)
+		history := mocks.NewMockHistoryRepo(ctrl)
+		history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{ID: "pr1", Status: openStatus, AuthorID: author.ID, Reviewers: []string{oldReviewer.ID}}, nil)
+		uRepo.EXPECT().GetByID(gomock.Any(), oldReviewer.ID).Return(oldReviewer, nil)
+		uRepo.EXPECT().GetLeastLoadedActiveTeammates(gomock.Any(), author.Team.ID, 1, author.ID, oldReviewer.ID).
+			Return([]entity.User{{ID: "newRev"}}, nil)
+		prRepo.EXPECT().ReassignReviewer(gomock.Any(), "pr1", oldReviewer.ID, "newRev", false).Return(nil)
+		prRepo.EXPECT().GetReviewersByPR(gomock.Any(), "pr1").Return([]entity.PRReviewer{{PRID: "pr1", ReviewerID: "newRev"}}, nil)
+
+		svc := service.New(prRepo, uRepo, nil, history, nil, tx, service.WithStrategy(entity.StrategyLeastLoaded))
+
+		_, newReviewerID, err := svc.ReassignReviewer(ctx, "pr1", oldReviewer.ID, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if newReviewerID != "newRev" {
+			t.Fatalf("expected newRev, got %s", newReviewerID)
+		}
+	})
+}
+
+func TestService_ReviewerSelector(t *testing.T) {
+	ctx := context.Background()
+
+	author := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New(), Name: "backend"}}
+
+	tests := []struct {
+		name  string
+		opts  []service.Option
+		setup func(u *mocks.MockUserRepo)
+	}{
+		{
+			name: "default strategy is random",
+			setup: func(u *mocks.MockUserRepo) {
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 2, author.ID).
+					Return([]entity.User{{ID: "r1"}, {ID: "r2"}}, nil)
+			},
+		},
+		{
+			name: "round robin",
+			opts: []service.Option{service.WithStrategy(entity.StrategyRoundRobin)},
+			setup: func(u *mocks.MockUserRepo) {
+				u.EXPECT().GetRoundRobinActiveTeammates(gomock.Any(), author.Team.ID, 2, author.ID).
+					Return([]entity.User{{ID: "r1"}, {ID: "r2"}}, nil)
+			},
+		},
+		{
+			name: "weighted",
+			opts: []service.Option{service.WithStrategy(entity.StrategyWeighted)},
+			setup: func(u *mocks.MockUserRepo) {
+				u.EXPECT().GetWeightedActiveTeammates(gomock.Any(), author.Team.ID, 2, author.ID).
+					Return([]entity.User{{ID: "r1"}, {ID: "r2"}}, nil)
+			},
+		},
+		{
+			name: "team override wins over default",
+			opts: []service.Option{
+				service.WithStrategy(entity.StrategyRandom),
+				service.WithTeamStrategies(map[string]entity.ReviewerStrategy{"backend": entity.StrategyLeastLoaded}),
+			},
+			setup: func(u *mocks.MockUserRepo) {
+				u.EXPECT().GetLeastLoadedActiveTeammates(gomock.Any(), author.Team.ID, 2, author.ID).
+					Return([]entity.User{{ID: "r1"}, {ID: "r2"}}, nil)
+			},
+		},
+		{
+			name: "override for another team is ignored",
+			opts: []service.Option{
+				service.WithStrategy(entity.StrategyRoundRobin),
+				service.WithTeamStrategies(map[string]entity.ReviewerStrategy{"frontend": entity.StrategyLeastLoaded}),
+			},
+			setup: func(u *mocks.MockUserRepo) {
+				u.EXPECT().GetRoundRobinActiveTeammates(gomock.Any(), author.Team.ID, 2, author.ID).
+					Return([]entity.User{{ID: "r1"}, {ID: "r2"}}, nil)
+			},
+		},
+		{
+			name: "unknown strategy falls back to random",
+			opts: []service.Option{service.WithStrategy("unknown")},
+			setup: func(u *mocks.MockUserRepo) {
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 2, author.ID).
+					Return([]entity.User{{ID: "r1"}, {ID: "r2"}}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			uRepo := mocks.NewMockUserRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			history := mocks.NewMockHistoryRepo(ctrl)
+			history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
+			uRepo.EXPECT().GetByID(gomock.Any(), author.ID).Return(author, nil)
+			tt.setup(uRepo)
+			prRepo.EXPECT().Create(gomock.Any(), "pr1", "title", author.ID, "OPEN", false).
+				Return(entity.PullRequest{ID: "pr1"}, nil)
+			prRepo.EXPECT().AssignReviewers(gomock.Any(), "pr1", []string{"r1", "r2"}).Return(nil)
+
+			svc := service.New(prRepo, uRepo, nil, history, nil, tx, tt.opts...)
+
+			if _, err := svc.CreatePR(ctx, "pr1", "title", author.ID, nil, nil, false); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+type stubSelector struct {
+	users []entity.User
+}
+
+func (s *stubSelector) Select(_ context.Context, _ uuid.UUID, _ int, _ ...string) ([]entity.User, error) {
+	return s.users, nil
+}
+
+func TestService_CustomSelector(t *testing.T) {
+	ctx := context.Background()
+
+	author := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New(), Name: "backend"}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := mocks.NewMockPRRepo(ctrl)
+	uRepo := mocks.NewMockUserRepo(ctrl)
+	tx := mock_transactor.NewMockTransactor(ctrl)
+	history := mocks.NewMockHistoryRepo(ctrl)
+	history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+	)
+	uRepo.EXPECT().GetByID(gomock.Any(), author.ID).Return(author, nil)
+	prRepo.EXPECT().Create(gomock.Any(), "pr1", "title", author.ID, "OPEN", true).
+		Return(entity.PullRequest{ID: "pr1"}, nil)
+	prRepo.EXPECT().AssignReviewers(gomock.Any(), "pr1", []string{"lead"}).Return(nil)
+
+	svc := service.New(prRepo, uRepo, nil, history, nil, tx,
+		service.WithSelector("leads_only", &stubSelector{users: []entity.User{{ID: "lead"}}}),
+		service.WithTeamStrategies(map[string]entity.ReviewerStrategy{"backend": "leads_only"}),
+	)
+
+	pr, err := svc.CreatePR(ctx, "pr1", "title", author.ID, nil, nil, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pr.Reviewers) != 1 || pr.Reviewers[0] != "lead" {
+		t.Fatalf("expected [lead], got %v", pr.Reviewers)
+	}
+}
+
+func TestService_CreatePR_CodeOwners(t *testing.T) {
+	ctx := context.Background()
+
+	author := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New(), Name: "backend"}}
+	infraTeam := entity.Team{ID: uuid.New(), Name: "infra"}
+
+	goOwner := entity.CodeOwner{ID: uuid.New(), Pattern: "*.go", UserID: "owner1"}
+	docsOwner := entity.CodeOwner{ID: uuid.New(), Pattern: "/docs/", UserID: "owner2"}
+	infraOwner := entity.CodeOwner{ID: uuid.New(), Pattern: "deploy/**", TeamID: infraTeam.ID, TeamName: infraTeam.Name}
+
+	tests := []struct {
+		name         string
+		changedFiles []string
+		setup        func(u *mocks.MockUserRepo, c *mocks.MockCodeOwnerRepo)
+		want         []string
+	}{
+		{
+			name:         "user owner first, rest from author's team",
+			changedFiles: []string{"internal/service/pr/service.go"},
+			setup: func(u *mocks.MockUserRepo, c *mocks.MockCodeOwnerRepo) {
+				c.EXPECT().GetAll(gomock.Any()).Return([]entity.CodeOwner{goOwner, docsOwner}, nil)
+				u.EXPECT().GetAvailableByIDs(gomock.Any(), []string{"owner1"}).Return([]entity.User{{ID: "owner1"}}, nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 1, author.ID, "owner1").
+					Return([]entity.User{{ID: "r1"}}, nil)
+			},
+			want: []string{"owner1", "r1"},
+		},
+		{
+			name:         "owners cover all required reviewers",
+			changedFiles: []string{"docs/api.md", "main.go"},
+			setup: func(u *mocks.MockUserRepo, c *mocks.MockCodeOwnerRepo) {
+				c.EXPECT().GetAll(gomock.Any()).Return([]entity.CodeOwner{goOwner, docsOwner}, nil)
+				u.EXPECT().GetAvailableByIDs(gomock.Any(), []string{"owner2", "owner1"}).
+					Return([]entity.User{{ID: "owner1"}, {ID: "owner2"}}, nil)
+			},
+			want: []string{"owner2", "owner1"},
+		},
+		{
+			name:         "last matching rule wins, team owner resolved to a member",
+			changedFiles: []string{"deploy/helm/values.go"},
+			setup: func(u *mocks.MockUserRepo, c *mocks.MockCodeOwnerRepo) {
+				c.EXPECT().GetAll(gomock.Any()).Return([]entity.CodeOwner{goOwner, infraOwner}, nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), infraTeam.ID, 1, author.ID).
+					Return([]entity.User{{ID: "infra1"}}, nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 1, author.ID, "infra1").
+					Return([]entity.User{{ID: "r1"}}, nil)
+			},
+			want: []string{"infra1", "r1"},
+		},
+		{
+			name:         "unavailable owner is skipped",
+			changedFiles: []string{"main.go"},
+			setup: func(u *mocks.MockUserRepo, c *mocks.MockCodeOwnerRepo) {
+				c.EXPECT().GetAll(gomock.Any()).Return([]entity.CodeOwner{goOwner}, nil)
+				u.EXPECT().GetAvailableByIDs(gomock.Any(), []string{"owner1"}).Return(nil, nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 2, author.ID).
+					Return([]entity.User{{ID: "r1"}, {ID: "r2"}}, nil)
+			},
+			want: []string{"r1", "r2"},
+		},
+		{
+			name:         "no matching rules",
+			changedFiles: []string{"README.md"},
+			setup: func(u *mocks.MockUserRepo, c *mocks.MockCodeOwnerRepo) {
+				c.EXPECT().GetAll(gomock.Any()).Return([]entity.CodeOwner{goOwner, docsOwner}, nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 2, author.ID).
+					Return([]entity.User{{ID: "r1"}, {ID: "r2"}}, nil)
+			},
+			want: []string{"r1", "r2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			uRepo := mocks.NewMockUserRepo(ctrl)
+			cRepo := mocks.NewMockCodeOwnerRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			history := mocks.NewMockHistoryRepo(ctrl)
+			history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
+			uRepo.EXPECT().GetByID(gomock.Any(), author.ID).Return(author, nil)
+			tt.setup(uRepo, cRepo)
+			prRepo.EXPECT().Create(gomock.Any(), "pr1", "title", author.ID, "OPEN", false).
+				Return(entity.PullRequest{ID: "pr1"}, nil)
+			prRepo.EXPECT().AssignReviewers(gomock.Any(), "pr1", tt.want).Return(nil)
+
+			svc := service.New(prRepo, uRepo, cRepo, history, nil, tx)
+
+			pr, err := svc.CreatePR(ctx, "pr1", "title", author.ID, tt.changedFiles, nil, false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(pr.Reviewers, tt.want) {
+				t.Fatalf("expected reviewers %v, got %v", tt.want, pr.Reviewers)
+			}
+		})
+	}
+}
+
+func TestService_CreatePR_Labels(t *testing.T) {
+	ctx := context.Background()
+
+	author := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New(), Name: "backend"}}
+
+	tests := []struct {
+		name   string
+		labels []string
+		setup  func(u *mocks.MockUserRepo, p *mocks.MockPRRepo)
+		want   []string
+	}{
+		{
+			name:   "tag-matched teammates fill all places",
+			labels: []string{" Postgres", "go", "go"},
+			setup: func(u *mocks.MockUserRepo, p *mocks.MockPRRepo) {
+				u.EXPECT().GetTagMatchedActiveTeammates(gomock.Any(), author.Team.ID, []string{"postgres", "go"}, 2, author.ID).
+					Return([]entity.User{{ID: "dba"}, {ID: "gopher"}}, nil)
+				p.EXPECT().AddLabels(gomock.Any(), "pr1", []string{"postgres", "go"}).Return(nil)
+			},
+			want: []string{"dba", "gopher"},
+		},
+		{
+			name:   "rest is filled by strategy",
+			labels: []string{"postgres"},
+			setup: func(u *mocks.MockUserRepo, p *mocks.MockPRRepo) {
+				u.EXPECT().GetTagMatchedActiveTeammates(gomock.Any(), author.Team.ID, []string{"postgres"}, 2, author.ID).
+					Return([]entity.User{{ID: "dba"}}, nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 1, author.ID, "dba").
+					Return([]entity.User{{ID: "r1"}}, nil)
+				p.EXPECT().AddLabels(gomock.Any(), "pr1", []string{"postgres"}).Return(nil)
+			},
+			want: []string{"dba", "r1"},
+		},
+		{
+			name:   "blank labels are ignored",
+			labels: []string{" ", ""},
+			setup: func(u *mocks.MockUserRepo, p *mocks.MockPRRepo) {
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 2, author.ID).
+					Return([]entity.User{{ID: "r1"}, {ID: "r2"}}, nil)
+			},
+			want: []string{"r1", "r2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			uRepo := mocks.NewMockUserRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			history := mocks.NewMockHistoryRepo(ctrl)
+			history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
+			uRepo.EXPECT().GetByID(gomock.Any(), author.ID).Return(author, nil)
+			prRepo.EXPECT().Create(gomock.Any(), "pr1", "title", author.ID, "OPEN", false).
+				Return(entity.PullRequest{ID: "pr1"}, nil)
+			tt.setup(uRepo, prRepo)
+			prRepo.EXPECT().AssignReviewers(gomock.Any(), "pr1", tt.want).Return(nil)
+
+			svc := service.New(prRepo, uRepo, nil, history, nil, tx)
+
+			pr, err := svc.CreatePR(ctx, "pr1", "title", author.ID, nil, tt.labels, false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(pr.Reviewers, tt.want) {
+				t.Fatalf("expected reviewers %v, got %v", tt.want, pr.Reviewers)
+			}
+		})
+	}
+}
+
+func TestService_CreatePR_BuddyTeams(t *testing.T) {
+	ctx := context.Background()
+
+	author := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New(), Name: "mobile"}}
+	buddyTeams := map[string][]string{"mobile": {"frontend", "design"}}
+
+	tests := []struct {
+		name              string
+		buddyTeams        map[string][]string
+		setup             func(u *mocks.MockUserRepo, p *mocks.MockPRRepo)
+		needMoreReviewers bool
+		wantReviewers     []string
+		wantFallback      []string
+	}{
+		{
+			name:       "missing reviewer is taken from buddy team",
+			buddyTeams: buddyTeams,
+			setup: func(u *mocks.MockUserRepo, p *mocks.MockPRRepo) {
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 2, author.ID).
+					Return([]entity.User{{ID: "r1"}}, nil)
+				u.EXPECT().GetRandomActiveUsersByTeams(gomock.Any(), []string{"frontend", "design"}, 1, author.ID, "r1").
+					Return([]entity.User{{ID: "fe1"}}, nil)
+				p.EXPECT().AssignReviewers(gomock.Any(), "pr1", []string{"r1"}).Return(nil)
+				p.EXPECT().AssignFallbackReviewers(gomock.Any(), "pr1", []string{"fe1"}).Return(nil)
+			},
+			needMoreReviewers: false,
+			wantReviewers:     []string{"r1", "fe1"},
+			wantFallback:      []string{"fe1"},
+		},
+		{
+			name:       "author is alone in the team",
+			buddyTeams: buddyTeams,
+			setup: func(u *mocks.MockUserRepo, p *mocks.MockPRRepo) {
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 2, author.ID).Return(nil, nil)
+				u.EXPECT().GetRandomActiveUsersByTeams(gomock.Any(), []string{"frontend", "design"}, 2, author.ID).
+					Return([]entity.User{{ID: "fe1"}}, nil)
+				p.EXPECT().AssignFallbackReviewers(gomock.Any(), "pr1", []string{"fe1"}).Return(nil)
+			},
+			needMoreReviewers: true,
+			wantReviewers:     []string{"fe1"},
+			wantFallback:      []string{"fe1"},
+		},
+		{
+			name:       "fallback is not configured",
+			buddyTeams: nil,
+			setup: func(u *mocks.MockUserRepo, p *mocks.MockPRRepo) {
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 2, author.ID).
+					Return([]entity.User{{ID: "r1"}}, nil)
+				p.EXPECT().AssignReviewers(gomock.Any(), "pr1", []string{"r1"}).Return(nil)
+			},
+			needMoreReviewers: true,
+			wantReviewers:     []string{"r1"},
+			wantFallback:      nil,
+		},
+		{
+			name:       "team has enough reviewers",
+			buddyTeams: buddyTeams,
+			setup: func(u *mocks.MockUserRepo, p *mocks.MockPRRepo) {
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 2, author.ID).
+					Return([]entity.User{{ID: "r1"}, {ID: "r2"}}, nil)
+				p.EXPECT().AssignReviewers(gomock.Any(), "pr1", []string{"r1", "r2"}).Return(nil)
+			},
+			needMoreReviewers: false,
+			wantReviewers:     []string{"r1", "r2"},
+			wantFallback:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			uRepo := mocks.NewMockUserRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			history := mocks.NewMockHistoryRepo(ctrl)
+			history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
+			uRepo.EXPECT().GetByID(gomock.Any(), author.ID).Return(author, nil)
+			prRepo.EXPECT().Create(gomock.Any(), "pr1", "title", author.ID, "OPEN", tt.needMoreReviewers).
+				Return(entity.PullRequest{ID: "pr1"}, nil)
+			tt.setup(uRepo, prRepo)
+
+			svc := service.New(prRepo, uRepo, nil, history, nil, tx, service.WithBuddyTeams(tt.buddyTeams))
+
+			pr, err := svc.CreatePR(ctx, "pr1", "title", author.ID, nil, nil, false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(pr.Reviewers, tt.wantReviewers) {
+				t.Fatalf("expected reviewers %v, got %v", tt.wantReviewers, pr.Reviewers)
+			}
+			if !reflect.DeepEqual(pr.FallbackReviewers, tt.wantFallback) {
+				t.Fatalf("expected fallback reviewers %v, got %v", tt.wantFallback, pr.FallbackReviewers)
+			}
+		})
+	}
+}
+
+func TestService_ReassignFallbackReviewer(t *testing.T) {
+	ctx := context.Background()
+
+	author := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New(), Name: "mobile"}}
+	buddy := entity.Team{ID: uuid.New(), Name: "frontend"}
+	pr := entity.PullRequest{
+		ID:                "pr1",
+		AuthorID:          author.ID,
+		Status:            entity.Status{Name: entity.StatusOPEN},
+		Reviewers:         []string{"fe1"},
+		FallbackReviewers: []string{"fe1"},
+	}
+
+	tests := []struct {
+		name          string
+		newReviewerID string
+		setup         func(u *mocks.MockUserRepo, p *mocks.MockPRRepo)
+	}{
+		{
+			name:          "teammate of the author is not fallback",
+			newReviewerID: "mate",
+			setup: func(u *mocks.MockUserRepo, p *mocks.MockPRRepo) {
+				u.EXPECT().GetByID(gomock.Any(), "mate").Return(entity.User{ID: "mate", IsActive: true, Team: author.Team}, nil)
+				p.EXPECT().ReassignReviewer(gomock.Any(), "pr1", "fe1", "mate", false).Return(nil)
+			},
+		},
+		{
+			name: "teammate of the fallback reviewer stays fallback",
+			setup: func(u *mocks.MockUserRepo, p *mocks.MockPRRepo) {
+				u.EXPECT().GetByID(gomock.Any(), "fe1").Return(entity.User{ID: "fe1", Team: buddy}, nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), buddy.ID, 1, author.ID, "fe1").
+					Return([]entity.User{{ID: "fe2"}}, nil)
+				p.EXPECT().ReassignReviewer(gomock.Any(), "pr1", "fe1", "fe2", true).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			uRepo := mocks.NewMockUserRepo(ctrl)
+			history := mocks.NewMockHistoryRepo(ctrl)
+			history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
+
+			prRepo.EXPECT().GetByID(gomock.Any(), "pr1").Return(pr, nil)
+			uRepo.EXPECT().GetByID(gomock.Any(), author.ID).Return(author, nil)
+			prRepo.EXPECT().GetReviewersByPR(gomock.Any(), "pr1").Return(nil, nil)
+			tt.setup(uRepo, prRepo)
+
+			svc := service.New(prRepo, uRepo, nil, history, nil, tx,
+				service.WithBuddyTeams(map[string][]string{"mobile": {"frontend"}}))
+
+			if _, _, err := svc.ReassignReviewer(ctx, "pr1", "fe1", tt.newReviewerID); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestService_AssignmentHistory(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), "alice")
+
+	author := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New(), Name: "backend", RequiredReviewers: 2}}
+	openStatus := entity.Status{ID: 1, Name: entity.StatusOPEN}
+
+	t.Run("create records assign events with reasons", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prRepo := mocks.NewMockPRRepo(ctrl)
+		uRepo := mocks.NewMockUserRepo(ctrl)
+		cRepo := mocks.NewMockCodeOwnerRepo(ctrl)
+		history := mocks.NewMockHistoryRepo(ctrl)
+		tx := mock_transactor.NewMockTransactor(ctrl)
+
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+		uRepo.EXPECT().GetByID(gomock.Any(), author.ID).Return(author, nil)
+		cRepo.EXPECT().GetAll(gomock.Any()).Return([]entity.CodeOwner{{Pattern: "*.go", UserID: "owner"}}, nil)
+		uRepo.EXPECT().GetAvailableByIDs(gomock.Any(), []string{"owner"}).Return([]entity.User{{ID: "owner"}}, nil)
+		uRepo.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 1, author.ID, "owner").
+			Return([]entity.User{{ID: "mate"}}, nil)
+		prRepo.EXPECT().Create(gomock.Any(), "pr1", "title", author.ID, "OPEN", false).
+			Return(entity.PullRequest{ID: "pr1"}, nil)
+		prRepo.EXPECT().AssignReviewers(gomock.Any(), "pr1", []string{"owner", "mate"}).Return(nil)
+		history.EXPECT().Create(gomock.Any(), []entity.AssignmentEvent{
+			{PRID: "pr1", Type: entity.AssignmentEventAssign, ReviewerID: "owner", Actor: "alice", Reason: "code owner of changed files"},
+			{PRID: "pr1", Type: entity.AssignmentEventAssign, ReviewerID: "mate", Actor: "alice", Reason: "assigned on PR creation"},
+		}).Return(nil)
+
+		svc := service.New(prRepo, uRepo, cRepo, history, nil, tx)
+
+		if _, err := svc.CreatePR(ctx, "pr1", "title", author.ID, []string{"main.go"}, nil, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("reassign records previous reviewer", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prRepo := mocks.NewMockPRRepo(ctrl)
+		uRepo := mocks.NewMockUserRepo(ctrl)
+		history := mocks.NewMockHistoryRepo(ctrl)
+		tx := mock_transactor.NewMockTransactor(ctrl)
+
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{ID: "pr1", Status: openStatus, AuthorID: author.ID, Reviewers: []string{"old"}}, nil)
+		uRepo.EXPECT().GetByID(gomock.Any(), "old").Return(entity.User{ID: "old", Team: author.Team}, nil)
+		uRepo.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 1, author.ID, "old").
+			Return([]entity.User{{ID: "new"}}, nil)
+		prRepo.EXPECT().ReassignReviewer(gomock.Any(), "pr1", "old", "new", false).Return(nil)
+		history.EXPECT().Create(gomock.Any(), []entity.AssignmentEvent{
+			{PRID: "pr1", Type: entity.AssignmentEventReassign, ReviewerID: "new", PreviousReviewerID: "old", Actor: "alice", Reason: "manual reassignment"},
+		}).Return(nil)
+		prRepo.EXPECT().GetReviewersByPR(gomock.Any(), "pr1").Return([]entity.PRReviewer{{PRID: "pr1", ReviewerID: "new"}}, nil)
+
+		svc := service.New(prRepo, uRepo, nil, history, nil, tx)
+
+		if _, _, err := svc.ReassignReviewer(ctx, "pr1", "old", ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("history of unknown PR", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prRepo := mocks.NewMockPRRepo(ctrl)
+		tx := mock_transactor.NewMockTransactor(ctrl)
+
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+		prRepo.EXPECT().GetByID(gomock.Any(), "missing").Return(entity.PullRequest{}, repository.ErrPRNotFound)
+
+		svc := service.New(prRepo, nil, nil, nil, nil, tx)
+
+		if _, err := svc.GetHistory(ctx, "missing"); !errors.Is(err, service.ErrPRNotFound) {
+			t.Fatalf("expected %v, got %v", service.ErrPRNotFound, err)
+		}
+	})
+}
+
+func TestService_SubmitReview(t *testing.T) {
+	ctx := context.Background()
+
+	openPR := entity.PullRequest{ID: "pr1", Status: entity.Status{Name: entity.StatusOPEN}, Reviewers: []string{"u1", "u2"}}
+	mergedPR := entity.PullRequest{ID: "pr1", Status: entity.Status{Name: entity.StatusMERGED}, Reviewers: []string{"u1", "u2"}}
+
+	tests := []struct {
+		name        string
+		reviewerID  string
+		state       entity.ReviewState
+		setup       func(pr *mocks.MockPRRepo, r *mocks.MockReviewRepo)
+		expectedErr error
+	}{
+		{
+			name:        "invalid state",
+			reviewerID:  "u1",
+			state:       "LGTM",
+			setup:       func(pr *mocks.MockPRRepo, r *mocks.MockReviewRepo) {},
+			expectedErr: service.ErrInvalidReviewState,
+		},
+		{
+			name:       "PR not found",
+			reviewerID: "u1",
+			state:      entity.ReviewApproved,
+			setup: func(pr *mocks.MockPRRepo, r *mocks.MockReviewRepo) {
+				pr.EXPECT().LockByID(gomock.Any(), "pr1").Return(nil)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{}, repository.ErrPRNotFound)
+			},
+			expectedErr: service.ErrPRNotFound,
+		},
+		{
+			name:       "merged PR",
+			reviewerID: "u1",
+			state:      entity.ReviewApproved,
+			setup: func(pr *mocks.MockPRRepo, r *mocks.MockReviewRepo) {
+				pr.EXPECT().LockByID(gomock.Any(), "pr1").Return(nil)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(mergedPR, nil)
+			},
+			expectedErr: service.ErrPRMerged,
+		},
+		{
+			name:       "reviewer not assigned",
+			reviewerID: "u3",
+			state:      entity.ReviewApproved,
+			setup: func(pr *mocks.MockPRRepo, r *mocks.MockReviewRepo) {
+				pr.EXPECT().LockByID(gomock.Any(), "pr1").Return(nil)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(openPR, nil)
+			},
+			expectedErr: service.ErrReviewerNotAssigned,
+		},
+		{
+			name:       "repo error",
+			reviewerID: "u1",
+			state:      entity.ReviewChangesRequested,
+			setup: func(pr *mocks.MockPRRepo, r *mocks.MockReviewRepo) {
+				pr.EXPECT().LockByID(gomock.Any(), "pr1").Return(nil)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(openPR, nil)
+				r.EXPECT().Create(gomock.Any(), "pr1", "u1", entity.ReviewChangesRequested, "fix it").
+					Return(entity.Review{}, errors.New("db"))
+			},
+			expectedErr: service.ErrCannotSubmitReview,
+		},
+		{
+			name:       "success",
+			reviewerID: "u1",
+			state:      entity.ReviewChangesRequested,
+			setup: func(pr *mocks.MockPRRepo, r *mocks.MockReviewRepo) {
+				pr.EXPECT().LockByID(gomock.Any(), "pr1").Return(nil)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(openPR, nil)
+				r.EXPECT().Create(gomock.Any(), "pr1", "u1", entity.ReviewChangesRequested, "fix it").
+					Return(entity.Review{ID: uuid.New(), PRID: "pr1", ReviewerID: "u1", State: entity.ReviewChangesRequested}, nil)
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			r := mocks.NewMockReviewRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			).AnyTimes()
+
+			tt.setup(prRepo, r)
+
+			svc := service.New(prRepo, nil, nil, nil, r, tx)
+
+			_, err := svc.SubmitReview(ctx, "pr1", tt.reviewerID, tt.state, "fix it")
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestService_MergePR_Policy(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), "admin")
+
+	teamID := uuid.New()
+	openStatus := entity.Status{ID: 1, Name: entity.StatusOPEN}
+	mergedStatus := entity.Status{ID: 2, Name: entity.StatusMERGED}
+	openPR := entity.PullRequest{ID: "pr1", AuthorID: "author", Status: openStatus, Reviewers: []string{"u1", "u2"}}
+
+	policy := entity.MergePolicy{MinApprovals: 1, BlockOnChangesRequested: true, RequireTeamApproval: true}
+
+	tests := []struct {
+		name      string
+		force     bool
+		reviews   []entity.Review
+		setup     func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo)
+		wantUnmet []entity.MergeCondition
+	}{
+		{
+			name:      "no reviews",
+			reviews:   nil,
+			setup:     func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo) {},
+			wantUnmet: []entity.MergeCondition{entity.MergeConditionMinApprovals, entity.MergeConditionTeamApproval},
+		},
+		{
+			name: "changes requested and approver from other team",
+			reviews: []entity.Review{
+				{PRID: "pr1", ReviewerID: "u1", State: entity.ReviewApproved},
+				{PRID: "pr1", ReviewerID: "u2", State: entity.ReviewChangesRequested},
+			},
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo) {
+				u.EXPECT().GetByID(gomock.Any(), "author").Return(entity.User{ID: "author", Team: entity.Team{ID: teamID}}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u1").Return(entity.User{ID: "u1", Team: entity.Team{ID: uuid.New()}}, nil)
+			},
+			wantUnmet: []entity.MergeCondition{entity.MergeConditionNoChangesRequested, entity.MergeConditionTeamApproval},
+		},
+		{
+			name: "approval of removed reviewer is ignored",
+			reviews: []entity.Review{
+				{PRID: "pr1", ReviewerID: "old", State: entity.ReviewApproved},
+			},
+			setup:     func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo) {},
+			wantUnmet: []entity.MergeCondition{entity.MergeConditionMinApprovals, entity.MergeConditionTeamApproval},
+		},
+		{
+			name: "policy satisfied",
+			reviews: []entity.Review{
+				{PRID: "pr1", ReviewerID: "u1", State: entity.ReviewApproved},
+			},
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo) {
+				u.EXPECT().GetByID(gomock.Any(), "author").Return(entity.User{ID: "author", Team: entity.Team{ID: teamID}}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u1").Return(entity.User{ID: "u1", Team: entity.Team{ID: teamID}}, nil)
+				pr.EXPECT().GetPRStatuses(gomock.Any()).Return([]entity.Status{mergedStatus}, nil)
+				pr.EXPECT().UpdateStatus(gomock.Any(), "pr1", mergedStatus.ID, gomock.Any()).Return(nil)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{ID: "pr1", Status: mergedStatus}, nil)
+			},
+			wantUnmet: nil,
+		},
+		{
+			name:    "forced merge is audited",
+			force:   true,
+			reviews: nil,
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo) {
+				pr.EXPECT().GetPRStatuses(gomock.Any()).Return([]entity.Status{mergedStatus}, nil)
+				pr.EXPECT().AddMergeOverride(gomock.Any(), "pr1", "admin", []string{"MIN_APPROVALS", "TEAM_APPROVAL"}).Return(nil)
+				pr.EXPECT().UpdateStatus(gomock.Any(), "pr1", mergedStatus.ID, gomock.Any()).Return(nil)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{ID: "pr1", Status: mergedStatus}, nil)
+			},
+			wantUnmet: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			uRepo := mocks.NewMockUserRepo(ctrl)
+			r := mocks.NewMockReviewRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			).AnyTimes()
+
+			prRepo.EXPECT().LockByID(gomock.Any(), "pr1").Return(nil)
+			prRepo.EXPECT().GetByID(gomock.Any(), "pr1").Return(openPR, nil)
+			r.EXPECT().GetLatestByPRs(gomock.Any(), []string{"pr1"}).Return(tt.reviews, nil)
+			tt.setup(prRepo, uRepo)
+
+			svc := service.New(prRepo, uRepo, nil, nil, r, tx, service.WithMergePolicy(policy))
+
+			_, err := svc.MergePR(ctx, "pr1", tt.force)
+
+			if tt.wantUnmet == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var blockedErr *service.MergeBlockedError
+			if !errors.As(err, &blockedErr) || !errors.Is(err, service.ErrMergeBlocked) {
+				t.Fatalf("expected merge blocked error, got %v", err)
+			}
+			if !reflect.DeepEqual(blockedErr.Unmet, tt.wantUnmet) {
+				t.Fatalf("expected unmet %v, got %v", tt.wantUnmet, blockedErr.Unmet)
+			}
+		})
+	}
+}
+
+func TestService_PRLifecycle(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), "alice")
+
+	author := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New(), Name: "backend", RequiredReviewers: 2}}
+	statuses := []entity.Status{
+		{ID: 0, Name: entity.StatusOPEN},
+		{ID: 1, Name: entity.StatusMERGED},
+		{ID: 2, Name: entity.StatusDRAFT},
+		{ID: 3, Name: entity.StatusCLOSED},
+	}
+
+	newTx := func(ctrl *gomock.Controller) *mock_transactor.MockTransactor {
+		tx := mock_transactor.NewMockTransactor(ctrl)
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		).AnyTimes()
+		return tx
+	}
+
+	t.Run("draft PR is created without reviewers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prRepo := mocks.NewMockPRRepo(ctrl)
+		uRepo := mocks.NewMockUserRepo(ctrl)
+		history := mocks.NewMockHistoryRepo(ctrl)
+
+		uRepo.EXPECT().GetByID(gomock.Any(), author.ID).Return(author, nil)
+		prRepo.EXPECT().Create(gomock.Any(), "pr1", "title", author.ID, "DRAFT", false).
+			Return(entity.PullRequest{ID: "pr1", Status: statuses[2]}, nil)
+
+		svc := service.New(prRepo, uRepo, nil, history, nil, newTx(ctrl))
+
+		pr, err := svc.CreatePR(ctx, "pr1", "title", author.ID, nil, nil, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(pr.Reviewers) != 0 {
+			t.Fatalf("expected no reviewers, got %v", pr.Reviewers)
+		}
+	})
+
+	t.Run("ready assigns reviewers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prRepo := mocks.NewMockPRRepo(ctrl)
+		uRepo := mocks.NewMockUserRepo(ctrl)
+		history := mocks.NewMockHistoryRepo(ctrl)
+
+		prRepo.EXPECT().LockByID(gomock.Any(), "pr1").Return(nil)
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr1").
+			Return(entity.PullRequest{ID: "pr1", AuthorID: author.ID, Status: statuses[2]}, nil)
+		prRepo.EXPECT().GetPRStatuses(gomock.Any()).Return(statuses, nil)
+		uRepo.EXPECT().GetByID(gomock.Any(), author.ID).Return(author, nil)
+		uRepo.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 2, author.ID).
+			Return([]entity.User{{ID: "u1"}, {ID: "u2"}}, nil)
+		prRepo.EXPECT().SetStatus(gomock.Any(), "pr1", 0, nil).Return(nil)
+		prRepo.EXPECT().SetNeedMoreReviewers(gomock.Any(), "pr1", false).Return(nil)
+		prRepo.EXPECT().AssignReviewers(gomock.Any(), "pr1", []string{"u1", "u2"}).Return(nil)
+		history.EXPECT().Create(gomock.Any(), []entity.AssignmentEvent{
+			{PRID: "pr1", Type: entity.AssignmentEventAssign, ReviewerID: "u1", Actor: "alice", Reason: "assigned when PR marked ready"},
+			{PRID: "pr1", Type: entity.AssignmentEventAssign, ReviewerID: "u2", Actor: "alice", Reason: "assigned when PR marked ready"},
+		}).Return(nil)
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr1").
+			Return(entity.PullRequest{ID: "pr1", AuthorID: author.ID, Status: statuses[0], Reviewers: []string{"u1", "u2"}}, nil)
+
+		svc := service.New(prRepo, uRepo, nil, history, nil, newTx(ctrl))
+
+		pr, err := svc.MarkReady(ctx, "pr1", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pr.Status.Name != entity.StatusOPEN || !reflect.DeepEqual(pr.Reviewers, []string{"u1", "u2"}) {
+			t.Fatalf("unexpected PR: %+v", pr)
+		}
+	})
+
+	t.Run("close releases reviewers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prRepo := mocks.NewMockPRRepo(ctrl)
+		history := mocks.NewMockHistoryRepo(ctrl)
+
+		prRepo.EXPECT().LockByID(gomock.Any(), "pr1").Return(nil)
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr1").
+			Return(entity.PullRequest{ID: "pr1", Status: statuses[0], Reviewers: []string{"u1", "u2"}}, nil)
+		prRepo.EXPECT().GetPRStatuses(gomock.Any()).Return(statuses, nil)
+		prRepo.EXPECT().SetStatus(gomock.Any(), "pr1", 3, gomock.Not(gomock.Nil())).Return(nil)
+		prRepo.EXPECT().RemoveReviewers(gomock.Any(), "pr1").Return([]string{"u1", "u2"}, nil)
+		prRepo.EXPECT().SetNeedMoreReviewers(gomock.Any(), "pr1", false).Return(nil)
+		history.EXPECT().Create(gomock.Any(), []entity.AssignmentEvent{
+			{PRID: "pr1", Type: entity.AssignmentEventUnassign, PreviousReviewerID: "u1", Actor: "alice", Reason: "PR closed"},
+			{PRID: "pr1", Type: entity.AssignmentEventUnassign, PreviousReviewerID: "u2", Actor: "alice", Reason: "PR closed"},
+		}).Return(nil)
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr1").
+			Return(entity.PullRequest{ID: "pr1", Status: statuses[3]}, nil)
+
+		svc := service.New(prRepo, nil, nil, history, nil, newTx(ctrl))
+
+		pr, err := svc.ClosePR(ctx, "pr1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pr.Status.Name != entity.StatusCLOSED {
+			t.Fatalf("expected CLOSED, got %s", pr.Status.Name)
+		}
+	})
+
+	invalid := []struct {
+		name        string
+		status      entity.Status
+		call        func(svc *service.Service) error
+		expectedErr error
+	}{
+		{
+			name:   "reopen open PR",
+			status: statuses[0],
+			call:   func(svc *service.Service) error { _, err := svc.ReopenPR(ctx, "pr1", nil); return err },
+			expectedErr: service.ErrInvalidPRState,
+		},
+		{
+			name:   "ready closed PR",
+			status: statuses[3],
+			call:   func(svc *service.Service) error { _, err := svc.MarkReady(ctx, "pr1", nil); return err },
+			expectedErr: service.ErrInvalidPRState,
+		},
+		{
+			name:   "close merged PR",
+			status: statuses[1],
+			call:   func(svc *service.Service) error { _, err := svc.ClosePR(ctx, "pr1"); return err },
+			expectedErr: service.ErrPRMerged,
+		},
+		{
+			name:   "merge draft PR",
+			status: statuses[2],
+			call:   func(svc *service.Service) error { _, err := svc.MergePR(ctx, "pr1", false); return err },
+			expectedErr: service.ErrInvalidPRState,
+		},
+		{
+			name:        "assign reviewer to merged PR",
+			status:      statuses[1],
+			call:        func(svc *service.Service) error { _, err := svc.AssignReviewer(ctx, "pr1", "u3"); return err },
+			expectedErr: service.ErrPRMerged,
+		},
+		{
+			name:        "assign reviewer to draft PR",
+			status:      statuses[2],
+			call:        func(svc *service.Service) error { _, err := svc.AssignReviewer(ctx, "pr1", "u3"); return err },
+			expectedErr: service.ErrInvalidPRState,
+		},
+		{
+			name:        "reassign reviewer on closed PR",
+			status:      statuses[3],
+			call:        func(svc *service.Service) error { _, _, err := svc.ReassignReviewer(ctx, "pr1", "u1", ""); return err },
+			expectedErr: service.ErrInvalidPRState,
+		},
+		{
+			name:        "review draft PR",
+			status:      statuses[2],
+			call:        func(svc *service.Service) error { _, err := svc.SubmitReview(ctx, "pr1", "u1", entity.ReviewApproved, ""); return err },
+			expectedErr: service.ErrInvalidPRState,
+		},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			prRepo.EXPECT().LockByID(gomock.Any(), "pr1").Return(nil).AnyTimes()
+			prRepo.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{ID: "pr1", Status: tt.status}, nil)
+
+			svc := service.New(prRepo, nil, nil, nil, nil, newTx(ctrl))
+
+			if err := tt.call(svc); !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestService_UpdatePR(t *testing.T) {
+	ctx := context.Background()
+
+	openPR := entity.PullRequest{ID: "pr1", Title: "old", Status: entity.Status{Name: entity.StatusOPEN}}
+	mergedPR := entity.PullRequest{ID: "pr1", Title: "old", Status: entity.Status{Name: entity.StatusMERGED}}
+
+	tests := []struct {
+		name        string
+		title       *string
+		description *string
+		setup       func(pr *mocks.MockPRRepo, tx *mock_transactor.MockTransactor)
+		expected    entity.PullRequest
+		expectedErr error
+	}{
+		{
+			name:        "nothing to update",
+			setup:       func(pr *mocks.MockPRRepo, tx *mock_transactor.MockTransactor) {},
+			expectedErr: service.ErrNothingToUpdate,
+		},
+		{
+			name:        "blank title",
+			title:       lo.ToPtr("  "),
+			setup:       func(pr *mocks.MockPRRepo, tx *mock_transactor.MockTransactor) {},
+			expectedErr: service.ErrInvalidTitle,
+		},
+		{
+			name:  "PR not found",
+			title: lo.ToPtr("new"),
+			setup: func(pr *mocks.MockPRRepo, tx *mock_transactor.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{}, repository.ErrPRNotFound)
+			},
+			expectedErr: service.ErrPRNotFound,
+		},
+		{
+			name:  "merged PR",
+			title: lo.ToPtr("new"),
+			setup: func(pr *mocks.MockPRRepo, tx *mock_transactor.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(mergedPR, nil)
+			},
+			expectedErr: service.ErrPRMerged,
+		},
+		{
+			name:        "update title and description",
+			title:       lo.ToPtr(" new "),
+			description: lo.ToPtr("details"),
+			setup: func(pr *mocks.MockPRRepo, tx *mock_transactor.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(openPR, nil)
+				pr.EXPECT().UpdateTitle(gomock.Any(), "pr1", "new").Return(nil)
+				pr.EXPECT().UpdateDescription(gomock.Any(), "pr1", "details").Return(nil)
+			},
+			expected: entity.PullRequest{ID: "pr1", Title: "new", Description: "details", Status: openPR.Status},
+		},
+		{
+			name:        "update description only",
+			description: lo.ToPtr(""),
+			setup: func(pr *mocks.MockPRRepo, tx *mock_transactor.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(openPR, nil)
+				pr.EXPECT().UpdateDescription(gomock.Any(), "pr1", "").Return(nil)
+			},
+			expected: openPR,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			tt.setup(prRepo, tx)
+
+			svc := service.New(prRepo, nil, nil, nil, nil, tx)
+
+			pr, err := svc.UpdatePR(ctx, "pr1", tt.title, tt.description)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
+			}
+			if err == nil && !reflect.DeepEqual(pr, tt.expected) {
+				t.Fatalf("expected %+v, got %+v", tt.expected, pr)
+			}
+		})
+	}
+}
+
+func TestService_TransferAuthor(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), "alice")
+
+	team := entity.Team{ID: uuid.New(), Name: "backend"}
+	newAuthor := entity.User{ID: "u2", Team: team}
+	openStatus := entity.Status{Name: entity.StatusOPEN}
+
+	tests := []struct {
+		name        string
+		setup       func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo)
+		expectedErr error
+	}{
+		{
+			name: "new author not found",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{ID: "pr1", AuthorID: "u1", Status: openStatus}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u2").Return(entity.User{}, repository.ErrUserNotFound)
+			},
+			expectedErr: service.ErrAuthorNotFound,
+		},
+		{
+			name: "same author is no-op",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{ID: "pr1", AuthorID: "u2", Status: openStatus}, nil)
+			},
+		},
+		{
+			name: "new author is not a reviewer",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").
+					Return(entity.PullRequest{ID: "pr1", AuthorID: "u1", Status: openStatus, Reviewers: []string{"u3"}}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u2").Return(newAuthor, nil)
+				pr.EXPECT().UpdateAuthor(gomock.Any(), "pr1", "u2").Return(nil)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").
+					Return(entity.PullRequest{ID: "pr1", AuthorID: "u2", Status: openStatus, Reviewers: []string{"u3"}}, nil)
+			},
+		},
+		{
+			name: "new author reviewer is replaced by teammate",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").
+					Return(entity.PullRequest{ID: "pr1", AuthorID: "u1", Status: openStatus, Reviewers: []string{"u2", "u3"}}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u2").Return(newAuthor, nil)
+				pr.EXPECT().UpdateAuthor(gomock.Any(), "pr1", "u2").Return(nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), team.ID, 1, "u2", "u3").Return([]entity.User{{ID: "u4"}}, nil)
+				pr.EXPECT().ReassignReviewer(gomock.Any(), "pr1", "u2", "u4", false).Return(nil)
+				h.EXPECT().Create(gomock.Any(), []entity.AssignmentEvent{
+					{PRID: "pr1", Type: entity.AssignmentEventReassign, ReviewerID: "u4", PreviousReviewerID: "u2", Actor: "alice", Reason: "reviewer became PR author"},
+				}).Return(nil)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").
+					Return(entity.PullRequest{ID: "pr1", AuthorID: "u2", Status: openStatus, Reviewers: []string{"u3", "u4"}}, nil)
+			},
+		},
+		{
+			name: "new author reviewer is unassigned without candidates",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").
+					Return(entity.PullRequest{ID: "pr1", AuthorID: "u1", Status: openStatus, Reviewers: []string{"u2"}}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u2").Return(newAuthor, nil)
+				pr.EXPECT().UpdateAuthor(gomock.Any(), "pr1", "u2").Return(nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), team.ID, 1, "u2").Return(nil, nil)
+				pr.EXPECT().RemoveReviewer(gomock.Any(), "pr1", "u2").Return(nil)
+				pr.EXPECT().SetNeedMoreReviewers(gomock.Any(), "pr1", true).Return(nil)
+				h.EXPECT().Create(gomock.Any(), []entity.AssignmentEvent{
+					{PRID: "pr1", Type: entity.AssignmentEventUnassign, PreviousReviewerID: "u2", Actor: "alice", Reason: "reviewer became PR author"},
+				}).Return(nil)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").
+					Return(entity.PullRequest{ID: "pr1", AuthorID: "u2", Status: openStatus, NeedMoreReviewers: true}, nil)
+			},
+		},
+		{
+			name: "merged PR",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").
+					Return(entity.PullRequest{ID: "pr1", AuthorID: "u1", Status: entity.Status{Name: entity.StatusMERGED}}, nil)
+			},
+			expectedErr: service.ErrPRMerged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			uRepo := mocks.NewMockUserRepo(ctrl)
+			history := mocks.NewMockHistoryRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
+			tt.setup(prRepo, uRepo, history)
+
+			svc := service.New(prRepo, uRepo, nil, history, nil, tx)
+
+			pr, err := svc.TransferAuthor(ctx, "pr1", "u2")
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
+			}
+			if err == nil && pr.AuthorID != "u2" {
+				t.Fatalf("expected author u2, got %s", pr.AuthorID)
+			}
+			if err == nil && slices.Contains(pr.Reviewers, "u2") {
+				t.Fatalf("author must not review own PR, reviewers %v", pr.Reviewers)
+			}
+		})
+	}
+}
+
+func TestService_UnassignReviewer(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), "alice")
+
+	author := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New(), Name: "backend", RequiredReviewers: 2}}
+	openPR := entity.PullRequest{ID: "pr1", AuthorID: author.ID, Status: entity.Status{Name: entity.StatusOPEN}, Reviewers: []string{"u1", "u2"}}
+	unassignEvent := entity.AssignmentEvent{PRID: "pr1", Type: entity.AssignmentEventUnassign, PreviousReviewerID: "u1", Actor: "alice", Reason: "manual unassignment"}
+
+	tests := []struct {
+		name        string
+		autoFill    bool
+		setup       func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo)
+		expectedErr error
+	}{
+		{
+			name: "PR not found",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{}, repository.ErrPRNotFound)
+			},
+			expectedErr: service.ErrPRNotFound,
+		},
+		{
+			name: "reviewer not assigned",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").
+					Return(entity.PullRequest{ID: "pr1", Status: openPR.Status, Reviewers: []string{"u2"}}, nil)
+			},
+			expectedErr: service.ErrReviewerNotAssigned,
+		},
+		{
+			name: "merged PR",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").
+					Return(entity.PullRequest{ID: "pr1", Status: entity.Status{Name: entity.StatusMERGED}, Reviewers: []string{"u1"}}, nil)
+			},
+			expectedErr: service.ErrPRMerged,
+		},
+		{
+			name: "unassign without auto-fill marks PR as needing reviewers",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(openPR, nil)
+				pr.EXPECT().RemoveReviewer(gomock.Any(), "pr1", "u1").Return(nil)
+				h.EXPECT().Create(gomock.Any(), []entity.AssignmentEvent{unassignEvent}).Return(nil)
+				u.EXPECT().GetByID(gomock.Any(), author.ID).Return(author, nil)
+				pr.EXPECT().SetNeedMoreReviewers(gomock.Any(), "pr1", true).Return(nil)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{ID: "pr1", Reviewers: []string{"u2"}, NeedMoreReviewers: true}, nil)
+			},
+		},
+		{
+			name:     "unassign with auto-fill",
+			autoFill: true,
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(openPR, nil)
+				pr.EXPECT().RemoveReviewer(gomock.Any(), "pr1", "u1").Return(nil)
+				h.EXPECT().Create(gomock.Any(), []entity.AssignmentEvent{unassignEvent}).Return(nil)
+				u.EXPECT().GetByID(gomock.Any(), author.ID).Return(author, nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 1, author.ID, "u1", "u2").
+					Return([]entity.User{{ID: "u3"}}, nil)
+				pr.EXPECT().AssignReviewers(gomock.Any(), "pr1", []string{"u3"}).Return(nil)
+				h.EXPECT().Create(gomock.Any(), []entity.AssignmentEvent{
+					{PRID: "pr1", Type: entity.AssignmentEventAssign, ReviewerID: "u3", Actor: "alice", Reason: "auto-filled after unassignment"},
+				}).Return(nil)
+				pr.EXPECT().SetNeedMoreReviewers(gomock.Any(), "pr1", false).Return(nil)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{ID: "pr1", Reviewers: []string{"u2", "u3"}}, nil)
+			},
+		},
+		{
+			name:     "auto-fill without candidates",
+			autoFill: true,
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo) {
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(openPR, nil)
+				pr.EXPECT().RemoveReviewer(gomock.Any(), "pr1", "u1").Return(nil)
+				h.EXPECT().Create(gomock.Any(), []entity.AssignmentEvent{unassignEvent}).Return(nil)
+				u.EXPECT().GetByID(gomock.Any(), author.ID).Return(author, nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 1, author.ID, "u1", "u2").Return(nil, nil)
+				pr.EXPECT().SetNeedMoreReviewers(gomock.Any(), "pr1", true).Return(nil)
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{ID: "pr1", Reviewers: []string{"u2"}, NeedMoreReviewers: true}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			uRepo := mocks.NewMockUserRepo(ctrl)
+			history := mocks.NewMockHistoryRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
+			tt.setup(prRepo, uRepo, history)
+
+			svc := service.New(prRepo, uRepo, nil, history, nil, tx)
+
+			pr, err := svc.UnassignReviewer(ctx, "pr1", "u1", tt.autoFill)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
+			}
+			if err == nil && slices.Contains(pr.Reviewers, "u1") {
+				t.Fatalf("reviewer u1 must be unassigned, reviewers %v", pr.Reviewers)
+			}
+		})
+	}
+}
+
+func TestService_GetOverdueReviews(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	pending := []entity.OverdueReview{
+		{PRID: "pr1", ReviewerID: "u1", AssignedAt: now.Add(-30 * 24 * time.Hour), ReviewSLA: 24 * time.Hour},
+		{PRID: "pr2", ReviewerID: "u2", AssignedAt: now.Add(-time.Minute), ReviewSLA: 24 * time.Hour},
+		{PRID: "pr3", ReviewerID: "u3", AssignedAt: now.Add(-60 * 24 * time.Hour), ReviewSLA: 24 * time.Hour},
+	}
+
+	tests := []struct {
+		name        string
+		setup       func(pr *mocks.MockPRRepo)
+		expectedIDs []string
+		expectedErr error
+	}{
+		{
+			name: "reviews within SLA are skipped, most overdue first",
+			setup: func(pr *mocks.MockPRRepo) {
+				pr.EXPECT().ListPendingReviewsPastSLA(gomock.Any()).Return(pending, nil)
+			},
+			expectedIDs: []string{"pr3", "pr1"},
+		},
+		{
+			name: "repo error",
+			setup: func(pr *mocks.MockPRRepo) {
+				pr.EXPECT().ListPendingReviewsPastSLA(gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			expectedErr: service.ErrCannotFetchOverdue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			tt.setup(prRepo)
+
+			svc := service.New(prRepo, nil, nil, nil, nil, nil)
+
+			reviews, err := svc.GetOverdueReviews(ctx)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			ids := lo.Map(reviews, func(r entity.OverdueReview, _ int) string { return r.PRID })
+			if !slices.Equal(ids, tt.expectedIDs) {
+				t.Fatalf("expected %v, got %v", tt.expectedIDs, ids)
+			}
+			for _, r := range reviews {
+				if r.Overdue <= 0 {
+					t.Fatalf("expected positive overdue for PR %s, got %v", r.PRID, r.Overdue)
+				}
+			}
+		})
+	}
+}
+
+func TestService_ReassignStaleReviews(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	threshold := 48 * time.Hour
+
+	author := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New()}}
+	openStatus := entity.Status{ID: 1, Name: entity.StatusOPEN}
+
+	stale := []entity.PRReviewer{
+		{PRID: "pr1", ReviewerID: "u1", AssignedAt: now.Add(-30 * 24 * time.Hour)},
+		// Within threshold, skipped
+		{PRID: "pr2", ReviewerID: "u2", AssignedAt: now.Add(-time.Hour)},
+		{PRID: "pr3", ReviewerID: "u3", AssignedAt: now.Add(-30 * 24 * time.Hour)},
+	}
+
+	tests := []struct {
+		name          string
+		setup         func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo, tx *mock_transactor.MockTransactor)
+		expectedCount int
+		expectedErr   error
+	}{
+		{
+			name: "repo error",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo, tx *mock_transactor.MockTransactor) {
+				pr.EXPECT().ListStaleReviews(gomock.Any(), gomock.Any(), 10).Return(nil, errors.New("db"))
+			},
+			expectedErr: service.ErrCannotFetchStale,
+		},
+		{
+			name: "stale reviews reassigned, failures skipped",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo, tx *mock_transactor.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				).Times(2)
+
+				pr.EXPECT().ListStaleReviews(gomock.Any(), gomock.Any(), 10).Return(stale, nil)
+
+				// pr1 is reassigned
+				pr.EXPECT().GetByID(gomock.Any(), "pr1").
+					Return(entity.PullRequest{ID: "pr1", Status: openStatus, AuthorID: author.ID, Reviewers: []string{"u1"}}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u1").Return(entity.User{ID: "u1", Team: author.Team}, nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 1, author.ID, "u1").
+					Return([]entity.User{{ID: "u4"}}, nil)
+				pr.EXPECT().ReassignReviewer(gomock.Any(), "pr1", "u1", "u4", false).Return(nil)
+				h.EXPECT().Create(gomock.Any(), []entity.AssignmentEvent{
+					{PRID: "pr1", Type: entity.AssignmentEventAutoReassign, ReviewerID: "u4", PreviousReviewerID: "u1", Actor: "system", Reason: "stale"},
+				}).Return(nil)
+				pr.EXPECT().GetReviewersByPR(gomock.Any(), "pr1").Return([]entity.PRReviewer{{PRID: "pr1", ReviewerID: "u4"}}, nil)
+
+				// pr3 has no candidates
+				pr.EXPECT().GetByID(gomock.Any(), "pr3").
+					Return(entity.PullRequest{ID: "pr3", Status: openStatus, AuthorID: author.ID, Reviewers: []string{"u3"}}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u3").Return(entity.User{ID: "u3", Team: author.Team}, nil)
+				u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 1, author.ID, "u3").Return(nil, nil)
+
+				// pr2 is deferred until its working time exceeds the threshold, pr3 is retried later
+				pr.EXPECT().DeferStaleCheck(gomock.Any(), "pr2", "u2", entity.WorkingDeadline(stale[1].AssignedAt, threshold)).Return(nil)
+				pr.EXPECT().DeferStaleCheck(gomock.Any(), "pr3", "u3", gomock.Any()).Return(nil)
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "first batch cannot be reassigned",
+			setup: func(pr *mocks.MockPRRepo, u *mocks.MockUserRepo, h *mocks.MockHistoryRepo, tx *mock_transactor.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				).Times(2)
+
+				blocked := []entity.PRReviewer{stale[0], stale[2]}
+				pr.EXPECT().ListStaleReviews(gomock.Any(), gomock.Any(), 10).Return(blocked, nil)
+
+				for _, review := range blocked {
+					pr.EXPECT().GetByID(gomock.Any(), review.PRID).
+						Return(entity.PullRequest{ID: review.PRID, Status: openStatus, AuthorID: author.ID, Reviewers: []string{review.ReviewerID}}, nil)
+					u.EXPECT().GetByID(gomock.Any(), review.ReviewerID).Return(entity.User{ID: review.ReviewerID, Team: author.Team}, nil)
+					u.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 1, author.ID, review.ReviewerID).Return(nil, nil)
+
+					// Deferred reviews are not listed again before retry, so they don't block the next batch
+					pr.EXPECT().DeferStaleCheck(gomock.Any(), review.PRID, review.ReviewerID, gomock.Cond(func(until time.Time) bool {
+						return !until.Before(now.Add(time.Hour))
+					})).Return(nil)
+				}
+			},
+			expectedCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			uRepo := mocks.NewMockUserRepo(ctrl)
+			history := mocks.NewMockHistoryRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			tt.setup(prRepo, uRepo, history, tx)
+
+			svc := service.New(prRepo, uRepo, nil, history, nil, tx)
+
+			count, err := svc.ReassignStaleReviews(ctx, threshold, time.Hour, 10)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
+			}
+			if count != tt.expectedCount {
+				t.Fatalf("expected %d reassigned, got %d", tt.expectedCount, count)
+			}
+		})
+	}
+}
+
+func TestService_Events(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), "alice")
+	openStatus := entity.Status{ID: 1, Name: entity.StatusOPEN}
+	mergedStatus := entity.Status{ID: 2, Name: entity.StatusMERGED}
+	closedStatus := entity.Status{ID: 3, Name: entity.StatusCLOSED}
+	author := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New()}}
+
+	t.Run("merge publishes pr.merged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prRepo := mocks.NewMockPRRepo(ctrl)
+		publisher := mocks.NewMockEventPublisher(ctrl)
+		tx := mock_transactor.NewMockTransactor(ctrl)
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+
+		prRepo.EXPECT().LockByID(gomock.Any(), "pr1").Return(nil)
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr1").
+			Return(entity.PullRequest{ID: "pr1", Title: "Add search", AuthorID: author.ID, Status: openStatus}, nil)
+		prRepo.EXPECT().GetPRStatuses(gomock.Any()).Return([]entity.Status{mergedStatus}, nil)
+		prRepo.EXPECT().UpdateStatus(gomock.Any(), "pr1", mergedStatus.ID, gomock.Any()).Return(nil)
+		publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, events ...entity.DomainEvent) error {
+				if len(events) != 1 {
+					t.Fatalf("expected one event, got %+v", events)
+				}
+				event, ok := events[0].(entity.PRMerged)
+				if !ok || event.PRID != "pr1" || event.AuthorID != author.ID || event.Actor != "alice" || event.Forced {
+					t.Fatalf("unexpected event %+v", events[0])
+				}
+				return nil
+			},
+		)
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{ID: "pr1", Status: mergedStatus}, nil)
+
+		svc := service.New(prRepo, nil, nil, nil, nil, tx, service.WithEvents(publisher))
+
+		if _, err := svc.MergePR(ctx, "pr1", false); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("reassign publishes reviewer.assigned", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prRepo := mocks.NewMockPRRepo(ctrl)
+		uRepo := mocks.NewMockUserRepo(ctrl)
+		history := mocks.NewMockHistoryRepo(ctrl)
+		publisher := mocks.NewMockEventPublisher(ctrl)
+		tx := mock_transactor.NewMockTransactor(ctrl)
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr1").
+			Return(entity.PullRequest{ID: "pr1", AuthorID: author.ID, Status: openStatus, Reviewers: []string{"u1"}}, nil)
+		uRepo.EXPECT().GetByID(gomock.Any(), "u1").Return(entity.User{ID: "u1", Team: author.Team}, nil)
+		uRepo.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 1, author.ID, "u1").Return([]entity.User{{ID: "u2"}}, nil)
+		prRepo.EXPECT().ReassignReviewer(gomock.Any(), "pr1", "u1", "u2", false).Return(nil)
+		history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		publisher.EXPECT().Publish(gomock.Any(), entity.ReviewerAssigned{
+			PRID:               "pr1",
+			ReviewerID:         "u2",
+			PreviousReviewerID: "u1",
+			AssignmentType:     string(entity.AssignmentEventReassign),
+			Actor:              "alice",
+			Reason:             "manual reassignment",
+		}).Return(nil)
+		prRepo.EXPECT().GetReviewersByPR(gomock.Any(), "pr1").Return([]entity.PRReviewer{{PRID: "pr1", ReviewerID: "u2"}}, nil)
+
+		svc := service.New(prRepo, uRepo, nil, history, nil, tx, service.WithEvents(publisher))
+
+		if _, _, err := svc.ReassignReviewer(ctx, "pr1", "u1", ""); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("close publishes reviewer.unassigned and pr.status_changed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prRepo := mocks.NewMockPRRepo(ctrl)
+		history := mocks.NewMockHistoryRepo(ctrl)
+		publisher := mocks.NewMockEventPublisher(ctrl)
+		tx := mock_transactor.NewMockTransactor(ctrl)
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+
+		prRepo.EXPECT().LockByID(gomock.Any(), "pr1").Return(nil)
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr1").
+			Return(entity.PullRequest{ID: "pr1", AuthorID: author.ID, Status: openStatus, Reviewers: []string{"u1"}}, nil)
+		prRepo.EXPECT().GetPRStatuses(gomock.Any()).Return([]entity.Status{openStatus, closedStatus}, nil)
+		prRepo.EXPECT().SetStatus(gomock.Any(), "pr1", closedStatus.ID, gomock.Any()).Return(nil)
+		prRepo.EXPECT().RemoveReviewers(gomock.Any(), "pr1").Return([]string{"u1"}, nil)
+		prRepo.EXPECT().SetNeedMoreReviewers(gomock.Any(), "pr1", false).Return(nil)
+		history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		gomock.InOrder(
+			publisher.EXPECT().Publish(gomock.Any(), entity.ReviewerUnassigned{
+				PRID:       "pr1",
+				ReviewerID: "u1",
+				Actor:      "alice",
+				Reason:     "PR closed",
+			}).Return(nil),
+			publisher.EXPECT().Publish(gomock.Any(), entity.PRStatusChanged{
+				PRID:   "pr1",
+				From:   entity.StatusOPEN,
+				To:     entity.StatusCLOSED,
+				Action: entity.ActionClose,
+				Actor:  "alice",
+			}).Return(nil),
+		)
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{ID: "pr1", AuthorID: author.ID, Status: closedStatus}, nil)
+
+		svc := service.New(prRepo, nil, nil, history, nil, tx, service.WithEvents(publisher))
+
+		if _, err := svc.ClosePR(ctx, "pr1"); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	})
+}
+
+func TestService_Authorization(t *testing.T) {
+	openStatus := entity.Status{ID: 1, Name: entity.StatusOPEN}
+	pr := entity.PullRequest{ID: "pr1", AuthorID: "author1", Status: openStatus, Reviewers: []string{"u1"}}
+
+	as := func(subject string, role auth.Role, team string) context.Context {
+		return auth.WithPrincipal(context.Background(), auth.Principal{Subject: subject, Role: role, Team: team})
+	}
+
+	mergeTests := []struct {
+		name        string
+		ctx         context.Context
+		force       bool
+		expectedErr error
+	}{
+		{name: "member is not the author", ctx: as("u2", auth.RoleMember, ""), expectedErr: service.ErrMergeForbidden},
+		{name: "lead is not the author", ctx: as("lead", auth.RoleTeamLead, "backend"), expectedErr: service.ErrMergeForbidden},
+		{name: "author forces merge", ctx: as("author1", auth.RoleMember, ""), force: true, expectedErr: service.ErrForceMergeForbidden},
+	}
+
+	for _, tt := range mergeTests {
+		t.Run("merge: "+tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			prRepo.EXPECT().LockByID(gomock.Any(), "pr1").Return(nil)
+			prRepo.EXPECT().GetByID(gomock.Any(), "pr1").Return(pr, nil)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
+
+			svc := service.New(prRepo, nil, nil, nil, nil, tx)
+
+			if _, err := svc.MergePR(tt.ctx, "pr1", tt.force); !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+
+	t.Run("merge: author is allowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prRepo := mocks.NewMockPRRepo(ctrl)
+		tx := mock_transactor.NewMockTransactor(ctrl)
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+		mergedStatus := entity.Status{ID: 2, Name: entity.StatusMERGED}
+
+		prRepo.EXPECT().LockByID(gomock.Any(), "pr1").Return(nil)
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr1").Return(pr, nil)
+		prRepo.EXPECT().GetPRStatuses(gomock.Any()).Return([]entity.Status{mergedStatus}, nil)
+		prRepo.EXPECT().UpdateStatus(gomock.Any(), "pr1", mergedStatus.ID, gomock.Any()).Return(nil)
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr1").Return(entity.PullRequest{ID: "pr1", Status: mergedStatus}, nil)
+
+		svc := service.New(prRepo, nil, nil, nil, nil, tx)
+
+		if _, err := svc.MergePR(as("author1", auth.RoleMember, ""), "pr1", false); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	})
+
+	reassignTests := []struct {
+		name        string
+		ctx         context.Context
+		setup       func(u *mocks.MockUserRepo)
+		expectedErr error
+	}{
+		{
+			name:        "member is not the reviewer",
+			ctx:         as("u2", auth.RoleMember, ""),
+			setup:       func(u *mocks.MockUserRepo) {},
+			expectedErr: service.ErrReassignForbidden,
+		},
+		{
+			name: "lead of another team",
+			ctx:  as("lead", auth.RoleTeamLead, "frontend"),
+			setup: func(u *mocks.MockUserRepo) {
+				u.EXPECT().GetByID(gomock.Any(), "u1").Return(entity.User{ID: "u1", Team: entity.Team{Name: "backend"}}, nil)
+			},
+			expectedErr: service.ErrReassignForbidden,
+		},
+		{
+			name:        "lead without team",
+			ctx:         as("lead", auth.RoleTeamLead, ""),
+			setup:       func(u *mocks.MockUserRepo) {},
+			expectedErr: service.ErrReassignForbidden,
+		},
+	}
+
+	for _, tt := range reassignTests {
+		t.Run("reassign: "+tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uRepo := mocks.NewMockUserRepo(ctrl)
+			tt.setup(uRepo)
+
+			svc := service.New(nil, uRepo, nil, nil, nil, nil)
+
+			if _, _, err := svc.ReassignReviewer(tt.ctx, "pr1", "u1", "u3"); !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+
+	allowedReassign := map[string]context.Context{
+		"affected reviewer": as("u1", auth.RoleMember, ""),
+		"lead of the team":  as("lead", auth.RoleTeamLead, "backend"),
+		"admin":             as("root", auth.RoleAdmin, ""),
+		"no authentication": context.Background(),
+	}
+
+	for name, ctx := range allowedReassign {
+		t.Run("reassign: "+name+" is allowed", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := mocks.NewMockPRRepo(ctrl)
+			uRepo := mocks.NewMockUserRepo(ctrl)
+			history := mocks.NewMockHistoryRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
+
+			uRepo.EXPECT().GetByID(gomock.Any(), "u1").Return(entity.User{ID: "u1", Team: entity.Team{Name: "backend"}}, nil).AnyTimes()
+			prRepo.EXPECT().GetByID(gomock.Any(), "pr1").Return(pr, nil)
+			uRepo.EXPECT().GetByID(gomock.Any(), "u3").Return(entity.User{ID: "u3", IsActive: true}, nil)
+			prRepo.EXPECT().ReassignReviewer(gomock.Any(), "pr1", "u1", "u3", false).Return(nil)
+			history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			prRepo.EXPECT().GetReviewersByPR(gomock.Any(), "pr1").Return([]entity.PRReviewer{{PRID: "pr1", ReviewerID: "u3"}}, nil)
+
+			svc := service.New(prRepo, uRepo, nil, history, nil, tx)
+
+			if _, _, err := svc.ReassignReviewer(ctx, "pr1", "u1", "u3"); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestService_ImportPRs(t *testing.T) {
+	ctx := context.Background()
+	author := entity.User{ID: "author1", Team: entity.Team{ID: uuid.New(), RequiredReviewers: 1}}
+
+	items := []entity.PRImport{
+		{ID: "pr1", Title: "first", AuthorID: "author1"},
+		{ID: "pr2", Title: "second", AuthorID: "author1"},
+		{ID: "pr3", Title: "third", AuthorID: "ghost"},
+	}
+
+	t.Run("reviewers are spread across the import", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prRepo := mocks.NewMockPRRepo(ctrl)
+		uRepo := mocks.NewMockUserRepo(ctrl)
+		history := mocks.NewMockHistoryRepo(ctrl)
+		tx := mock_transactor.NewMockTransactor(ctrl)
+
+		history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		// 2 batches joined by CreatePR, and a savepoint for every PR
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).Times(5).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+		tx.EXPECT().WithinSavepoint(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+
+		uRepo.EXPECT().GetByID(gomock.Any(), "author1").Return(author, nil).Times(2)
+		uRepo.EXPECT().GetByID(gomock.Any(), "ghost").Return(entity.User{}, repository.ErrUserNotFound)
+
+		// The strategy prefers r1 both times, but r1 already reviews pr1 of the import
+		gomock.InOrder(
+			uRepo.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 1, "author1").
+				Return([]entity.User{{ID: "r1"}}, nil),
+			uRepo.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 2, "author1").
+				Return([]entity.User{{ID: "r1"}, {ID: "r2"}}, nil),
+		)
+
+		prRepo.EXPECT().Create(gomock.Any(), "pr1", "first", "author1", "OPEN", false).Return(entity.PullRequest{ID: "pr1"}, nil)
+		prRepo.EXPECT().Create(gomock.Any(), "pr2", "second", "author1", "OPEN", false).Return(entity.PullRequest{ID: "pr2"}, nil)
+		prRepo.EXPECT().AssignReviewers(gomock.Any(), "pr1", []string{"r1"}).Return(nil)
+		prRepo.EXPECT().AssignReviewers(gomock.Any(), "pr2", []string{"r2"}).Return(nil)
+
+		svc := service.New(prRepo, uRepo, nil, history, nil, tx, service.WithImportBatchSize(2))
+		results := svc.ImportPRs(ctx, items)
+
+		if len(results) != 3 {
+			t.Fatalf("expected 3 results, got %d", len(results))
+		}
+		if results[0].Err != nil || !reflect.DeepEqual(results[0].PR.Reviewers, []string{"r1"}) {
+			t.Errorf("unexpected first result: %+v", results[0])
+		}
+		if results[1].Err != nil || !reflect.DeepEqual(results[1].PR.Reviewers, []string{"r2"}) {
+			t.Errorf("unexpected second result: %+v", results[1])
+		}
+		if !errors.Is(results[2].Err, service.ErrAuthorNotFound) || results[2].Index != 2 {
+			t.Errorf("unexpected third result: %+v", results[2])
+		}
+	})
+
+	t.Run("failed batch commit fails all its PRs", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prRepo := mocks.NewMockPRRepo(ctrl)
+		uRepo := mocks.NewMockUserRepo(ctrl)
+		history := mocks.NewMockHistoryRepo(ctrl)
+		tx := mock_transactor.NewMockTransactor(ctrl)
+
+		history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		// The batch is declared first and matches the outer call, CreatePR joins it
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error {
+				_ = fn(ctx)
+				return errors.New("commit")
+			},
+		)
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+		tx.EXPECT().WithinSavepoint(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+
+		uRepo.EXPECT().GetByID(gomock.Any(), "author1").Return(author, nil)
+		uRepo.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 1, "author1").Return(nil, nil)
+		prRepo.EXPECT().Create(gomock.Any(), "pr1", "first", "author1", "OPEN", true).Return(entity.PullRequest{ID: "pr1"}, nil)
+
+		svc := service.New(prRepo, uRepo, nil, history, nil, tx)
+		results := svc.ImportPRs(ctx, items[:1])
+
+		if len(results) != 1 || !errors.Is(results[0].Err, service.ErrCannotCreatePR) {
+			t.Errorf("unexpected results: %+v", results)
+		}
+	})
+
+	t.Run("reviewers of a failed batch are not counted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		prRepo := mocks.NewMockPRRepo(ctrl)
+		uRepo := mocks.NewMockUserRepo(ctrl)
+		history := mocks.NewMockHistoryRepo(ctrl)
+		tx := mock_transactor.NewMockTransactor(ctrl)
+
+		history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		// The first batch fails to commit, the rest succeed
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error {
+				_ = fn(ctx)
+				return errors.New("commit")
+			},
+		)
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+		tx.EXPECT().WithinSavepoint(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+
+		uRepo.EXPECT().GetByID(gomock.Any(), "author1").Return(author, nil).Times(2)
+		// r1 of the rolled back pr1 doesn't push pr2 to another reviewer
+		uRepo.EXPECT().GetRandomActiveTeammates(gomock.Any(), author.Team.ID, 1, "author1").
+			Return([]entity.User{{ID: "r1"}}, nil).Times(2)
+
+		prRepo.EXPECT().Create(gomock.Any(), "pr1", "first", "author1", "OPEN", false).Return(entity.PullRequest{ID: "pr1"}, nil)
+		prRepo.EXPECT().Create(gomock.Any(), "pr2", "second", "author1", "OPEN", false).Return(entity.PullRequest{ID: "pr2"}, nil)
+		prRepo.EXPECT().AssignReviewers(gomock.Any(), "pr1", []string{"r1"}).Return(nil)
+		prRepo.EXPECT().AssignReviewers(gomock.Any(), "pr2", []string{"r1"}).Return(nil)
+
+		svc := service.New(prRepo, uRepo, nil, history, nil, tx, service.WithImportBatchSize(1))
+		results := svc.ImportPRs(ctx, items[:2])
+
+		if !errors.Is(results[0].Err, service.ErrCannotCreatePR) {
+			t.Errorf("unexpected first result: %+v", results[0])
+		}
+		if results[1].Err != nil || !reflect.DeepEqual(results[1].PR.Reviewers, []string{"r1"}) {
+			t.Errorf("unexpected second result: %+v", results[1])
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package pr
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/sirupsen/logrus"
+)
+
+const staleReviewReason = "stale"
+
+// Reassigns up to limit reviews of open PRs with no reviewer activity for longer than threshold (working time).
+// Skipped reviews are deferred, so they don't fill later batches: reviews within threshold until their working time
+// exceeds it, reviews which cannot be reassigned for retryAfter. Returns number of reassigned reviews
+func (s *Service) ReassignStaleReviews(ctx context.Context, threshold, retryAfter time.Duration, limit int) (int, error) {
+	logrus.Infof("PRService.ReassignStaleReviews: looking for reviews without activity for %v", threshold)
+
+	now := time.Now()
+
+	// Calendar time is never shorter than working time, so the repo narrows the candidates
+	stale, err := s.PRRepo.ListStaleReviews(ctx, now.Add(-threshold), limit)
+	if err != nil {
+		logrus.Errorf("PRService.ReassignStaleReviews: failed to fetch stale reviews: %v", err)
+		return 0, ErrCannotFetchStale
+	}
+
+	reassigned := 0
+	for _, review := range stale {
+		if ctx.Err() != nil {
+			break
+		}
+		if entity.WorkingTime(review.AssignedAt, now) <= threshold {
+			s.deferStaleCheck(ctx, review, entity.WorkingDeadline(review.AssignedAt, threshold))
+			continue
+		}
+
+		_, newReviewerID, err := s.reassignReviewer(ctx, review.PRID, review.ReviewerID, "", false, entity.AssignmentEventAutoReassign, staleReviewReason)
+		if err != nil {
+			logrus.Warnf("PRService.ReassignStaleReviews: cannot reassign reviewer %s of PR %s: %v", review.ReviewerID, review.PRID, err)
+			s.deferStaleCheck(ctx, review, now.Add(retryAfter))
+			continue
+		}
+
+		logrus.Infof("PRService.ReassignStaleReviews: PR %s reassigned from %s to %s", review.PRID, review.ReviewerID, newReviewerID)
+		reassigned++
+	}
+
+	logrus.Infof("PRService.ReassignStaleReviews: reassigned %d of %d stale reviews", reassigned, len(stale))
+	return reassigned, nil
+}
+
+// Review which failed to be deferred is checked again in the next pass
+func (s *Service) deferStaleCheck(ctx context.Context, review entity.PRReviewer, until time.Time) {
+	if err := s.PRRepo.DeferStaleCheck(ctx, review.PRID, review.ReviewerID, until); err != nil {
+		logrus.Warnf("PRService.ReassignStaleReviews: cannot defer review of %s on PR %s: %v", review.ReviewerID, review.PRID, err)
+	}
+}
@@ -0,0 +1,100 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// Removes reviewer from the PR and recomputes need_more_reviewers.
+// With autoFill free slots are filled from author's team (and buddy teams) by the usual selection rules
+func (s *Service) UnassignReviewer(ctx context.Context, prID, reviewerID string, autoFill bool) (entity.PullRequest, error) {
+	logrus.Infof("PRService.UnassignReviewer: unassigning reviewer %s from PR %s", reviewerID, prID)
+
+	var pullRequest entity.PullRequest
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		pr, err := s.PRRepo.GetByID(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := checkAction(pr, entity.ActionUnassignReviewer); err != nil {
+			return err
+		}
+
+		if !slices.Contains(pr.Reviewers, reviewerID) {
+			return ErrReviewerNotAssigned
+		}
+
+		if err := s.PRRepo.RemoveReviewer(ctx, prID, reviewerID); err != nil {
+			return err
+		}
+		err = s.events.RecordAssignments(ctx, []entity.AssignmentEvent{
+			newAssignmentEvent(ctx, prID, entity.AssignmentEventUnassign, "", reviewerID, "manual unassignment"),
+		})
+		if err != nil {
+			return err
+		}
+
+		remaining := lo.Without(pr.Reviewers, reviewerID)
+
+		// Get required amount of reviewers from author`s team
+		author, err := s.UserRepo.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+		requiredReviewers := author.Team.ReviewersRequired()
+
+		if autoFill && len(remaining) < requiredReviewers {
+			// Removed reviewer is not picked again
+			excludeIDs := append([]string{pr.AuthorID, reviewerID}, remaining...)
+
+			teammates, err := s.selectReviewers(ctx, author.Team, requiredReviewers-len(remaining), pr.Labels, excludeIDs...)
+			if err != nil {
+				return err
+			}
+			picked := pickedReviewers{reviewerIDs: lo.Map(teammates, func(e entity.User, _ int) string { return e.ID })}
+
+			excludeIDs = append(excludeIDs, picked.reviewerIDs...)
+			picked.fallbackIDs, err = s.selectFallbackReviewers(ctx, author.Team, requiredReviewers-len(remaining)-len(picked.reviewerIDs), excludeIDs...)
+			if err != nil {
+				return err
+			}
+
+			if err := s.assignPicked(ctx, prID, picked, "auto-filled after unassignment"); err != nil {
+				return err
+			}
+			remaining = append(remaining, picked.all()...)
+		}
+
+		if err := s.PRRepo.SetNeedMoreReviewers(ctx, prID, len(remaining) < requiredReviewers); err != nil {
+			return err
+		}
+
+		pullRequest, err = s.PRRepo.GetByID(ctx, prID)
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, repository.ErrPRNotFound) {
+			return entity.PullRequest{}, ErrPRNotFound
+		}
+		if errors.Is(err, ErrReviewerNotAssigned) || errors.Is(err, repository.ErrReviewerNotFound) {
+			return entity.PullRequest{}, ErrReviewerNotAssigned
+		}
+		if errors.Is(err, ErrPRMerged) || errors.Is(err, ErrInvalidPRState) {
+			return entity.PullRequest{}, err
+		}
+		logrus.Errorf("PRService.UnassignReviewer: failed to unassign reviewer %s from PR %s: %v", reviewerID, prID, err)
+		return entity.PullRequest{}, ErrCannotUnassignReviewer
+	}
+
+	logrus.Infof("PRService.UnassignReviewer: reviewer %s unassigned from PR %s", reviewerID, prID)
+	return pullRequest, nil
+}
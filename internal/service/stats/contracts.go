@@ -2,10 +2,12 @@ package stats
 
 import (
 	"context"
+	"time"
 
 	"github.com/4udiwe/avito-pr-service/internal/entity"
 )
 
 type StatsRepo interface {
 	GetStats(ctx context.Context) (*entity.Stats, error)
+	GetReviewSLASamples(ctx context.Context, since time.Time) ([]entity.ReviewSLASample, error)
 }
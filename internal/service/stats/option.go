@@ -0,0 +1,14 @@
+package stats
+
+import "time"
+
+type Option func(*Service)
+
+// WithReviewSLAWindow limits review SLA compliance to reviews assigned within the window
+func WithReviewSLAWindow(window time.Duration) Option {
+	return func(s *Service) {
+		if window > 0 {
+			s.reviewSLAWindow = window
+		}
+	}
+}
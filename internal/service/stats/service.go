@@ -2,19 +2,32 @@ package stats
 
 import (
 	"context"
+	"math"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/4udiwe/avito-pr-service/internal/entity"
 	"github.com/sirupsen/logrus"
 )
 
+const defaultReviewSLAWindow = 30 * 24 * time.Hour
+
 type Service struct {
 	statsRepo StatsRepo
+
+	reviewSLAWindow time.Duration
 }
 
-func New(statsRepo StatsRepo) *Service {
-	return &Service{
-		statsRepo: statsRepo,
+func New(statsRepo StatsRepo, opts ...Option) *Service {
+	s := &Service{
+		statsRepo:       statsRepo,
+		reviewSLAWindow: defaultReviewSLAWindow,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 func (s *Service) GetStats(ctx context.Context) (*entity.Stats, error) {
@@ -23,5 +36,58 @@ func (s *Service) GetStats(ctx context.Context) (*entity.Stats, error) {
 		logrus.Errorf("Falied to collect stats: %v", err)
 		return nil, ErrCannotCollectStats
 	}
+
+	now := time.Now()
+	samples, err := s.statsRepo.GetReviewSLASamples(ctx, now.Add(-s.reviewSLAWindow))
+	if err != nil {
+		logrus.Errorf("Falied to collect review SLA stats: %v", err)
+		return nil, ErrCannotCollectStats
+	}
+	stats.ReviewSLA = reviewSLAStats(samples, now)
+
 	return stats, nil
 }
+
+// Groups samples by team. Undecided reviews still within SLA are not counted yet
+func reviewSLAStats(samples []entity.ReviewSLASample, now time.Time) []entity.TeamSLAStats {
+	byTeam := make(map[string]*entity.TeamSLAStats)
+
+	for _, sample := range samples {
+		decidedAt := now
+		if sample.DecidedAt != nil {
+			decidedAt = *sample.DecidedAt
+		}
+		breached := entity.WorkingTime(sample.AssignedAt, decidedAt) > sample.ReviewSLA
+		if sample.DecidedAt == nil && !breached {
+			continue
+		}
+
+		team, ok := byTeam[sample.TeamName]
+		if !ok {
+			team = &entity.TeamSLAStats{
+				TeamName:       sample.TeamName,
+				ReviewSLAHours: int(sample.ReviewSLA.Hours()),
+			}
+			byTeam[sample.TeamName] = team
+		}
+
+		if breached {
+			team.BreachedSLA++
+		} else {
+			team.MetSLA++
+		}
+	}
+
+	result := make([]entity.TeamSLAStats, 0, len(byTeam))
+	for _, team := range byTeam {
+		percent := float64(team.MetSLA) * 100 / float64(team.MetSLA+team.BreachedSLA)
+		team.CompliancePercent = math.Round(percent*10) / 10
+		result = append(result, *team)
+	}
+
+	slices.SortFunc(result, func(a, b entity.TeamSLAStats) int {
+		return strings.Compare(a.TeamName, b.TeamName)
+	})
+
+	return result
+}
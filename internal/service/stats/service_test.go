@@ -0,0 +1,108 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewSLAStats(t *testing.T) {
+	// Wednesday
+	now := time.Date(2025, time.December, 10, 12, 0, 0, 0, time.UTC)
+	hoursAgo := func(h int) time.Time { return now.Add(-time.Duration(h) * time.Hour) }
+	decided := func(t time.Time) *time.Time { return &t }
+	sample := func(team string, slaHours int, assignedAt time.Time, decidedAt *time.Time) entity.ReviewSLASample {
+		return entity.ReviewSLASample{
+			TeamName:   team,
+			ReviewSLA:  time.Duration(slaHours) * time.Hour,
+			AssignedAt: assignedAt,
+			DecidedAt:  decidedAt,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		samples []entity.ReviewSLASample
+		want    []entity.TeamSLAStats
+	}{
+		{
+			name: "no samples",
+			want: []entity.TeamSLAStats{},
+		},
+		{
+			name: "all met",
+			samples: []entity.ReviewSLASample{
+				sample("backend", 24, hoursAgo(30), decided(hoursAgo(20))),
+				sample("backend", 24, hoursAgo(10), decided(hoursAgo(5))),
+			},
+			want: []entity.TeamSLAStats{
+				{TeamName: "backend", ReviewSLAHours: 24, MetSLA: 2, CompliancePercent: 100},
+			},
+		},
+		{
+			name: "compliance is rounded to one decimal",
+			samples: []entity.ReviewSLASample{
+				sample("backend", 24, hoursAgo(40), decided(hoursAgo(30))),
+				sample("backend", 24, hoursAgo(20), decided(hoursAgo(1))),
+				sample("backend", 24, hoursAgo(50), decided(hoursAgo(20))),
+			},
+			want: []entity.TeamSLAStats{
+				{TeamName: "backend", ReviewSLAHours: 24, MetSLA: 2, BreachedSLA: 1, CompliancePercent: 66.7},
+			},
+		},
+		{
+			name: "undecided review within SLA is not counted",
+			samples: []entity.ReviewSLASample{
+				sample("backend", 24, hoursAgo(10), nil),
+				sample("backend", 24, hoursAgo(30), decided(hoursAgo(10))),
+			},
+			want: []entity.TeamSLAStats{
+				{TeamName: "backend", ReviewSLAHours: 24, MetSLA: 1, CompliancePercent: 100},
+			},
+		},
+		{
+			name: "undecided review after SLA is breached",
+			samples: []entity.ReviewSLASample{
+				sample("backend", 24, hoursAgo(30), nil),
+			},
+			want: []entity.TeamSLAStats{
+				{TeamName: "backend", ReviewSLAHours: 24, BreachedSLA: 1, CompliancePercent: 0},
+			},
+		},
+		{
+			name: "weekend is not counted against SLA",
+			samples: []entity.ReviewSLASample{
+				// Friday 18:00 -> Monday 10:00 is 16 working hours
+				sample("backend", 24,
+					time.Date(2025, time.December, 5, 18, 0, 0, 0, time.UTC),
+					decided(time.Date(2025, time.December, 8, 10, 0, 0, 0, time.UTC))),
+			},
+			want: []entity.TeamSLAStats{
+				{TeamName: "backend", ReviewSLAHours: 24, MetSLA: 1, CompliancePercent: 100},
+			},
+		},
+		{
+			name: "teams sorted by name",
+			samples: []entity.ReviewSLASample{
+				sample("mobile", 8, hoursAgo(30), decided(hoursAgo(10))),
+				sample("backend", 24, hoursAgo(5), decided(hoursAgo(1))),
+				sample("frontend", 24, hoursAgo(30), decided(hoursAgo(28))),
+				sample("frontend", 24, hoursAgo(40), decided(hoursAgo(1))),
+				sample("frontend", 24, hoursAgo(50), decided(hoursAgo(1))),
+			},
+			want: []entity.TeamSLAStats{
+				{TeamName: "backend", ReviewSLAHours: 24, MetSLA: 1, CompliancePercent: 100},
+				{TeamName: "frontend", ReviewSLAHours: 24, MetSLA: 1, BreachedSLA: 2, CompliancePercent: 33.3},
+				{TeamName: "mobile", ReviewSLAHours: 8, BreachedSLA: 1, CompliancePercent: 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, reviewSLAStats(tt.samples, now))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package team
+
+import (
+	"context"
+
+	"github.com/4udiwe/avito-pr-service/pkg/auth"
+	"github.com/sirupsen/logrus"
+)
+
+// Members of a team are changed by admins and leads of that team. Lead without a team in the token
+// can change no team. Without authenticated caller (auth disabled, background jobs) everything is allowed
+func authorizeMembers(ctx context.Context, method, teamName string) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok || principal.HasRole(auth.RoleAdmin) {
+		return nil
+	}
+
+	if principal.HasRole(auth.RoleTeamLead) && principal.Team != "" && principal.Team == teamName {
+		return nil
+	}
+
+	logrus.Warnf("TeamService.%s: %s (%s) is not allowed to change members of team %s", method, principal.Subject, principal.Role, teamName)
+	return ErrMembersForbidden
+}
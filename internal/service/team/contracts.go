@@ -10,13 +10,16 @@ import (
 //go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
 
 type UserRepo interface {
+	GetByID(ctx context.Context, ID string) (entity.User, error)
 	GetByTeamID(ctx context.Context, teamID uuid.UUID) ([]entity.User, error)
-	GetRandomActiveUsers(ctx context.Context, limit int, excludeIDs ...string) ([]entity.User, error)
 	CreateUsersBatch(ctx context.Context, users []entity.User, teamID uuid.UUID) ([]entity.User, error)
+	SetTeamID(ctx context.Context, userID string, teamID uuid.UUID) error
+	SetName(ctx context.Context, userID, name string) error
+	ClearTeamID(ctx context.Context, userID string) error
 }
 
 type TeamRepo interface {
-	Create(ctx context.Context, name string) (entity.Team, error)
+	Create(ctx context.Context, name string, requiredReviewers, reviewSLAHours int) (entity.Team, error)
 	GetByName(ctx context.Context, name string) (entity.Team, error)
 	GetAll(ctx context.Context, limit int, offset int) (teams []entity.Team, total int, err error)
 	DeactivateTeamMembers(ctx context.Context, teamName string) ([]entity.User, error)
@@ -24,5 +27,18 @@ type TeamRepo interface {
 
 type PRRepo interface {
 	ListByReviewer(ctx context.Context, reviewerID string) ([]entity.PullRequest, error)
-	ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error
+}
+
+// Reassigns reviews by the rules of PR service: team strategy, PR labels, PR state and buddy teams
+type PRService interface {
+	HandOverReview(ctx context.Context, prID, reviewerID, reason string, otherTeams bool) (string, error)
+}
+
+type HistoryRepo interface {
+	Create(ctx context.Context, events []entity.AssignmentEvent) error
+}
+
+// Outbox of domain events
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...entity.DomainEvent) error
 }
@@ -10,6 +10,14 @@ var (
 	ErrCannotFetchTeams     = errors.New("cannot fetch teams")
 	ErrCannotDeactivateTeam = errors.New("cannot deactivate team")
 
+	ErrInvalidRequiredReviewers = errors.New("required_reviewers must be at least 1")
+	ErrInvalidReviewSLA         = errors.New("review_sla_hours must be at least 1")
+
 	ErrUserAlreadyExists      = errors.New("user already exists")
+	ErrUserNotFound           = errors.New("user not found")
+	ErrUserInOtherTeam        = errors.New("user is a member of another team, move it instead")
+	ErrUserNotInTeam          = errors.New("user is not a member of the team")
+	ErrMembersForbidden       = errors.New("not allowed to change members of the team")
+	ErrCannotChangeMembers    = errors.New("cannot change team members")
 	ErrCannotFetchNewReviewer = errors.New("cannot fetch new reviewer")
 )
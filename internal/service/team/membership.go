@@ -0,0 +1,253 @@
+package team
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/4udiwe/avito-pr-service/pkg/actor"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// Adds users to an existing team: new users are created, users without a team join it,
+// members of the team are left as is. Members of other teams have to be moved with MoveMember
+func (s *Service) AddMembers(ctx context.Context, teamName string, users []entity.User) (entity.Team, []entity.User, error) {
+	logrus.Infof("TeamService.AddMembers: adding %d users to team %s", len(users), teamName)
+
+	if err := authorizeMembers(ctx, "AddMembers", teamName); err != nil {
+		return entity.Team{}, nil, err
+	}
+
+	var team entity.Team
+	var added []entity.User
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.teamRepo.GetByName(ctx, teamName)
+		if err != nil {
+			return err
+		}
+
+		team = existing
+
+		added, err = s.addMembers(ctx, team, users)
+		if err != nil {
+			return err
+		}
+
+		team.Members, err = s.userRepo.GetByTeamID(ctx, team.ID)
+		return err
+	})
+
+	if err != nil {
+		return entity.Team{}, nil, s.mapMembershipError("AddMembers", err)
+	}
+
+	logrus.Infof("TeamService.AddMembers: %d users added to team %s", len(added), teamName)
+	return team, added, nil
+}
+
+// Removes user from the team. Open reviews of the user are handed over to the teammates
+// when reassignReviews is set, otherwise the user keeps them
+func (s *Service) RemoveMember(ctx context.Context, teamName, userID string, reassignReviews bool) (entity.MemberTransfer, error) {
+	logrus.Infof("TeamService.RemoveMember: removing user %s from team %s", userID, teamName)
+
+	if err := authorizeMembers(ctx, "RemoveMember", teamName); err != nil {
+		return entity.MemberTransfer{}, err
+	}
+
+	var transfer entity.MemberTransfer
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		team, err := s.teamRepo.GetByName(ctx, teamName)
+		if err != nil {
+			return err
+		}
+
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.Team.ID != team.ID {
+			return ErrUserNotInTeam
+		}
+
+		transfer, err = s.handOverReviews(ctx, user, reassignReviews, "removed from team "+teamName)
+		if err != nil {
+			return err
+		}
+
+		if err := s.userRepo.ClearTeamID(ctx, userID); err != nil {
+			return err
+		}
+		transfer.User.Team = entity.Team{}
+
+		return s.events.Publish(ctx, entity.UserTeamChanged{
+			UserID:       userID,
+			PreviousTeam: teamName,
+			Actor:        actor.FromContext(ctx),
+		})
+	})
+
+	if err != nil {
+		return entity.MemberTransfer{}, s.mapMembershipError("RemoveMember", err)
+	}
+
+	logrus.Infof("TeamService.RemoveMember: user %s removed from team %s, %d reviews reassigned", userID, teamName, len(transfer.Reassigned))
+	return transfer, nil
+}
+
+// Moves user to another team. Open reviews of the user are handed over to the teammates
+// in the previous team when reassignReviews is set, otherwise the user keeps them.
+// Both the target team and the user's current team have to be allowed to the caller
+func (s *Service) MoveMember(ctx context.Context, userID, teamName string, reassignReviews bool) (entity.MemberTransfer, error) {
+	logrus.Infof("TeamService.MoveMember: moving user %s to team %s", userID, teamName)
+
+	if err := authorizeMembers(ctx, "MoveMember", teamName); err != nil {
+		return entity.MemberTransfer{}, err
+	}
+
+	var transfer entity.MemberTransfer
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		team, err := s.teamRepo.GetByName(ctx, teamName)
+		if err != nil {
+			return err
+		}
+
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.Team.ID != uuid.Nil {
+			if err := authorizeMembers(ctx, "MoveMember", user.Team.Name); err != nil {
+				return err
+			}
+		}
+		if user.Team.ID == team.ID {
+			// Already there, nothing to hand over
+			transfer = entity.MemberTransfer{User: user, PreviousTeam: user.Team.Name}
+			return nil
+		}
+
+		transfer, err = s.handOverReviews(ctx, user, reassignReviews, "moved to team "+teamName)
+		if err != nil {
+			return err
+		}
+
+		if err := s.userRepo.SetTeamID(ctx, userID, team.ID); err != nil {
+			return err
+		}
+		transfer.User.Team = team
+
+		return s.events.Publish(ctx, entity.UserTeamChanged{
+			UserID:       userID,
+			TeamName:     teamName,
+			PreviousTeam: transfer.PreviousTeam,
+			Actor:        actor.FromContext(ctx),
+		})
+	})
+
+	if err != nil {
+		return entity.MemberTransfer{}, s.mapMembershipError("MoveMember", err)
+	}
+
+	logrus.Infof("TeamService.MoveMember: user %s moved to team %s, %d reviews reassigned", userID, teamName, len(transfer.Reassigned))
+	return transfer, nil
+}
+
+// Creates new users in the team and attaches users without a team, returns the added users
+func (s *Service) addMembers(ctx context.Context, team entity.Team, users []entity.User) ([]entity.User, error) {
+	var toCreate, added []entity.User
+
+	for _, u := range lo.UniqBy(users, func(u entity.User) string { return u.ID }) {
+		existing, err := s.userRepo.GetByID(ctx, u.ID)
+		switch {
+		case errors.Is(err, repository.ErrUserNotFound):
+			toCreate = append(toCreate, u)
+		case err != nil:
+			return nil, err
+		case existing.Team.ID == team.ID:
+			// Already a member
+		case existing.Team.ID == uuid.Nil:
+			if err := s.userRepo.SetTeamID(ctx, existing.ID, team.ID); err != nil {
+				return nil, err
+			}
+			existing.Team = team
+			added = append(added, existing)
+		default:
+			return nil, ErrUserInOtherTeam
+		}
+	}
+
+	if len(toCreate) > 0 {
+		created, err := s.userRepo.CreateUsersBatch(ctx, toCreate, team.ID)
+		if err != nil {
+			return nil, err
+		}
+		added = append(added, created...)
+	}
+
+	events := lo.Map(added, func(u entity.User, _ int) entity.DomainEvent {
+		return entity.UserTeamChanged{UserID: u.ID, TeamName: team.Name, Actor: actor.FromContext(ctx)}
+	})
+	return added, s.events.Publish(ctx, events...)
+}
+
+// Collects open reviews of the user leaving its team. With reassign each review is handed over to a teammate
+// by PR service, reviews nobody can take are kept. Reassignments are recorded in history by PR service
+func (s *Service) handOverReviews(ctx context.Context, user entity.User, reassign bool, reason string) (entity.MemberTransfer, error) {
+	transfer := entity.MemberTransfer{User: user, PreviousTeam: user.Team.Name}
+
+	prs, err := s.prRepo.ListByReviewer(ctx, user.ID)
+	if err != nil {
+		return entity.MemberTransfer{}, err
+	}
+
+	for _, pr := range prs {
+		if pr.Status.Name != entity.StatusOPEN {
+			continue
+		}
+		if !reassign || user.Team.ID == uuid.Nil {
+			transfer.KeptPRIDs = append(transfer.KeptPRIDs, pr.ID)
+			continue
+		}
+
+		newReviewerID, err := s.prService.HandOverReview(ctx, pr.ID, user.ID, reason, false)
+		if err != nil {
+			return entity.MemberTransfer{}, err
+		}
+		if newReviewerID == "" {
+			transfer.KeptPRIDs = append(transfer.KeptPRIDs, pr.ID)
+			continue
+		}
+
+		transfer.Reassigned = append(transfer.Reassigned, entity.AssignmentEvent{
+			PRID:               pr.ID,
+			Type:               entity.AssignmentEventAutoReassign,
+			ReviewerID:         newReviewerID,
+			PreviousReviewerID: user.ID,
+			Actor:              actor.FromContext(ctx),
+			Reason:             reason,
+		})
+	}
+
+	return transfer, nil
+}
+
+func (s *Service) mapMembershipError(method string, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrTeamNotFound):
+		return ErrTeamNotFound
+	case errors.Is(err, repository.ErrUserNotFound):
+		return ErrUserNotFound
+	case errors.Is(err, repository.ErrUserAlreadyExists):
+		return ErrUserAlreadyExists
+	case errors.Is(err, ErrUserInOtherTeam), errors.Is(err, ErrUserNotInTeam), errors.Is(err, ErrMembersForbidden):
+		return err
+	}
+	logrus.Errorf("TeamService.%s: %v", method, err)
+	return ErrCannotChangeMembers
+}
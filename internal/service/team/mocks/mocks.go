@@ -42,6 +42,20 @@ func (m *MockUserRepo) EXPECT() *MockUserRepoMockRecorder {
 	return m.recorder
 }
 
+// ClearTeamID mocks base method.
+func (m *MockUserRepo) ClearTeamID(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearTeamID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearTeamID indicates an expected call of ClearTeamID.
+func (mr *MockUserRepoMockRecorder) ClearTeamID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearTeamID", reflect.TypeOf((*MockUserRepo)(nil).ClearTeamID), ctx, userID)
+}
+
 // CreateUsersBatch mocks base method.
 func (m *MockUserRepo) CreateUsersBatch(ctx context.Context, users []entity.User, teamID uuid.UUID) ([]entity.User, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +71,21 @@ func (mr *MockUserRepoMockRecorder) CreateUsersBatch(ctx, users, teamID any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUsersBatch", reflect.TypeOf((*MockUserRepo)(nil).CreateUsersBatch), ctx, users, teamID)
 }
 
+// GetByID mocks base method.
+func (m *MockUserRepo) GetByID(ctx context.Context, ID string) (entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, ID)
+	ret0, _ := ret[0].(entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUserRepoMockRecorder) GetByID(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepo)(nil).GetByID), ctx, ID)
+}
+
 // GetByTeamID mocks base method.
 func (m *MockUserRepo) GetByTeamID(ctx context.Context, teamID uuid.UUID) ([]entity.User, error) {
 	m.ctrl.T.Helper()
@@ -72,24 +101,32 @@ func (mr *MockUserRepoMockRecorder) GetByTeamID(ctx, teamID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTeamID", reflect.TypeOf((*MockUserRepo)(nil).GetByTeamID), ctx, teamID)
 }
 
-// GetRandomActiveUsers mocks base method.
-func (m *MockUserRepo) GetRandomActiveUsers(ctx context.Context, limit int, excludeIDs ...string) ([]entity.User, error) {
+// SetName mocks base method.
+func (m *MockUserRepo) SetName(ctx context.Context, userID, name string) error {
 	m.ctrl.T.Helper()
-	varargs := []any{ctx, limit}
-	for _, a := range excludeIDs {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "GetRandomActiveUsers", varargs...)
-	ret0, _ := ret[0].([]entity.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetName", ctx, userID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetRandomActiveUsers indicates an expected call of GetRandomActiveUsers.
-func (mr *MockUserRepoMockRecorder) GetRandomActiveUsers(ctx, limit any, excludeIDs ...any) *gomock.Call {
+// SetName indicates an expected call of SetName.
+func (mr *MockUserRepoMockRecorder) SetName(ctx, userID, name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{ctx, limit}, excludeIDs...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRandomActiveUsers", reflect.TypeOf((*MockUserRepo)(nil).GetRandomActiveUsers), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetName", reflect.TypeOf((*MockUserRepo)(nil).SetName), ctx, userID, name)
+}
+
+// SetTeamID mocks base method.
+func (m *MockUserRepo) SetTeamID(ctx context.Context, userID string, teamID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTeamID", ctx, userID, teamID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTeamID indicates an expected call of SetTeamID.
+func (mr *MockUserRepoMockRecorder) SetTeamID(ctx, userID, teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTeamID", reflect.TypeOf((*MockUserRepo)(nil).SetTeamID), ctx, userID, teamID)
 }
 
 // MockTeamRepo is a mock of TeamRepo interface.
@@ -117,18 +154,18 @@ func (m *MockTeamRepo) EXPECT() *MockTeamRepoMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockTeamRepo) Create(ctx context.Context, name string) (entity.Team, error) {
+func (m *MockTeamRepo) Create(ctx context.Context, name string, requiredReviewers, reviewSLAHours int) (entity.Team, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, name)
+	ret := m.ctrl.Call(m, "Create", ctx, name, requiredReviewers, reviewSLAHours)
 	ret0, _ := ret[0].(entity.Team)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockTeamRepoMockRecorder) Create(ctx, name any) *gomock.Call {
+func (mr *MockTeamRepoMockRecorder) Create(ctx, name, requiredReviewers, reviewSLAHours any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTeamRepo)(nil).Create), ctx, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTeamRepo)(nil).Create), ctx, name, requiredReviewers, reviewSLAHours)
 }
 
 // DeactivateTeamMembers mocks base method.
@@ -216,16 +253,122 @@ func (mr *MockPRRepoMockRecorder) ListByReviewer(ctx, reviewerID any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByReviewer", reflect.TypeOf((*MockPRRepo)(nil).ListByReviewer), ctx, reviewerID)
 }
 
-// ReassignReviewer mocks base method.
-func (m *MockPRRepo) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error {
+// MockPRService is a mock of PRService interface.
+type MockPRService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPRServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPRServiceMockRecorder is the mock recorder for MockPRService.
+type MockPRServiceMockRecorder struct {
+	mock *MockPRService
+}
+
+// NewMockPRService creates a new mock instance.
+func NewMockPRService(ctrl *gomock.Controller) *MockPRService {
+	mock := &MockPRService{ctrl: ctrl}
+	mock.recorder = &MockPRServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPRService) EXPECT() *MockPRServiceMockRecorder {
+	return m.recorder
+}
+
+// HandOverReview mocks base method.
+func (m *MockPRService) HandOverReview(ctx context.Context, prID, reviewerID, reason string, otherTeams bool) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandOverReview", ctx, prID, reviewerID, reason, otherTeams)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandOverReview indicates an expected call of HandOverReview.
+func (mr *MockPRServiceMockRecorder) HandOverReview(ctx, prID, reviewerID, reason, otherTeams any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandOverReview", reflect.TypeOf((*MockPRService)(nil).HandOverReview), ctx, prID, reviewerID, reason, otherTeams)
+}
+
+// MockHistoryRepo is a mock of HistoryRepo interface.
+type MockHistoryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockHistoryRepoMockRecorder is the mock recorder for MockHistoryRepo.
+type MockHistoryRepoMockRecorder struct {
+	mock *MockHistoryRepo
+}
+
+// NewMockHistoryRepo creates a new mock instance.
+func NewMockHistoryRepo(ctrl *gomock.Controller) *MockHistoryRepo {
+	mock := &MockHistoryRepo{ctrl: ctrl}
+	mock.recorder = &MockHistoryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryRepo) EXPECT() *MockHistoryRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockHistoryRepo) Create(ctx context.Context, events []entity.AssignmentEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReassignReviewer", ctx, prID, oldReviewerID, newReviewerID)
+	ret := m.ctrl.Call(m, "Create", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHistoryRepoMockRecorder) Create(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHistoryRepo)(nil).Create), ctx, events)
+}
+
+// MockEventPublisher is a mock of EventPublisher interface.
+type MockEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockEventPublisherMockRecorder is the mock recorder for MockEventPublisher.
+type MockEventPublisherMockRecorder struct {
+	mock *MockEventPublisher
+}
+
+// NewMockEventPublisher creates a new mock instance.
+func NewMockEventPublisher(ctrl *gomock.Controller) *MockEventPublisher {
+	mock := &MockEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPublisher) EXPECT() *MockEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventPublisher) Publish(ctx context.Context, events ...entity.DomainEvent) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Publish", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ReassignReviewer indicates an expected call of ReassignReviewer.
-func (mr *MockPRRepoMockRecorder) ReassignReviewer(ctx, prID, oldReviewerID, newReviewerID any) *gomock.Call {
+// Publish indicates an expected call of Publish.
+func (mr *MockEventPublisherMockRecorder) Publish(ctx any, events ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignReviewer", reflect.TypeOf((*MockPRRepo)(nil).ReassignReviewer), ctx, prID, oldReviewerID, newReviewerID)
+	varargs := append([]any{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventPublisher)(nil).Publish), varargs...)
 }
@@ -0,0 +1,10 @@
+package team
+
+type Option func(*Service)
+
+// WithEvents enables publishing of domain events for team changes and reviewer reassignments
+func WithEvents(publisher EventPublisher) Option {
+	return func(s *Service) {
+		s.publisher = publisher
+	}
+}
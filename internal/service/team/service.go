@@ -6,35 +6,92 @@ import (
 
 	"github.com/4udiwe/avito-pr-service/internal/entity"
 	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/4udiwe/avito-pr-service/internal/service/events"
+	"github.com/4udiwe/avito-pr-service/pkg/actor"
 	"github.com/4udiwe/avito-pr-service/pkg/transactor"
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 )
 
 type Service struct {
-	userRepo  UserRepo
-	teamRepo  TeamRepo
-	prRepo    PRRepo
-	txManager transactor.Transactor
+	userRepo    UserRepo
+	teamRepo    TeamRepo
+	prRepo      PRRepo
+	historyRepo HistoryRepo
+	prService   PRService
+	txManager   transactor.Transactor
+
+	// Optional outbox of domain events
+	publisher EventPublisher
+	// Writes assignment history and domain events
+	events *events.Recorder
 }
 
-func New(userRepo UserRepo, teamRepo TeamRepo, prRepo PRRepo, txManager transactor.Transactor) *Service {
-	return &Service{
-		userRepo:  userRepo,
-		teamRepo:  teamRepo,
-		prRepo:    prRepo,
-		txManager: txManager,
+func New(
+	userRepo UserRepo,
+	teamRepo TeamRepo,
+	prRepo PRRepo,
+	historyRepo HistoryRepo,
+	prService PRService,
+	txManager transactor.Transactor,
+	opts ...Option,
+) *Service {
+	s := &Service{
+		userRepo:    userRepo,
+		teamRepo:    teamRepo,
+		prRepo:      prRepo,
+		historyRepo: historyRepo,
+		prService:   prService,
+		txManager:   txManager,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.events = events.NewRecorder(historyRepo, s.publisher)
+
+	return s
 }
 
-func (s *Service) CreateTeamWithUsers(ctx context.Context, teamName string, users []entity.User) (entity.Team, error) {
+// Creates team with users. Re-posted team is not an error: missing members are added to it the same way
+// as in AddMembers, with the same permissions, and settings of the team are kept. created is false for an already existing team
+func (s *Service) CreateTeamWithUsers(
+	ctx context.Context,
+	teamName string,
+	requiredReviewers, reviewSLAHours int,
+	users []entity.User,
+) (team entity.Team, created bool, err error) {
 	logrus.Infof("TeamService.CreateTeamWithUsers: creating team %s with %d users", teamName, len(users))
 
-	var team entity.Team
+	if requiredReviewers < 1 {
+		return entity.Team{}, false, ErrInvalidRequiredReviewers
+	}
+	if reviewSLAHours < 1 {
+		return entity.Team{}, false, ErrInvalidReviewSLA
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.teamRepo.GetByName(ctx, teamName)
+		if err == nil {
+			if err := authorizeMembers(ctx, "CreateTeamWithUsers", teamName); err != nil {
+				return err
+			}
+			team = existing
+			if _, err := s.addMembers(ctx, team, users); err != nil {
+				return err
+			}
+			team.Members, err = s.userRepo.GetByTeamID(ctx, team.ID)
+			return err
+		}
+		if !errors.Is(err, repository.ErrTeamNotFound) {
+			return err
+		}
+
+		created = true
 
-	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
 		// Create a team
-		newTeam, err := s.teamRepo.Create(ctx, teamName)
+		newTeam, err := s.teamRepo.Create(ctx, teamName, requiredReviewers, reviewSLAHours)
 		if err != nil {
 			return err
 		}
@@ -47,21 +104,30 @@ func (s *Service) CreateTeamWithUsers(ctx context.Context, teamName string, user
 			return err
 		}
 
-		return nil
+		return s.events.Publish(ctx, entity.TeamCreated{
+			TeamName:          teamName,
+			RequiredReviewers: requiredReviewers,
+			ReviewSLAHours:    reviewSLAHours,
+			MemberIDs:         lo.Map(team.Members, func(u entity.User, _ int) string { return u.ID }),
+			Actor:             actor.FromContext(ctx),
+		})
 	})
 
 	if err != nil {
 		if errors.Is(err, repository.ErrTeamAlreadyExists) {
-			return entity.Team{}, ErrTeamAlreadyExists
+			return entity.Team{}, false, ErrTeamAlreadyExists
 		}
 		if errors.Is(err, repository.ErrUserAlreadyExists) {
-			return entity.Team{}, ErrUserAlreadyExists
+			return entity.Team{}, false, ErrUserAlreadyExists
+		}
+		if errors.Is(err, ErrUserInOtherTeam) || errors.Is(err, ErrMembersForbidden) {
+			return entity.Team{}, false, err
 		}
 		logrus.Errorf("TeamService.CreateTeamWithUsers: failed to create team %s: %v", teamName, err)
-		return entity.Team{}, ErrCannotCreateTeam
+		return entity.Team{}, false, ErrCannotCreateTeam
 	}
 
-	return team, nil
+	return team, created, nil
 }
 
 func (s *Service) GetTeamWithMembers(ctx context.Context, teamName string) (entity.Team, error) {
@@ -116,7 +182,7 @@ func (s *Service) GetAllTeams(ctx context.Context, page, pageSize int) ([]entity
 	return teams, total, nil
 }
 
-// Deactivates all team members and reassigns them on open PRs with new random reviewers from other teams
+// Deactivates all team members and hands their reviews of open PRs over to reviewers from other teams
 func (s *Service) DeactivateTeamAndReassignPRs(ctx context.Context, teamName string) error {
 	logrus.Infof("TeamService.DeactivateTeamAndReassignPRs: deactivating team %s and reassigning PRs", teamName)
 
@@ -142,27 +208,24 @@ func (s *Service) DeactivateTeamAndReassignPRs(ctx context.Context, teamName str
 			}
 
 			for _, pr := range prs {
-				// For each PR get new random reviewers from other teams
-				newReviewers, err := s.userRepo.GetRandomActiveUsers(
-					ctx,
-					1,                                      // Get only one new reviewer instead deactivated
-					append(deactivatedIDs, pr.AuthorID)..., // Exclude deactivated users and author himself
-				)
-				if err != nil {
-					return err
-				}
-				if len(newReviewers) > 1 {
-					return ErrCannotFetchNewReviewer
+				// Reviewers of merged and closed PRs are not changed
+				if !entity.ActionReassignReviewer.Allowed(pr.Status.Name) {
+					continue
 				}
 
-				// Reassign deactivated reviewer with new random
-				if err := s.prRepo.ReassignReviewer(ctx, pr.ID, userID, newReviewers[0].ID); err != nil {
+				// Teammates are deactivated, so the review goes to buddy teams of the author's team or to any active user.
+				// Review nobody can take is kept
+				if _, err := s.prService.HandOverReview(ctx, pr.ID, userID, "team "+teamName+" deactivated", true); err != nil {
 					return err
 				}
 			}
 		}
-		return err
 
+		return s.events.Publish(ctx, entity.TeamDeactivated{
+			TeamName:           teamName,
+			DeactivatedUserIDs: deactivatedIDs,
+			Actor:              actor.FromContext(ctx),
+		})
 	})
 
 	if err != nil {
@@ -3,6 +3,8 @@ package team_test
 import (
 	"context"
 	"errors"
+	"maps"
+	"slices"
 	"testing"
 
 	"github.com/4udiwe/avito-pr-service/internal/entity"
@@ -10,6 +12,7 @@ import (
 	"github.com/4udiwe/avito-pr-service/internal/repository"
 	"github.com/4udiwe/avito-pr-service/internal/service/team"
 	"github.com/4udiwe/avito-pr-service/internal/service/team/mocks"
+	"github.com/4udiwe/avito-pr-service/pkg/auth"
 	"github.com/google/uuid"
 	"go.uber.org/mock/gomock"
 )
@@ -28,7 +31,7 @@ func TestService_CreateTeamWithUsers(t *testing.T) {
 		expectedErr error
 	}{
 		{
-			name: "team already exists",
+			name: "team created concurrently",
 			setup: func(
 				u *mocks.MockUserRepo,
 				tr *mocks.MockTeamRepo,
@@ -41,13 +44,58 @@ func TestService_CreateTeamWithUsers(t *testing.T) {
 						return fn(ctx)
 					})
 
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(entity.Team{}, repository.ErrTeamNotFound)
 				tr.EXPECT().
-					Create(gomock.Any(), "backend").
+					Create(gomock.Any(), "backend", 2, 24).
 					Return(entity.Team{}, repository.ErrTeamAlreadyExists)
 			},
 			expectedErr: team.ErrTeamAlreadyExists,
 		},
 
+		{
+			name: "re-posted team",
+			setup: func(
+				u *mocks.MockUserRepo,
+				tr *mocks.MockTeamRepo,
+				pr *mocks.MockPRRepo,
+				tx *mock_transactor.MockTransactor,
+			) {
+				tx.EXPECT().
+					WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				existingTeam := entity.Team{ID: uuid.New(), Name: "backend"}
+				member := entity.User{ID: "1", Name: "John", Team: existingTeam, IsActive: true}
+
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(existingTeam, nil)
+				u.EXPECT().GetByID(gomock.Any(), "1").Return(member, nil)
+				u.EXPECT().GetByTeamID(gomock.Any(), existingTeam.ID).Return([]entity.User{member}, nil)
+			},
+			expectedErr: nil,
+		},
+
+		{
+			name: "re-posted team with member of another team",
+			setup: func(
+				u *mocks.MockUserRepo,
+				tr *mocks.MockTeamRepo,
+				pr *mocks.MockPRRepo,
+				tx *mock_transactor.MockTransactor,
+			) {
+				tx.EXPECT().
+					WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(entity.Team{ID: uuid.New(), Name: "backend"}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "1").Return(entity.User{ID: "1", Team: entity.Team{ID: uuid.New()}}, nil)
+			},
+			expectedErr: team.ErrUserInOtherTeam,
+		},
+
 		{
 			name: "user already exists",
 			setup: func(
@@ -64,8 +112,10 @@ func TestService_CreateTeamWithUsers(t *testing.T) {
 
 				createdTeam := entity.Team{ID: uuid.New(), Name: "backend"}
 
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(entity.Team{}, repository.ErrTeamNotFound)
+
 				tr.EXPECT().
-					Create(gomock.Any(), "backend").
+					Create(gomock.Any(), "backend", 2, 24).
 					Return(createdTeam, nil)
 
 				u.EXPECT().
@@ -91,11 +141,13 @@ func TestService_CreateTeamWithUsers(t *testing.T) {
 
 				createdTeam := entity.Team{ID: uuid.New(), Name: "backend"}
 
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(entity.Team{}, repository.ErrTeamNotFound)
+
 				users := make([]entity.User, 1)
 				users = append(users, entity.User{ID: "1", Name: "John", Team: entity.Team{ID: createdTeam.ID}, IsActive: true})
 
 				tr.EXPECT().
-					Create(gomock.Any(), "backend").
+					Create(gomock.Any(), "backend", 2, 24).
 					Return(createdTeam, nil)
 
 				u.EXPECT().
@@ -115,12 +167,14 @@ func TestService_CreateTeamWithUsers(t *testing.T) {
 			tr := mocks.NewMockTeamRepo(ctrl)
 			pr := mocks.NewMockPRRepo(ctrl)
 			tx := mock_transactor.NewMockTransactor(ctrl)
+			history := mocks.NewMockHistoryRepo(ctrl)
+			history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 			tt.setup(u, tr, pr, tx)
 
-			svc := team.New(u, tr, pr, tx)
+			svc := team.New(u, tr, pr, history, nil, tx)
 
-			_, err := svc.CreateTeamWithUsers(ctx, "backend", []entity.User{
+			_, _, err := svc.CreateTeamWithUsers(ctx, "backend", 2, 24, []entity.User{
 				{ID: "1", Name: "John", IsActive: true},
 			})
 
@@ -131,6 +185,44 @@ func TestService_CreateTeamWithUsers(t *testing.T) {
 	}
 }
 
+func TestService_CreateTeamWithUsers_InvalidRequiredReviewers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := team.New(
+		mocks.NewMockUserRepo(ctrl),
+		mocks.NewMockTeamRepo(ctrl),
+		mocks.NewMockPRRepo(ctrl),
+		mocks.NewMockHistoryRepo(ctrl),
+		nil,
+		mock_transactor.NewMockTransactor(ctrl),
+	)
+
+	_, _, err := svc.CreateTeamWithUsers(context.Background(), "backend", 0, 24, nil)
+	if !errors.Is(err, team.ErrInvalidRequiredReviewers) {
+		t.Fatalf("expected: %v, got: %v", team.ErrInvalidRequiredReviewers, err)
+	}
+}
+
+func TestService_CreateTeamWithUsers_InvalidReviewSLA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := team.New(
+		mocks.NewMockUserRepo(ctrl),
+		mocks.NewMockTeamRepo(ctrl),
+		mocks.NewMockPRRepo(ctrl),
+		mocks.NewMockHistoryRepo(ctrl),
+		nil,
+		mock_transactor.NewMockTransactor(ctrl),
+	)
+
+	_, _, err := svc.CreateTeamWithUsers(context.Background(), "backend", 2, 0, nil)
+	if !errors.Is(err, team.ErrInvalidReviewSLA) {
+		t.Fatalf("expected: %v, got: %v", team.ErrInvalidReviewSLA, err)
+	}
+}
+
 func TestService_GetTeamWithMembers(t *testing.T) {
 	ctx := context.Background()
 
@@ -205,10 +297,12 @@ func TestService_GetTeamWithMembers(t *testing.T) {
 			tr := mocks.NewMockTeamRepo(ctrl)
 			pr := mocks.NewMockPRRepo(ctrl)
 			tx := mock_transactor.NewMockTransactor(ctrl)
+			history := mocks.NewMockHistoryRepo(ctrl)
+			history.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 			tt.setup(u, tr, pr, tx)
 
-			svc := team.New(u, tr, pr, tx)
+			svc := team.New(u, tr, pr, history, nil, tx)
 
 			_, err := svc.GetTeamWithMembers(ctx, "backend")
 
@@ -271,7 +365,7 @@ func TestService_GetAllTeams(t *testing.T) {
 			tr := mocks.NewMockTeamRepo(ctrl)
 			pr := mocks.NewMockPRRepo(ctrl)
 
-			svc := team.New(u, tr, pr, nil)
+			svc := team.New(u, tr, pr, nil, nil, nil)
 
 			tt.setup(u, tr, pr)
 
@@ -292,187 +386,381 @@ func TestService_DeactivateTeamAndReassignPRs(t *testing.T) {
 		{ID: "u2", Name: "Jane"},
 	}
 
-	prList := []entity.PullRequest{
-		{
-			ID:        "pr1",
-			AuthorID:  "a1",
-			Reviewers: []string{"u1"},
-		},
-	}
+	openPR := entity.PullRequest{ID: "pr1", AuthorID: "a1", Reviewers: []string{"u1"}, Status: entity.Status{Name: entity.StatusOPEN}}
+	mergedPR := entity.PullRequest{ID: "pr2", AuthorID: "a1", Reviewers: []string{"u1"}, Status: entity.Status{Name: entity.StatusMERGED}}
+	closedPR := entity.PullRequest{ID: "pr3", AuthorID: "a1", Reviewers: []string{"u2"}, Status: entity.Status{Name: entity.StatusCLOSED}}
 
 	tests := []struct {
-		name  string
-		setup func(
-			u *mocks.MockUserRepo,
-			tr *mocks.MockTeamRepo,
-			pr *mocks.MockPRRepo,
-			tx *mock_transactor.MockTransactor,
-		)
+		name        string
+		setup       func(tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService)
 		expectedErr error
 	}{
 		{
 			name: "deactivation error",
-			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, tx *mock_transactor.MockTransactor) {
-				tx.EXPECT().
-					WithinTransaction(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						return fn(ctx)
-					})
-
-				tr.EXPECT().
-					DeactivateTeamMembers(gomock.Any(), "backend").
-					Return(nil, errors.New("db"))
+			setup: func(tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService) {
+				tr.EXPECT().DeactivateTeamMembers(gomock.Any(), "backend").Return(nil, errors.New("db"))
 			},
 			expectedErr: team.ErrCannotDeactivateTeam,
 		},
-
 		{
 			name: "no users → no PR actions",
-			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, tx *mock_transactor.MockTransactor) {
-				tx.EXPECT().
-					WithinTransaction(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						return fn(ctx)
-					})
-
-				tr.EXPECT().
-					DeactivateTeamMembers(gomock.Any(), "backend").
-					Return([]entity.User{}, nil)
+			setup: func(tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService) {
+				tr.EXPECT().DeactivateTeamMembers(gomock.Any(), "backend").Return([]entity.User{}, nil)
 			},
-			expectedErr: nil,
 		},
-
 		{
 			name: "ListByReviewer error",
-			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, tx *mock_transactor.MockTransactor) {
-				tx.EXPECT().
-					WithinTransaction(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						return fn(ctx)
-					})
-
-				tr.EXPECT().
-					DeactivateTeamMembers(gomock.Any(), "backend").
-					Return(deactivateResult, nil)
-
-				pr.EXPECT().
-					ListByReviewer(gomock.Any(), "u1").
-					Return(nil, errors.New("db"))
+			setup: func(tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService) {
+				tr.EXPECT().DeactivateTeamMembers(gomock.Any(), "backend").Return(deactivateResult, nil)
+				pr.EXPECT().ListByReviewer(gomock.Any(), "u1").Return(nil, errors.New("db"))
 			},
 			expectedErr: team.ErrCannotDeactivateTeam,
 		},
-
 		{
-			name: "GetRandomActiveUsers error",
-			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, tx *mock_transactor.MockTransactor) {
-				tx.EXPECT().
-					WithinTransaction(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						return fn(ctx)
-					})
-
-				tr.EXPECT().
-					DeactivateTeamMembers(gomock.Any(), "backend").
-					Return(deactivateResult, nil)
-
-				pr.EXPECT().
-					ListByReviewer(gomock.Any(), "u1").
-					Return(prList, nil)
-
-				u.EXPECT().
-					GetRandomActiveUsers(gomock.Any(), 1, gomock.Any()).
-					Return(nil, errors.New("db"))
+			name: "hand over error",
+			setup: func(tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService) {
+				tr.EXPECT().DeactivateTeamMembers(gomock.Any(), "backend").Return(deactivateResult, nil)
+				pr.EXPECT().ListByReviewer(gomock.Any(), "u1").Return([]entity.PullRequest{openPR}, nil)
+				ps.EXPECT().HandOverReview(gomock.Any(), "pr1", "u1", "team backend deactivated", true).Return("", errors.New("db"))
 			},
 			expectedErr: team.ErrCannotDeactivateTeam,
 		},
-
 		{
-			name: "GetRandomActiveUsers returned >1 user",
-			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, tx *mock_transactor.MockTransactor) {
-				tx.EXPECT().
-					WithinTransaction(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						return fn(ctx)
-					})
+			name: "open reviews handed over, merged and closed PRs skipped",
+			setup: func(tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService) {
+				tr.EXPECT().DeactivateTeamMembers(gomock.Any(), "backend").Return(deactivateResult, nil)
+				pr.EXPECT().ListByReviewer(gomock.Any(), "u1").Return([]entity.PullRequest{openPR, mergedPR}, nil)
+				ps.EXPECT().HandOverReview(gomock.Any(), "pr1", "u1", "team backend deactivated", true).Return("r1", nil)
+				pr.EXPECT().ListByReviewer(gomock.Any(), "u2").Return([]entity.PullRequest{closedPR}, nil)
+			},
+		},
+		{
+			name: "review without replacement is kept",
+			setup: func(tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService) {
+				tr.EXPECT().DeactivateTeamMembers(gomock.Any(), "backend").Return(deactivateResult, nil)
+				pr.EXPECT().ListByReviewer(gomock.Any(), "u1").Return([]entity.PullRequest{openPR}, nil)
+				ps.EXPECT().HandOverReview(gomock.Any(), "pr1", "u1", "team backend deactivated", true).Return("", nil)
+				pr.EXPECT().ListByReviewer(gomock.Any(), "u2").Return(nil, nil)
+			},
+		},
+	}
 
-				tr.EXPECT().
-					DeactivateTeamMembers(gomock.Any(), "backend").
-					Return(deactivateResult, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
 
-				pr.EXPECT().
-					ListByReviewer(gomock.Any(), "u1").
-					Return(prList, nil)
+			tr := mocks.NewMockTeamRepo(ctrl)
+			pr := mocks.NewMockPRRepo(ctrl)
+			prService := mocks.NewMockPRService(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
 
-				u.EXPECT().
-					GetRandomActiveUsers(gomock.Any(), 1, gomock.Any()).
-					Return([]entity.User{
-						{ID: "x1"},
-						{ID: "x2"},
-					}, nil)
+			tt.setup(tr, pr, prService)
+
+			svc := team.New(mocks.NewMockUserRepo(ctrl), tr, pr, mocks.NewMockHistoryRepo(ctrl), prService, tx)
+
+			err := svc.DeactivateTeamAndReassignPRs(ctx, "backend")
+
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestService_AddMembers(t *testing.T) {
+	ctx := context.Background()
+	backend := entity.Team{ID: uuid.New(), Name: "backend"}
+
+	tests := []struct {
+		name          string
+		setup         func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo)
+		expectedAdded []string
+		expectedErr   error
+	}{
+		{
+			name: "team not found",
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo) {
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(entity.Team{}, repository.ErrTeamNotFound)
 			},
-			expectedErr: team.ErrCannotDeactivateTeam,
+			expectedErr: team.ErrTeamNotFound,
 		},
-
 		{
-			name: "ReassignReviewer error",
-			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, tx *mock_transactor.MockTransactor) {
-				tx.EXPECT().
-					WithinTransaction(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						return fn(ctx)
-					})
+			name: "new, teamless and existing members",
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo) {
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(backend, nil)
+				u.EXPECT().GetByID(gomock.Any(), "new").Return(entity.User{}, repository.ErrUserNotFound)
+				u.EXPECT().GetByID(gomock.Any(), "teamless").Return(entity.User{ID: "teamless"}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "member").Return(entity.User{ID: "member", Team: backend}, nil)
+				u.EXPECT().SetTeamID(gomock.Any(), "teamless", backend.ID).Return(nil)
+				u.EXPECT().CreateUsersBatch(gomock.Any(), []entity.User{{ID: "new", Name: "New"}}, backend.ID).
+					Return([]entity.User{{ID: "new", Name: "New", Team: backend}}, nil)
+				u.EXPECT().GetByTeamID(gomock.Any(), backend.ID).Return(nil, nil)
+			},
+			expectedAdded: []string{"teamless", "new"},
+		},
+		{
+			name: "member of another team",
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo) {
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(backend, nil)
+				u.EXPECT().GetByID(gomock.Any(), "new").Return(entity.User{ID: "new", Team: entity.Team{ID: uuid.New()}}, nil)
+			},
+			expectedErr: team.ErrUserInOtherTeam,
+		},
+	}
 
-				tr.EXPECT().
-					DeactivateTeamMembers(gomock.Any(), "backend").
-					Return(deactivateResult, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mocks.NewMockUserRepo(ctrl)
+			tr := mocks.NewMockTeamRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
 
-				pr.EXPECT().
-					ListByReviewer(gomock.Any(), "u1").
-					Return(prList, nil)
+			tt.setup(u, tr)
 
-				u.EXPECT().
-					GetRandomActiveUsers(gomock.Any(), 1, gomock.Any()).
-					Return([]entity.User{{ID: "newrev"}}, nil)
+			svc := team.New(u, tr, mocks.NewMockPRRepo(ctrl), mocks.NewMockHistoryRepo(ctrl), nil, tx)
+
+			_, added, err := svc.AddMembers(ctx, "backend", []entity.User{
+				{ID: "new", Name: "New"},
+				{ID: "teamless", Name: "Teamless"},
+				{ID: "member", Name: "Member"},
+				{ID: "new", Name: "New"},
+			})
+
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected: %v, got: %v", tt.expectedErr, err)
+			}
+			addedIDs := make([]string, 0, len(added))
+			for _, a := range added {
+				addedIDs = append(addedIDs, a.ID)
+			}
+			if tt.expectedErr == nil && !slices.Equal(addedIDs, tt.expectedAdded) {
+				t.Fatalf("expected added: %v, got: %v", tt.expectedAdded, addedIDs)
+			}
+		})
+	}
+}
+
+func TestService_UpsertTeamWithUsers(t *testing.T) {
+	ctx := context.Background()
+	backend := entity.Team{ID: uuid.New(), Name: "backend"}
+	frontend := entity.Team{ID: uuid.New(), Name: "frontend"}
+	openPR := entity.PullRequest{ID: "pr1", Status: entity.Status{Name: entity.StatusOPEN}}
 
-				pr.EXPECT().
-					ReassignReviewer(gomock.Any(), "pr1", "u1", "newrev").
-					Return(errors.New("db"))
+	tests := []struct {
+		name             string
+		reassign         bool
+		setup            func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService)
+		expectedCreated  bool
+		expectedStatuses map[string]entity.MemberUpsertStatus
+		expectedKept     []string
+		expectedReviewer string
+		expectedErr      error
+	}{
+		{
+			name: "new team",
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService) {
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(entity.Team{}, repository.ErrTeamNotFound)
+				tr.EXPECT().Create(gomock.Any(), "backend", 2, 24).Return(backend, nil)
+				u.EXPECT().GetByID(gomock.Any(), "new").Return(entity.User{}, repository.ErrUserNotFound)
+				u.EXPECT().GetByID(gomock.Any(), "renamed").Return(entity.User{}, repository.ErrUserNotFound)
+				u.EXPECT().GetByID(gomock.Any(), "mover").Return(entity.User{ID: "mover", Name: "Mover", Team: frontend}, nil)
+				pr.EXPECT().ListByReviewer(gomock.Any(), "mover").Return([]entity.PullRequest{openPR}, nil)
+				u.EXPECT().SetTeamID(gomock.Any(), "mover", backend.ID).Return(nil)
+				u.EXPECT().CreateUsersBatch(gomock.Any(), []entity.User{{ID: "new", Name: "New"}, {ID: "renamed", Name: "Renamed"}}, backend.ID).
+					Return([]entity.User{{ID: "new", Name: "New", Team: backend}, {ID: "renamed", Name: "Renamed", Team: backend}}, nil)
+				u.EXPECT().GetByTeamID(gomock.Any(), backend.ID).Return(nil, nil)
 			},
-			expectedErr: team.ErrCannotDeactivateTeam,
+			expectedCreated: true,
+			expectedStatuses: map[string]entity.MemberUpsertStatus{
+				"new":     entity.MemberCreated,
+				"renamed": entity.MemberCreated,
+				"mover":   entity.MemberMoved,
+			},
+			expectedKept: []string{"pr1"},
 		},
-
 		{
-			name: "success",
-			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, tx *mock_transactor.MockTransactor) {
-				tx.EXPECT().
-					WithinTransaction(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						return fn(ctx)
-					})
+			name:     "reviews of moved user are reassigned",
+			reassign: true,
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService) {
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(backend, nil)
+				u.EXPECT().GetByID(gomock.Any(), "new").Return(entity.User{ID: "new", Name: "New", Team: backend}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "renamed").Return(entity.User{ID: "renamed", Name: "Renamed", Team: backend}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "mover").Return(entity.User{ID: "mover", Name: "Mover", Team: frontend}, nil)
+				pr.EXPECT().ListByReviewer(gomock.Any(), "mover").Return([]entity.PullRequest{openPR}, nil)
+				ps.EXPECT().HandOverReview(gomock.Any(), "pr1", "mover", "moved to team backend", false).Return("u3", nil)
+				u.EXPECT().SetTeamID(gomock.Any(), "mover", backend.ID).Return(nil)
+				u.EXPECT().GetByTeamID(gomock.Any(), backend.ID).Return(nil, nil)
+			},
+			expectedStatuses: map[string]entity.MemberUpsertStatus{
+				"new":     entity.MemberUnchanged,
+				"renamed": entity.MemberUnchanged,
+				"mover":   entity.MemberMoved,
+			},
+			expectedReviewer: "u3",
+		},
+		{
+			name: "existing team",
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService) {
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(backend, nil)
+				u.EXPECT().GetByID(gomock.Any(), "new").Return(entity.User{ID: "new", Name: "New", Team: backend}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "renamed").Return(entity.User{ID: "renamed", Name: "Old name", Team: backend}, nil)
+				u.EXPECT().GetByID(gomock.Any(), "mover").Return(entity.User{ID: "mover", Name: "Old name"}, nil)
+				pr.EXPECT().ListByReviewer(gomock.Any(), "mover").Return(nil, nil)
+				u.EXPECT().SetName(gomock.Any(), "renamed", "Renamed").Return(nil)
+				u.EXPECT().SetTeamID(gomock.Any(), "mover", backend.ID).Return(nil)
+				u.EXPECT().SetName(gomock.Any(), "mover", "Mover").Return(nil)
+				u.EXPECT().GetByTeamID(gomock.Any(), backend.ID).Return(nil, nil)
+			},
+			expectedStatuses: map[string]entity.MemberUpsertStatus{
+				"new":     entity.MemberUnchanged,
+				"renamed": entity.MemberUpdated,
+				"mover":   entity.MemberMoved,
+			},
+		},
+		{
+			name: "user exists",
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService) {
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(backend, nil)
+				u.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(entity.User{}, repository.ErrUserNotFound).Times(3)
+				u.EXPECT().CreateUsersBatch(gomock.Any(), gomock.Any(), backend.ID).Return(nil, repository.ErrUserAlreadyExists)
+			},
+			expectedErr: team.ErrUserAlreadyExists,
+		},
+		{
+			name: "cannot move user",
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService) {
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(backend, nil)
+				u.EXPECT().GetByID(gomock.Any(), "new").Return(entity.User{ID: "new", Name: "New", Team: frontend}, nil)
+				pr.EXPECT().ListByReviewer(gomock.Any(), "new").Return(nil, nil)
+				u.EXPECT().SetTeamID(gomock.Any(), "new", backend.ID).Return(errors.New("db error"))
+			},
+			expectedErr: team.ErrCannotCreateTeam,
+		},
+	}
 
-				tr.EXPECT().
-					DeactivateTeamMembers(gomock.Any(), "backend").
-					Return(deactivateResult, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
 
-				/* for u1 */
-				pr.EXPECT().
-					ListByReviewer(gomock.Any(), "u1").
-					Return(prList, nil)
+			u := mocks.NewMockUserRepo(ctrl)
+			tr := mocks.NewMockTeamRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
 
-				u.EXPECT().
-					GetRandomActiveUsers(gomock.Any(), 1, gomock.Any()).
-					Return([]entity.User{{ID: "r1"}}, nil)
+			pr := mocks.NewMockPRRepo(ctrl)
+			prService := mocks.NewMockPRService(ctrl)
+			tt.setup(u, tr, pr, prService)
+
+			svc := team.New(u, tr, pr, mocks.NewMockHistoryRepo(ctrl), prService, tx)
+
+			result, err := svc.UpsertTeamWithUsers(ctx, "backend", 2, 24, []entity.User{
+				{ID: "new", Name: "New"},
+				{ID: "renamed", Name: "Renamed"},
+				{ID: "mover", Name: "Mover"},
+				{ID: "new", Name: "New"},
+			}, tt.reassign)
+
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected: %v, got: %v", tt.expectedErr, err)
+			}
+			if tt.expectedErr != nil {
+				return
+			}
+			if result.TeamCreated != tt.expectedCreated {
+				t.Fatalf("expected team created: %v, got: %v", tt.expectedCreated, result.TeamCreated)
+			}
+			statuses := make(map[string]entity.MemberUpsertStatus, len(result.Members))
+			var kept []string
+			var newReviewer string
+			for _, m := range result.Members {
+				statuses[m.User.ID] = m.Status
+				kept = append(kept, m.KeptPRIDs...)
+				for _, e := range m.Reassigned {
+					newReviewer = e.ReviewerID
+				}
+			}
+			if !maps.Equal(statuses, tt.expectedStatuses) {
+				t.Fatalf("expected statuses: %v, got: %v", tt.expectedStatuses, statuses)
+			}
+			if !slices.Equal(kept, tt.expectedKept) {
+				t.Fatalf("expected kept: %v, got: %v", tt.expectedKept, kept)
+			}
+			if newReviewer != tt.expectedReviewer {
+				t.Fatalf("expected new reviewer: %q, got: %q", tt.expectedReviewer, newReviewer)
+			}
+		})
+	}
+}
 
-				pr.EXPECT().
-					ReassignReviewer(gomock.Any(), "pr1", "u1", "r1").
-					Return(nil)
+func TestService_MoveMember(t *testing.T) {
+	ctx := context.Background()
+	backend := entity.Team{ID: uuid.New(), Name: "backend"}
+	frontend := entity.Team{ID: uuid.New(), Name: "frontend"}
+	user := entity.User{ID: "u1", Team: backend}
+
+	openPR := entity.PullRequest{ID: "pr1", Status: entity.Status{Name: entity.StatusOPEN}}
+	mergedPR := entity.PullRequest{ID: "pr2", Status: entity.Status{Name: entity.StatusMERGED}}
+	lonelyPR := entity.PullRequest{ID: "pr3", Status: entity.Status{Name: entity.StatusOPEN}}
 
-				/* for u2 — no PRs */
-				pr.EXPECT().
-					ListByReviewer(gomock.Any(), "u2").
-					Return([]entity.PullRequest{}, nil)
+	tests := []struct {
+		name               string
+		reassign           bool
+		setup              func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService)
+		expectedReassigned []string
+		expectedKept       []string
+		expectedErr        error
+	}{
+		{
+			name: "user not found",
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService) {
+				tr.EXPECT().GetByName(gomock.Any(), "frontend").Return(frontend, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u1").Return(entity.User{}, repository.ErrUserNotFound)
 			},
-			expectedErr: nil,
+			expectedErr: team.ErrUserNotFound,
+		},
+		{
+			name: "already in the team",
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService) {
+				tr.EXPECT().GetByName(gomock.Any(), "frontend").Return(frontend, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u1").Return(entity.User{ID: "u1", Team: frontend}, nil)
+			},
+		},
+		{
+			name: "reviews are kept",
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService) {
+				tr.EXPECT().GetByName(gomock.Any(), "frontend").Return(frontend, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u1").Return(user, nil)
+				pr.EXPECT().ListByReviewer(gomock.Any(), "u1").Return([]entity.PullRequest{openPR, mergedPR}, nil)
+				u.EXPECT().SetTeamID(gomock.Any(), "u1", frontend.ID).Return(nil)
+			},
+			expectedKept: []string{"pr1"},
+		},
+		{
+			name:     "reviews are reassigned within the old team",
+			reassign: true,
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo, ps *mocks.MockPRService) {
+				tr.EXPECT().GetByName(gomock.Any(), "frontend").Return(frontend, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u1").Return(user, nil)
+				pr.EXPECT().ListByReviewer(gomock.Any(), "u1").Return([]entity.PullRequest{openPR, mergedPR, lonelyPR}, nil)
+
+				ps.EXPECT().HandOverReview(gomock.Any(), "pr1", "u1", "moved to team frontend", false).Return("u3", nil)
+				ps.EXPECT().HandOverReview(gomock.Any(), "pr3", "u1", "moved to team frontend", false).Return("", nil)
+				u.EXPECT().SetTeamID(gomock.Any(), "u1", frontend.ID).Return(nil)
+			},
+			expectedReassigned: []string{"pr1"},
+			expectedKept:       []string{"pr3"},
 		},
 	}
 
@@ -484,16 +772,307 @@ func TestService_DeactivateTeamAndReassignPRs(t *testing.T) {
 			u := mocks.NewMockUserRepo(ctrl)
 			tr := mocks.NewMockTeamRepo(ctrl)
 			pr := mocks.NewMockPRRepo(ctrl)
+			prService := mocks.NewMockPRService(ctrl)
 			tx := mock_transactor.NewMockTransactor(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
 
-			svc := team.New(u, tr, pr, tx)
+			tt.setup(u, tr, pr, prService)
 
-			tt.setup(u, tr, pr, tx)
+			svc := team.New(u, tr, pr, mocks.NewMockHistoryRepo(ctrl), prService, tx)
 
-			err := svc.DeactivateTeamAndReassignPRs(ctx, "backend")
+			transfer, err := svc.MoveMember(ctx, "u1", "frontend", tt.reassign)
 
 			if !errors.Is(err, tt.expectedErr) {
-				t.Fatalf("expected %v, got %v", tt.expectedErr, err)
+				t.Fatalf("expected: %v, got: %v", tt.expectedErr, err)
+			}
+			reassigned := make([]string, 0, len(transfer.Reassigned))
+			for _, e := range transfer.Reassigned {
+				reassigned = append(reassigned, e.PRID)
+			}
+			if len(reassigned) != len(tt.expectedReassigned) || !slices.Equal(reassigned, tt.expectedReassigned) {
+				t.Fatalf("expected reassigned: %v, got: %v", tt.expectedReassigned, reassigned)
+			}
+			if !slices.Equal(transfer.KeptPRIDs, tt.expectedKept) {
+				t.Fatalf("expected kept: %v, got: %v", tt.expectedKept, transfer.KeptPRIDs)
+			}
+		})
+	}
+}
+
+func TestService_RemoveMember(t *testing.T) {
+	ctx := context.Background()
+	backend := entity.Team{ID: uuid.New(), Name: "backend"}
+
+	t.Run("user is not a member", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		u := mocks.NewMockUserRepo(ctrl)
+		tr := mocks.NewMockTeamRepo(ctrl)
+		tx := mock_transactor.NewMockTransactor(ctrl)
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+		tr.EXPECT().GetByName(gomock.Any(), "backend").Return(backend, nil)
+		u.EXPECT().GetByID(gomock.Any(), "u1").Return(entity.User{ID: "u1", Team: entity.Team{ID: uuid.New()}}, nil)
+
+		svc := team.New(u, tr, mocks.NewMockPRRepo(ctrl), mocks.NewMockHistoryRepo(ctrl), nil, tx)
+
+		if _, err := svc.RemoveMember(ctx, "backend", "u1", false); !errors.Is(err, team.ErrUserNotInTeam) {
+			t.Fatalf("expected: %v, got: %v", team.ErrUserNotInTeam, err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		u := mocks.NewMockUserRepo(ctrl)
+		tr := mocks.NewMockTeamRepo(ctrl)
+		pr := mocks.NewMockPRRepo(ctrl)
+		tx := mock_transactor.NewMockTransactor(ctrl)
+		tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		)
+		tr.EXPECT().GetByName(gomock.Any(), "backend").Return(backend, nil)
+		u.EXPECT().GetByID(gomock.Any(), "u1").Return(entity.User{ID: "u1", Team: backend}, nil)
+		pr.EXPECT().ListByReviewer(gomock.Any(), "u1").Return(nil, nil)
+		u.EXPECT().ClearTeamID(gomock.Any(), "u1").Return(nil)
+
+		svc := team.New(u, tr, pr, mocks.NewMockHistoryRepo(ctrl), nil, tx)
+
+		transfer, err := svc.RemoveMember(ctx, "backend", "u1", true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if transfer.PreviousTeam != "backend" || transfer.User.Team.ID != uuid.Nil {
+			t.Fatalf("unexpected transfer: %+v", transfer)
+		}
+	})
+}
+
+func TestService_MembersAuthorization(t *testing.T) {
+	backend := entity.Team{ID: uuid.New(), Name: "backend"}
+	frontend := entity.Team{ID: uuid.New(), Name: "frontend"}
+
+	admin := auth.Principal{Subject: "admin", Role: auth.RoleAdmin}
+	backendLead := auth.Principal{Subject: "lead", Role: auth.RoleTeamLead, Team: "backend"}
+	noTeamLead := auth.Principal{Subject: "lead", Role: auth.RoleTeamLead}
+	member := auth.Principal{Subject: "member", Role: auth.RoleMember, Team: "backend"}
+
+	tests := []struct {
+		name        string
+		principal   auth.Principal
+		call        func(ctx context.Context, svc *team.Service) error
+		setup       func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo)
+		expectedErr error
+	}{
+		{
+			name:      "lead adds members to own team",
+			principal: backendLead,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, _, err := svc.AddMembers(ctx, "backend", nil)
+				return err
+			},
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo) {
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(backend, nil)
+				u.EXPECT().GetByTeamID(gomock.Any(), backend.ID).Return(nil, nil)
+			},
+		},
+		{
+			name:      "lead adds members to another team",
+			principal: backendLead,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, _, err := svc.AddMembers(ctx, "frontend", nil)
+				return err
+			},
+			expectedErr: team.ErrMembersForbidden,
+		},
+		{
+			name:      "lead without team adds members",
+			principal: noTeamLead,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, _, err := svc.AddMembers(ctx, "frontend", nil)
+				return err
+			},
+			expectedErr: team.ErrMembersForbidden,
+		},
+		{
+			name:      "member removes a teammate",
+			principal: member,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, err := svc.RemoveMember(ctx, "backend", "u1", false)
+				return err
+			},
+			expectedErr: team.ErrMembersForbidden,
+		},
+		{
+			name:      "lead removes member of another team",
+			principal: backendLead,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, err := svc.RemoveMember(ctx, "frontend", "u1", false)
+				return err
+			},
+			expectedErr: team.ErrMembersForbidden,
+		},
+		{
+			name:      "lead moves own member to another team",
+			principal: backendLead,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, err := svc.MoveMember(ctx, "u1", "frontend", false)
+				return err
+			},
+			expectedErr: team.ErrMembersForbidden,
+		},
+		{
+			name:      "admin moves member to another team",
+			principal: admin,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, err := svc.MoveMember(ctx, "u1", "frontend", false)
+				return err
+			},
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo) {
+				tr.EXPECT().GetByName(gomock.Any(), "frontend").Return(frontend, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u1").Return(entity.User{ID: "u1", Team: backend}, nil)
+				pr.EXPECT().ListByReviewer(gomock.Any(), "u1").Return(nil, nil)
+				u.EXPECT().SetTeamID(gomock.Any(), "u1", frontend.ID).Return(nil)
+			},
+		},
+		{
+			name:      "lead takes member of another team",
+			principal: backendLead,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, err := svc.MoveMember(ctx, "u1", "backend", false)
+				return err
+			},
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo) {
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(backend, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u1").Return(entity.User{ID: "u1", Team: frontend}, nil)
+			},
+			expectedErr: team.ErrMembersForbidden,
+		},
+		{
+			name:      "lead takes user without a team",
+			principal: backendLead,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, err := svc.MoveMember(ctx, "u1", "backend", false)
+				return err
+			},
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo) {
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(backend, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u1").Return(entity.User{ID: "u1"}, nil)
+				pr.EXPECT().ListByReviewer(gomock.Any(), "u1").Return(nil, nil)
+				u.EXPECT().SetTeamID(gomock.Any(), "u1", backend.ID).Return(nil)
+			},
+		},
+		{
+			name:      "member re-posts existing team",
+			principal: member,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, _, err := svc.CreateTeamWithUsers(ctx, "backend", 2, 24, []entity.User{{ID: "u1"}})
+				return err
+			},
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo) {
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(backend, nil)
+			},
+			expectedErr: team.ErrMembersForbidden,
+		},
+		{
+			name:      "member creates new team",
+			principal: member,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, _, err := svc.CreateTeamWithUsers(ctx, "backend", 2, 24, nil)
+				return err
+			},
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo) {
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(entity.Team{}, repository.ErrTeamNotFound)
+				tr.EXPECT().Create(gomock.Any(), "backend", 2, 24).Return(backend, nil)
+				u.EXPECT().CreateUsersBatch(gomock.Any(), nil, backend.ID).Return(nil, nil)
+			},
+		},
+		{
+			name:      "member upserts team",
+			principal: member,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, err := svc.UpsertTeamWithUsers(ctx, "backend", 2, 24, []entity.User{{ID: "u1", Name: "Renamed"}}, false)
+				return err
+			},
+			expectedErr: team.ErrMembersForbidden,
+		},
+		{
+			name:      "lead upserts another team",
+			principal: backendLead,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, err := svc.UpsertTeamWithUsers(ctx, "frontend", 2, 24, nil, false)
+				return err
+			},
+			expectedErr: team.ErrMembersForbidden,
+		},
+		{
+			name:      "lead upserts own team with member of another team",
+			principal: backendLead,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, err := svc.UpsertTeamWithUsers(ctx, "backend", 2, 24, []entity.User{{ID: "u1"}}, false)
+				return err
+			},
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo) {
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(backend, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u1").Return(entity.User{ID: "u1", Team: frontend}, nil)
+			},
+			expectedErr: team.ErrMembersForbidden,
+		},
+		{
+			name:      "lead upserts own team with user without a team",
+			principal: backendLead,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, err := svc.UpsertTeamWithUsers(ctx, "backend", 2, 24, []entity.User{{ID: "u1"}}, false)
+				return err
+			},
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo) {
+				tr.EXPECT().GetByName(gomock.Any(), "backend").Return(backend, nil)
+				u.EXPECT().GetByID(gomock.Any(), "u1").Return(entity.User{ID: "u1"}, nil)
+				pr.EXPECT().ListByReviewer(gomock.Any(), "u1").Return(nil, nil)
+				u.EXPECT().SetTeamID(gomock.Any(), "u1", backend.ID).Return(nil)
+				u.EXPECT().GetByTeamID(gomock.Any(), backend.ID).Return(nil, nil)
+			},
+		},
+		{
+			name:      "admin re-posts existing team",
+			principal: admin,
+			call: func(ctx context.Context, svc *team.Service) error {
+				_, _, err := svc.CreateTeamWithUsers(ctx, "frontend", 2, 24, nil)
+				return err
+			},
+			setup: func(u *mocks.MockUserRepo, tr *mocks.MockTeamRepo, pr *mocks.MockPRRepo) {
+				tr.EXPECT().GetByName(gomock.Any(), "frontend").Return(frontend, nil)
+				u.EXPECT().GetByTeamID(gomock.Any(), frontend.ID).Return(nil, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			u := mocks.NewMockUserRepo(ctrl)
+			tr := mocks.NewMockTeamRepo(ctrl)
+			pr := mocks.NewMockPRRepo(ctrl)
+			tx := mock_transactor.NewMockTransactor(ctrl)
+			tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
+			if tt.setup != nil {
+				tt.setup(u, tr, pr)
+			}
+
+			svc := team.New(u, tr, pr, mocks.NewMockHistoryRepo(ctrl), nil, tx)
+
+			err := tt.call(auth.WithPrincipal(context.Background(), tt.principal), svc)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected: %v, got: %v", tt.expectedErr, err)
 			}
 		})
 	}
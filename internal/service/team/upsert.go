@@ -0,0 +1,165 @@
+package team
+
+import (
+	"context"
+	"errors"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/4udiwe/avito-pr-service/pkg/actor"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// Creates the team if it doesn't exist and makes the listed users its members in one transaction:
+// new users are created, names of existing users are updated, members of other teams and users
+// without a team are moved into it. Open reviews of moved users are handed over as in MoveMember.
+// Settings of an existing team and activity of existing users are not changed, users missing from the list
+// stay in the team. Caller must be allowed to change members of the team and of every team users are moved from
+func (s *Service) UpsertTeamWithUsers(
+	ctx context.Context,
+	teamName string,
+	requiredReviewers, reviewSLAHours int,
+	users []entity.User,
+	reassignReviews bool,
+) (entity.TeamUpsert, error) {
+	logrus.Infof("TeamService.UpsertTeamWithUsers: upserting team %s with %d users", teamName, len(users))
+
+	if err := authorizeMembers(ctx, "UpsertTeamWithUsers", teamName); err != nil {
+		return entity.TeamUpsert{}, err
+	}
+	if requiredReviewers < 1 {
+		return entity.TeamUpsert{}, ErrInvalidRequiredReviewers
+	}
+	if reviewSLAHours < 1 {
+		return entity.TeamUpsert{}, ErrInvalidReviewSLA
+	}
+
+	var result entity.TeamUpsert
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		team, err := s.teamRepo.GetByName(ctx, teamName)
+		if errors.Is(err, repository.ErrTeamNotFound) {
+			team, err = s.teamRepo.Create(ctx, teamName, requiredReviewers, reviewSLAHours)
+			result.TeamCreated = true
+		}
+		if err != nil {
+			return err
+		}
+
+		var events []entity.DomainEvent
+		var toCreate []entity.User
+
+		for _, u := range lo.UniqBy(users, func(u entity.User) string { return u.ID }) {
+			existing, err := s.userRepo.GetByID(ctx, u.ID)
+			if errors.Is(err, repository.ErrUserNotFound) {
+				toCreate = append(toCreate, u)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			member := entity.MemberUpsert{User: existing, Status: entity.MemberUnchanged}
+
+			if existing.Team.ID != team.ID {
+				if existing.Team.ID != uuid.Nil {
+					if err := authorizeMembers(ctx, "UpsertTeamWithUsers", existing.Team.Name); err != nil {
+						return err
+					}
+				}
+
+				transfer, err := s.handOverReviews(ctx, existing, reassignReviews, "moved to team "+teamName)
+				if err != nil {
+					return err
+				}
+				if err := s.userRepo.SetTeamID(ctx, existing.ID, team.ID); err != nil {
+					return err
+				}
+				member.Status = entity.MemberMoved
+				member.Reassigned = transfer.Reassigned
+				member.KeptPRIDs = transfer.KeptPRIDs
+				member.PreviousTeam = existing.Team.Name
+				member.User.Team = team
+
+				events = append(events, entity.UserTeamChanged{
+					UserID:       existing.ID,
+					TeamName:     teamName,
+					PreviousTeam: existing.Team.Name,
+					Actor:        actor.FromContext(ctx),
+				})
+			}
+
+			if u.Name != "" && u.Name != existing.Name {
+				if err := s.userRepo.SetName(ctx, existing.ID, u.Name); err != nil {
+					return err
+				}
+				if member.Status == entity.MemberUnchanged {
+					member.Status = entity.MemberUpdated
+				}
+				member.User.Name = u.Name
+
+				events = append(events, entity.UserRenamed{
+					UserID:       existing.ID,
+					Name:         u.Name,
+					PreviousName: existing.Name,
+					Actor:        actor.FromContext(ctx),
+				})
+			}
+
+			result.Members = append(result.Members, member)
+		}
+
+		if len(toCreate) > 0 {
+			created, err := s.userRepo.CreateUsersBatch(ctx, toCreate, team.ID)
+			if err != nil {
+				return err
+			}
+			for _, u := range created {
+				result.Members = append(result.Members, entity.MemberUpsert{User: u, Status: entity.MemberCreated})
+				if !result.TeamCreated {
+					events = append(events, entity.UserTeamChanged{UserID: u.ID, TeamName: teamName, Actor: actor.FromContext(ctx)})
+				}
+			}
+		}
+
+		if result.TeamCreated {
+			// Members of a new team are announced with the team
+			events = append([]entity.DomainEvent{entity.TeamCreated{
+				TeamName:          teamName,
+				RequiredReviewers: requiredReviewers,
+				ReviewSLAHours:    reviewSLAHours,
+				MemberIDs:         lo.Map(result.Members, func(m entity.MemberUpsert, _ int) string { return m.User.ID }),
+				Actor:             actor.FromContext(ctx),
+			}}, events...)
+		}
+
+		result.Team = team
+		result.Team.Members, err = s.userRepo.GetByTeamID(ctx, team.ID)
+		if err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, events...)
+	})
+
+	if err != nil {
+		if errors.Is(err, repository.ErrTeamAlreadyExists) {
+			return entity.TeamUpsert{}, ErrTeamAlreadyExists
+		}
+		if errors.Is(err, repository.ErrUserAlreadyExists) {
+			return entity.TeamUpsert{}, ErrUserAlreadyExists
+		}
+		if errors.Is(err, ErrMembersForbidden) {
+			return entity.TeamUpsert{}, err
+		}
+		logrus.Errorf("TeamService.UpsertTeamWithUsers: failed to upsert team %s: %v", teamName, err)
+		return entity.TeamUpsert{}, ErrCannotCreateTeam
+	}
+
+	counts := lo.CountValuesBy(result.Members, func(m entity.MemberUpsert) entity.MemberUpsertStatus { return m.Status })
+	logrus.Infof("TeamService.UpsertTeamWithUsers: team %s upserted, %d users created, %d updated, %d moved",
+		teamName, counts[entity.MemberCreated], counts[entity.MemberUpdated], counts[entity.MemberMoved])
+	return result, nil
+}
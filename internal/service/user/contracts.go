@@ -2,8 +2,10 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/google/uuid"
 )
 
 //go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
@@ -11,8 +13,37 @@ import (
 type UserRepo interface {
 	GetByID(ctx context.Context, ID string) (entity.User, error)
 	SetActiveStatus(ctx context.Context, userID string, isActive bool) error
+	SetReviewWeight(ctx context.Context, userID string, weight float64) error
+	GetTags(ctx context.Context, userID string) ([]string, error)
+	AddTags(ctx context.Context, userID string, tags []string) error
+	RemoveTags(ctx context.Context, userID string, tags []string) error
 }
 
 type PullReqeustRepo interface {
 	ListByReviewer(ctx context.Context, reviewerID string) ([]entity.PullRequest, error)
 }
+
+// Reassigns reviews by the rules of PR service: team strategy, PR labels, PR state and buddy teams
+type PRService interface {
+	HandOverReview(ctx context.Context, prID, reviewerID, reason string, otherTeams bool) (string, error)
+}
+
+type UnavailabilityRepo interface {
+	Create(ctx context.Context, userID string, startsAt, endsAt time.Time, reason string) (entity.Unavailability, error)
+	ListByUser(ctx context.Context, userID string) ([]entity.Unavailability, error)
+	Delete(ctx context.Context, ID uuid.UUID) error
+}
+
+type HistoryRepo interface {
+	Create(ctx context.Context, events []entity.AssignmentEvent) error
+}
+
+// Outbox of webhook events, enqueued in the transaction of the change
+// Outbox of domain events
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...entity.DomainEvent) error
+}
+
+type ReviewRepo interface {
+	GetLatestByPRs(ctx context.Context, prIDs []string) ([]entity.Review, error)
+}
@@ -6,4 +6,15 @@ var (
 	ErrUserNotFound         = errors.New("user not found")
 	ErrCannotSetUserStatus  = errors.New("cannot set user status")
 	ErrCannotGetUserReviews = errors.New("cannot get user reviews")
+	ErrInvalidReviewWeight  = errors.New("review weight must be positive")
+	ErrCannotSetWeight      = errors.New("cannot set review weight")
+
+	ErrInvalidUnavailabilityPeriod = errors.New("unavailability period must end after it starts")
+	ErrUnavailabilityNotFound      = errors.New("unavailability period not found")
+	ErrCannotAddUnavailability     = errors.New("cannot add unavailability period")
+	ErrCannotGetUnavailability     = errors.New("cannot get unavailability periods")
+	ErrCannotDeleteUnavailability  = errors.New("cannot delete unavailability period")
+
+	ErrEmptyTags        = errors.New("at least one non-empty tag is required")
+	ErrCannotUpdateTags = errors.New("cannot update user tags")
 )
@@ -12,8 +12,10 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/4udiwe/avito-pr-service/internal/entity"
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +43,20 @@ func (m *MockUserRepo) EXPECT() *MockUserRepoMockRecorder {
 	return m.recorder
 }
 
+// AddTags mocks base method.
+func (m *MockUserRepo) AddTags(ctx context.Context, userID string, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTags", ctx, userID, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTags indicates an expected call of AddTags.
+func (mr *MockUserRepoMockRecorder) AddTags(ctx, userID, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTags", reflect.TypeOf((*MockUserRepo)(nil).AddTags), ctx, userID, tags)
+}
+
 // GetByID mocks base method.
 func (m *MockUserRepo) GetByID(ctx context.Context, ID string) (entity.User, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +72,35 @@ func (mr *MockUserRepoMockRecorder) GetByID(ctx, ID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepo)(nil).GetByID), ctx, ID)
 }
 
+// GetTags mocks base method.
+func (m *MockUserRepo) GetTags(ctx context.Context, userID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockUserRepoMockRecorder) GetTags(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockUserRepo)(nil).GetTags), ctx, userID)
+}
+
+// RemoveTags mocks base method.
+func (m *MockUserRepo) RemoveTags(ctx context.Context, userID string, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTags", ctx, userID, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTags indicates an expected call of RemoveTags.
+func (mr *MockUserRepoMockRecorder) RemoveTags(ctx, userID, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTags", reflect.TypeOf((*MockUserRepo)(nil).RemoveTags), ctx, userID, tags)
+}
+
 // SetActiveStatus mocks base method.
 func (m *MockUserRepo) SetActiveStatus(ctx context.Context, userID string, isActive bool) error {
 	m.ctrl.T.Helper()
@@ -70,6 +115,20 @@ func (mr *MockUserRepoMockRecorder) SetActiveStatus(ctx, userID, isActive any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActiveStatus", reflect.TypeOf((*MockUserRepo)(nil).SetActiveStatus), ctx, userID, isActive)
 }
 
+// SetReviewWeight mocks base method.
+func (m *MockUserRepo) SetReviewWeight(ctx context.Context, userID string, weight float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReviewWeight", ctx, userID, weight)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReviewWeight indicates an expected call of SetReviewWeight.
+func (mr *MockUserRepoMockRecorder) SetReviewWeight(ctx, userID, weight any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReviewWeight", reflect.TypeOf((*MockUserRepo)(nil).SetReviewWeight), ctx, userID, weight)
+}
+
 // MockPullReqeustRepo is a mock of PullReqeustRepo interface.
 type MockPullReqeustRepo struct {
 	ctrl     *gomock.Controller
@@ -108,3 +167,230 @@ func (mr *MockPullReqeustRepoMockRecorder) ListByReviewer(ctx, reviewerID any) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByReviewer", reflect.TypeOf((*MockPullReqeustRepo)(nil).ListByReviewer), ctx, reviewerID)
 }
+
+// MockPRService is a mock of PRService interface.
+type MockPRService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPRServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPRServiceMockRecorder is the mock recorder for MockPRService.
+type MockPRServiceMockRecorder struct {
+	mock *MockPRService
+}
+
+// NewMockPRService creates a new mock instance.
+func NewMockPRService(ctrl *gomock.Controller) *MockPRService {
+	mock := &MockPRService{ctrl: ctrl}
+	mock.recorder = &MockPRServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPRService) EXPECT() *MockPRServiceMockRecorder {
+	return m.recorder
+}
+
+// HandOverReview mocks base method.
+func (m *MockPRService) HandOverReview(ctx context.Context, prID, reviewerID, reason string, otherTeams bool) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandOverReview", ctx, prID, reviewerID, reason, otherTeams)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandOverReview indicates an expected call of HandOverReview.
+func (mr *MockPRServiceMockRecorder) HandOverReview(ctx, prID, reviewerID, reason, otherTeams any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandOverReview", reflect.TypeOf((*MockPRService)(nil).HandOverReview), ctx, prID, reviewerID, reason, otherTeams)
+}
+
+// MockUnavailabilityRepo is a mock of UnavailabilityRepo interface.
+type MockUnavailabilityRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnavailabilityRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockUnavailabilityRepoMockRecorder is the mock recorder for MockUnavailabilityRepo.
+type MockUnavailabilityRepoMockRecorder struct {
+	mock *MockUnavailabilityRepo
+}
+
+// NewMockUnavailabilityRepo creates a new mock instance.
+func NewMockUnavailabilityRepo(ctrl *gomock.Controller) *MockUnavailabilityRepo {
+	mock := &MockUnavailabilityRepo{ctrl: ctrl}
+	mock.recorder = &MockUnavailabilityRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnavailabilityRepo) EXPECT() *MockUnavailabilityRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUnavailabilityRepo) Create(ctx context.Context, userID string, startsAt, endsAt time.Time, reason string) (entity.Unavailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, startsAt, endsAt, reason)
+	ret0, _ := ret[0].(entity.Unavailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUnavailabilityRepoMockRecorder) Create(ctx, userID, startsAt, endsAt, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUnavailabilityRepo)(nil).Create), ctx, userID, startsAt, endsAt, reason)
+}
+
+// Delete mocks base method.
+func (m *MockUnavailabilityRepo) Delete(ctx context.Context, ID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUnavailabilityRepoMockRecorder) Delete(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUnavailabilityRepo)(nil).Delete), ctx, ID)
+}
+
+// ListByUser mocks base method.
+func (m *MockUnavailabilityRepo) ListByUser(ctx context.Context, userID string) ([]entity.Unavailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, userID)
+	ret0, _ := ret[0].([]entity.Unavailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockUnavailabilityRepoMockRecorder) ListByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockUnavailabilityRepo)(nil).ListByUser), ctx, userID)
+}
+
+// MockHistoryRepo is a mock of HistoryRepo interface.
+type MockHistoryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockHistoryRepoMockRecorder is the mock recorder for MockHistoryRepo.
+type MockHistoryRepoMockRecorder struct {
+	mock *MockHistoryRepo
+}
+
+// NewMockHistoryRepo creates a new mock instance.
+func NewMockHistoryRepo(ctrl *gomock.Controller) *MockHistoryRepo {
+	mock := &MockHistoryRepo{ctrl: ctrl}
+	mock.recorder = &MockHistoryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryRepo) EXPECT() *MockHistoryRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockHistoryRepo) Create(ctx context.Context, events []entity.AssignmentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHistoryRepoMockRecorder) Create(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHistoryRepo)(nil).Create), ctx, events)
+}
+
+// MockEventPublisher is a mock of EventPublisher interface.
+type MockEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockEventPublisherMockRecorder is the mock recorder for MockEventPublisher.
+type MockEventPublisherMockRecorder struct {
+	mock *MockEventPublisher
+}
+
+// NewMockEventPublisher creates a new mock instance.
+func NewMockEventPublisher(ctrl *gomock.Controller) *MockEventPublisher {
+	mock := &MockEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPublisher) EXPECT() *MockEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventPublisher) Publish(ctx context.Context, events ...entity.DomainEvent) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Publish", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventPublisherMockRecorder) Publish(ctx any, events ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventPublisher)(nil).Publish), varargs...)
+}
+
+// MockReviewRepo is a mock of ReviewRepo interface.
+type MockReviewRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockReviewRepoMockRecorder is the mock recorder for MockReviewRepo.
+type MockReviewRepoMockRecorder struct {
+	mock *MockReviewRepo
+}
+
+// NewMockReviewRepo creates a new mock instance.
+func NewMockReviewRepo(ctrl *gomock.Controller) *MockReviewRepo {
+	mock := &MockReviewRepo{ctrl: ctrl}
+	mock.recorder = &MockReviewRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewRepo) EXPECT() *MockReviewRepoMockRecorder {
+	return m.recorder
+}
+
+// GetLatestByPRs mocks base method.
+func (m *MockReviewRepo) GetLatestByPRs(ctx context.Context, prIDs []string) ([]entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestByPRs", ctx, prIDs)
+	ret0, _ := ret[0].([]entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestByPRs indicates an expected call of GetLatestByPRs.
+func (mr *MockReviewRepoMockRecorder) GetLatestByPRs(ctx, prIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestByPRs", reflect.TypeOf((*MockReviewRepo)(nil).GetLatestByPRs), ctx, prIDs)
+}
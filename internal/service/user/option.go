@@ -0,0 +1,10 @@
+package user
+
+type Option func(*Service)
+
+// WithEvents enables publishing of domain events for user changes and reviews reassigned on deactivation
+func WithEvents(publisher EventPublisher) Option {
+	return func(s *Service) {
+		s.publisher = publisher
+	}
+}
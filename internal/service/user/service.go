@@ -3,52 +3,160 @@ package user
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/4udiwe/avito-pr-service/internal/entity"
 	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/4udiwe/avito-pr-service/internal/service/events"
+	"github.com/4udiwe/avito-pr-service/pkg/actor"
 	"github.com/4udiwe/avito-pr-service/pkg/transactor"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 )
 
 type Service struct {
-	userRepo  UserRepo
-	PRRepo    PullReqeustRepo
-	txManager transactor.Transactor
+	userRepo           UserRepo
+	PRRepo             PullReqeustRepo
+	unavailabilityRepo UnavailabilityRepo
+	historyRepo        HistoryRepo
+	reviewRepo         ReviewRepo
+	prService          PRService
+	txManager          transactor.Transactor
+
+	// Optional outbox of domain events
+	publisher EventPublisher
+	// Writes assignment history and domain events
+	events *events.Recorder
 }
 
-func New(userRepo UserRepo, PRRepo PullReqeustRepo, txManager transactor.Transactor) *Service {
-	return &Service{
-		userRepo:  userRepo,
-		PRRepo:    PRRepo,
-		txManager: txManager,
+func New(
+	userRepo UserRepo,
+	PRRepo PullReqeustRepo,
+	unavailabilityRepo UnavailabilityRepo,
+	historyRepo HistoryRepo,
+	reviewRepo ReviewRepo,
+	prService PRService,
+	txManager transactor.Transactor,
+	opts ...Option,
+) *Service {
+	s := &Service{
+		userRepo:           userRepo,
+		PRRepo:             PRRepo,
+		unavailabilityRepo: unavailabilityRepo,
+		historyRepo:        historyRepo,
+		reviewRepo:         reviewRepo,
+		prService:          prService,
+		txManager:          txManager,
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
+
+	s.events = events.NewRecorder(historyRepo, s.publisher)
+
+	return s
 }
 
-func (s *Service) SetUserStatus(ctx context.Context, userID string, isActive bool) (entity.User, error) {
+// Sets user's active status. On deactivation user's reviews of open PRs are moved
+// to active teammates, falling back to active users of other teams
+func (s *Service) SetUserStatus(ctx context.Context, userID string, isActive bool) (entity.User, []entity.Reassignment, error) {
 	logrus.Infof("UserService.SetUserStatus: setting user %s active status to %v", userID, isActive)
 
-	err := s.userRepo.SetActiveStatus(ctx, userID, isActive)
+	var user entity.User
+	var reassignments []entity.Reassignment
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := s.userRepo.SetActiveStatus(ctx, userID, isActive)
+		if err != nil {
+			return err
+		}
+
+		user, err = s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		err = s.events.Publish(ctx, entity.UserStatusChanged{
+			UserID:   userID,
+			IsActive: isActive,
+			Actor:    actor.FromContext(ctx),
+		})
+		if err != nil || isActive {
+			return err
+		}
+
+		reassignments, err = s.reassignReviews(ctx, user)
+		return err
+	})
 
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
-			return entity.User{}, ErrUserNotFound
+			return entity.User{}, nil, ErrUserNotFound
 		}
 		logrus.Errorf("UserService.SetUserStatus: failed to set active status for user %s: %v", userID, err)
-		return entity.User{}, ErrCannotSetUserStatus
+		return entity.User{}, nil, ErrCannotSetUserStatus
 	}
 
-	user, err := s.userRepo.GetByID(ctx, userID)
+	logrus.Infof("UserService.SetUserStatus: user %s active status set to %v, %d reviews reassigned", userID, isActive, len(reassignments))
+	return user, reassignments, nil
+}
 
+// Moves reviews of open PRs from deactivated user to other reviewers, one by one. PR service picks
+// a teammate by the team's rules, then a reviewer from buddy teams or other teams
+func (s *Service) reassignReviews(ctx context.Context, user entity.User) ([]entity.Reassignment, error) {
+	prs, err := s.PRRepo.ListByReviewer(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	reassignments := make([]entity.Reassignment, 0, len(prs))
+
+	for _, pr := range prs {
+		if !entity.ActionReassignReviewer.Allowed(pr.Status.Name) {
+			continue
+		}
+
+		newReviewerID, err := s.prService.HandOverReview(ctx, pr.ID, user.ID, "user deactivated", true)
+		if err != nil {
+			return nil, err
+		}
+
+		reassignments = append(reassignments, entity.Reassignment{PRID: pr.ID, OldReviewerID: user.ID, NewReviewerID: newReviewerID})
+	}
+
+	return reassignments, nil
+}
+
+// Sets user's weight for the weighted reviewer strategy (default weight is 1)
+func (s *Service) SetReviewWeight(ctx context.Context, userID string, weight float64) error {
+	logrus.Infof("UserService.SetReviewWeight: setting user %s review weight to %v", userID, weight)
+
+	if weight <= 0 {
+		return ErrInvalidReviewWeight
+	}
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.SetReviewWeight(ctx, userID, weight); err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, entity.UserReviewWeightChanged{
+			UserID: userID,
+			Weight: weight,
+			Actor:  actor.FromContext(ctx),
+		})
+	})
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
-			return entity.User{}, ErrUserNotFound
+			return ErrUserNotFound
 		}
-		logrus.Errorf("UserService.SetUserStatus: failed to get user %s after status update: %v", userID, err)
-		return entity.User{}, ErrCannotSetUserStatus
+		logrus.Errorf("UserService.SetReviewWeight: failed to set review weight for user %s: %v", userID, err)
+		return ErrCannotSetWeight
 	}
 
-	logrus.Infof("UserService.SetUserStatus: user %s active status set to %v", userID, isActive)
-	return user, nil
+	return nil
 }
 
 func (s *Service) GetUserReviews(ctx context.Context, userID string) ([]entity.PullRequest, error) {
@@ -65,7 +173,21 @@ func (s *Service) GetUserReviews(ctx context.Context, userID string) ([]entity.P
 
 		// Fetch PRs assigned to the user as a reviewer
 		prs, err = s.PRRepo.ListByReviewer(ctx, userID)
-		return err
+		if err != nil {
+			return err
+		}
+
+		// Attach latest decisions of the reviewers
+		reviews, err := s.reviewRepo.GetLatestByPRs(ctx, lo.Map(prs, func(pr entity.PullRequest, _ int) string { return pr.ID }))
+		if err != nil {
+			return err
+		}
+
+		byPR := lo.GroupBy(reviews, func(r entity.Review) string { return r.PRID })
+		for i := range prs {
+			prs[i].Reviews = byPR[prs[i].ID]
+		}
+		return nil
 	})
 
 	if err != nil {
@@ -79,3 +201,162 @@ func (s *Service) GetUserReviews(ctx context.Context, userID string) ([]entity.P
 	logrus.Infof("UserService.GetUserReviews: fetched %d PRs for user %s", len(prs), userID)
 	return prs, nil
 }
+
+// Adds a period when user is away. While the period lasts, user is not picked as a reviewer,
+// but keeps the is_active flag and already assigned reviews
+func (s *Service) AddUnavailability(
+	ctx context.Context,
+	userID string,
+	startsAt, endsAt time.Time,
+	reason string,
+) (entity.Unavailability, error) {
+	logrus.Infof("UserService.AddUnavailability: adding unavailability for user %s from %s to %s", userID, startsAt, endsAt)
+
+	if !endsAt.After(startsAt) {
+		return entity.Unavailability{}, ErrInvalidUnavailabilityPeriod
+	}
+
+	var period entity.Unavailability
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		period, err = s.unavailabilityRepo.Create(ctx, userID, startsAt, endsAt, reason)
+		if err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, entity.UserUnavailabilityAdded{
+			ID:       period.ID,
+			UserID:   userID,
+			StartsAt: period.StartsAt,
+			EndsAt:   period.EndsAt,
+			Reason:   period.Reason,
+			Actor:    actor.FromContext(ctx),
+		})
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return entity.Unavailability{}, ErrUserNotFound
+		}
+		logrus.Errorf("UserService.AddUnavailability: failed to add unavailability for user %s: %v", userID, err)
+		return entity.Unavailability{}, ErrCannotAddUnavailability
+	}
+
+	logrus.Infof("UserService.AddUnavailability: unavailability %s added for user %s", period.ID, userID)
+	return period, nil
+}
+
+func (s *Service) GetUnavailability(ctx context.Context, userID string) ([]entity.Unavailability, error) {
+	logrus.Infof("UserService.GetUnavailability: fetching unavailability for user %s", userID)
+
+	var periods []entity.Unavailability
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Check if user exists
+		_, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		periods, err = s.unavailabilityRepo.ListByUser(ctx, userID)
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		logrus.Errorf("UserService.GetUnavailability: failed to list unavailability for user %s: %v", userID, err)
+		return nil, ErrCannotGetUnavailability
+	}
+
+	logrus.Infof("UserService.GetUnavailability: fetched %d periods for user %s", len(periods), userID)
+	return periods, nil
+}
+
+func (s *Service) DeleteUnavailability(ctx context.Context, ID uuid.UUID) error {
+	logrus.Infof("UserService.DeleteUnavailability: deleting unavailability %s", ID)
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.unavailabilityRepo.Delete(ctx, ID); err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, entity.UserUnavailabilityDeleted{
+			ID:    ID,
+			Actor: actor.FromContext(ctx),
+		})
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrUnavailabilityNotFound) {
+			return ErrUnavailabilityNotFound
+		}
+		logrus.Errorf("UserService.DeleteUnavailability: failed to delete unavailability %s: %v", ID, err)
+		return ErrCannotDeleteUnavailability
+	}
+
+	logrus.Infof("UserService.DeleteUnavailability: unavailability %s deleted", ID)
+	return nil
+}
+
+// Adds expertise tags to user, returns all user's tags. Tags are matched against PR labels on assignment
+func (s *Service) AddTags(ctx context.Context, userID string, tags []string) ([]string, error) {
+	logrus.Infof("UserService.AddTags: adding tags %+v to user %s", tags, userID)
+
+	return s.updateTags(ctx, userID, tags, s.userRepo.AddTags)
+}
+
+// Removes tags from user, returns remaining user's tags. Missing tags are ignored
+func (s *Service) RemoveTags(ctx context.Context, userID string, tags []string) ([]string, error) {
+	logrus.Infof("UserService.RemoveTags: removing tags %+v from user %s", tags, userID)
+
+	return s.updateTags(ctx, userID, tags, s.userRepo.RemoveTags)
+}
+
+func (s *Service) updateTags(
+	ctx context.Context,
+	userID string,
+	tags []string,
+	update func(ctx context.Context, userID string, tags []string) error,
+) ([]string, error) {
+	tags = entity.NormalizeTags(tags)
+	if len(tags) == 0 {
+		return nil, ErrEmptyTags
+	}
+
+	var userTags []string
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Check if user exists
+		_, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := update(ctx, userID, tags); err != nil {
+			return err
+		}
+
+		userTags, err = s.userRepo.GetTags(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		return s.events.Publish(ctx, entity.UserTagsChanged{
+			UserID: userID,
+			Tags:   userTags,
+			Actor:  actor.FromContext(ctx),
+		})
+	})
+
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		logrus.Errorf("UserService.updateTags: failed to update tags for user %s: %v", userID, err)
+		return nil, ErrCannotUpdateTags
+	}
+
+	logrus.Infof("UserService.updateTags: user %s has tags %+v", userID, userTags)
+	return userTags, nil
+}
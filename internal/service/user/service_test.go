@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/4udiwe/avito-pr-service/internal/entity"
 	mock_transactor "github.com/4udiwe/avito-pr-service/internal/mocks"
 	"github.com/4udiwe/avito-pr-service/internal/repository"
 	service "github.com/4udiwe/avito-pr-service/internal/service/user"
 	"github.com/4udiwe/avito-pr-service/internal/service/user/mocks"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -18,6 +20,7 @@ func TestSetUserStatus(t *testing.T) {
 	var (
 		ctx          = context.Background()
 		userID       = "user123"
+		teamID       = uuid.New()
 		arbitraryErr = errors.New("arbitrary error")
 	)
 
@@ -25,42 +28,106 @@ func TestSetUserStatus(t *testing.T) {
 		ID:       userID,
 		Name:     "John",
 		IsActive: true,
+		Team:     entity.Team{ID: teamID, Name: "backend"},
 	}
 
+	inactiveUser := mockUser
+	inactiveUser.IsActive = false
+
+	openPR := entity.PullRequest{ID: "pr1", AuthorID: "author", Status: entity.Status{Name: entity.StatusOPEN}}
+	mergedPR := entity.PullRequest{ID: "pr2", AuthorID: "author", Status: entity.Status{Name: entity.StatusMERGED}}
+
 	type MockBehavior func(
 		u *mocks.MockUserRepo,
+		p *mocks.MockPullReqeustRepo,
+		ps *mocks.MockPRService,
 	)
 
 	for _, tc := range []struct {
-		name         string
-		userID       string
-		isActive     bool
-		mockBehavior MockBehavior
-		want         entity.User
-		wantErr      error
+		name              string
+		userID            string
+		isActive          bool
+		mockBehavior      MockBehavior
+		want              entity.User
+		wantReassignments []entity.Reassignment
+		wantErr           error
 	}{
 		{
-			name:     "success",
+			name:     "success without reviews",
+			userID:   userID,
+			isActive: false,
+			mockBehavior: func(u *mocks.MockUserRepo, p *mocks.MockPullReqeustRepo, ps *mocks.MockPRService) {
+				u.EXPECT().SetActiveStatus(ctx, userID, false).Return(nil).Times(1)
+				u.EXPECT().GetByID(ctx, userID).Return(inactiveUser, nil).Times(1)
+				p.EXPECT().ListByReviewer(ctx, userID).Return(nil, nil).Times(1)
+			},
+			want:              inactiveUser,
+			wantReassignments: []entity.Reassignment{},
+			wantErr:           nil,
+		},
+		{
+			name:     "activation does not reassign",
+			userID:   userID,
+			isActive: true,
+			mockBehavior: func(u *mocks.MockUserRepo, p *mocks.MockPullReqeustRepo, ps *mocks.MockPRService) {
+				u.EXPECT().SetActiveStatus(ctx, userID, true).Return(nil).Times(1)
+				u.EXPECT().GetByID(ctx, userID).Return(mockUser, nil).Times(1)
+			},
+			want:              mockUser,
+			wantReassignments: nil,
+			wantErr:           nil,
+		},
+		{
+			name:     "open review moved to teammate, merged skipped",
+			userID:   userID,
+			isActive: false,
+			mockBehavior: func(u *mocks.MockUserRepo, p *mocks.MockPullReqeustRepo, ps *mocks.MockPRService) {
+				u.EXPECT().SetActiveStatus(ctx, userID, false).Return(nil).Times(1)
+				u.EXPECT().GetByID(ctx, userID).Return(inactiveUser, nil).Times(1)
+				p.EXPECT().ListByReviewer(ctx, userID).Return([]entity.PullRequest{openPR, mergedPR}, nil).Times(1)
+				ps.EXPECT().HandOverReview(ctx, "pr1", userID, "user deactivated", true).Return("u3", nil).Times(1)
+			},
+			want: inactiveUser,
+			wantReassignments: []entity.Reassignment{
+				{PRID: "pr1", OldReviewerID: userID, NewReviewerID: "u3"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:     "no candidates at all",
 			userID:   userID,
 			isActive: false,
-			mockBehavior: func(u *mocks.MockUserRepo) {
+			mockBehavior: func(u *mocks.MockUserRepo, p *mocks.MockPullReqeustRepo, ps *mocks.MockPRService) {
 				u.EXPECT().SetActiveStatus(ctx, userID, false).Return(nil).Times(1)
-				expectedUser := mockUser
-				expectedUser.IsActive = false
-				u.EXPECT().GetByID(ctx, userID).Return(expectedUser, nil).Times(1)
-			},
-			want: func() entity.User {
-				u := mockUser
-				u.IsActive = false
-				return u
-			}(),
+				u.EXPECT().GetByID(ctx, userID).Return(inactiveUser, nil).Times(1)
+				p.EXPECT().ListByReviewer(ctx, userID).Return([]entity.PullRequest{openPR}, nil).Times(1)
+				ps.EXPECT().HandOverReview(ctx, "pr1", userID, "user deactivated", true).Return("", nil).Times(1)
+			},
+			want: inactiveUser,
+			wantReassignments: []entity.Reassignment{
+				{PRID: "pr1", OldReviewerID: userID},
+			},
 			wantErr: nil,
 		},
+		{
+			name:     "reassign error",
+			userID:   userID,
+			isActive: false,
+			mockBehavior: func(u *mocks.MockUserRepo, p *mocks.MockPullReqeustRepo, ps *mocks.MockPRService) {
+				u.EXPECT().SetActiveStatus(ctx, userID, false).Return(nil).Times(1)
+				u.EXPECT().GetByID(ctx, userID).Return(inactiveUser, nil).Times(1)
+				p.EXPECT().ListByReviewer(ctx, userID).Return([]entity.PullRequest{openPR}, nil).Times(1)
+				ps.EXPECT().HandOverReview(ctx, "pr1", userID, "user deactivated", true).Return("", arbitraryErr).Times(1)
+			},
+			want:              entity.User{},
+			wantReassignments: nil,
+			wantErr:           service.ErrCannotSetUserStatus,
+		},
 		{
 			name:     "user not found on status update",
 			userID:   userID,
 			isActive: false,
-			mockBehavior: func(u *mocks.MockUserRepo) {
+			mockBehavior: func(u *mocks.MockUserRepo, p *mocks.MockPullReqeustRepo, ps *mocks.MockPRService) {
 				u.EXPECT().SetActiveStatus(ctx, userID, false).Return(repository.ErrUserNotFound).Times(1)
 			},
 			want:    entity.User{},
@@ -70,7 +137,7 @@ func TestSetUserStatus(t *testing.T) {
 			name:     "internal error on status update",
 			userID:   userID,
 			isActive: true,
-			mockBehavior: func(u *mocks.MockUserRepo) {
+			mockBehavior: func(u *mocks.MockUserRepo, p *mocks.MockPullReqeustRepo, ps *mocks.MockPRService) {
 				u.EXPECT().SetActiveStatus(ctx, userID, true).Return(arbitraryErr).Times(1)
 			},
 			want:    entity.User{},
@@ -80,7 +147,7 @@ func TestSetUserStatus(t *testing.T) {
 			name:     "user not found on GetByID",
 			userID:   userID,
 			isActive: true,
-			mockBehavior: func(u *mocks.MockUserRepo) {
+			mockBehavior: func(u *mocks.MockUserRepo, p *mocks.MockPullReqeustRepo, ps *mocks.MockPRService) {
 				u.EXPECT().SetActiveStatus(ctx, userID, true).Return(nil).Times(1)
 				u.EXPECT().GetByID(ctx, userID).Return(entity.User{}, repository.ErrUserNotFound).Times(1)
 			},
@@ -91,7 +158,7 @@ func TestSetUserStatus(t *testing.T) {
 			name:     "internal error on GetByID",
 			userID:   userID,
 			isActive: true,
-			mockBehavior: func(u *mocks.MockUserRepo) {
+			mockBehavior: func(u *mocks.MockUserRepo, p *mocks.MockPullReqeustRepo, ps *mocks.MockPRService) {
 				u.EXPECT().SetActiveStatus(ctx, userID, true).Return(nil).Times(1)
 				u.EXPECT().GetByID(ctx, userID).Return(entity.User{}, arbitraryErr).Times(1)
 			},
@@ -108,15 +175,23 @@ func TestSetUserStatus(t *testing.T) {
 			MockUserRepo := mocks.NewMockUserRepo(ctrl)
 			MockPRRepo := mocks.NewMockPullReqeustRepo(ctrl)
 			MockTransactor := mock_transactor.NewMockTransactor(ctrl)
+			MockHistoryRepo := mocks.NewMockHistoryRepo(ctrl)
+			MockPRService := mocks.NewMockPRService(ctrl)
+
+			MockTransactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
 
-			tc.mockBehavior(MockUserRepo)
+			tc.mockBehavior(MockUserRepo, MockPRRepo, MockPRService)
 
-			s := service.New(MockUserRepo, MockPRRepo, MockTransactor)
+			s := service.New(MockUserRepo, MockPRRepo, nil, MockHistoryRepo, nil, MockPRService, MockTransactor)
 
-			out, err := s.SetUserStatus(ctx, tc.userID, tc.isActive)
+			out, reassignments, err := s.SetUserStatus(ctx, tc.userID, tc.isActive)
 
 			assert.ErrorIs(t, err, tc.wantErr)
 			assert.Equal(t, tc.want, out)
+			assert.Equal(t, tc.wantReassignments, reassignments)
 		})
 	}
 }
@@ -138,6 +213,13 @@ func TestGetUserReviews(t *testing.T) {
 		{ID: "2", AuthorID: "a2", Reviewers: mockReviewers},
 	}
 
+	approved := entity.Review{PRID: "1", ReviewerID: userID, State: entity.ReviewApproved}
+
+	reviewedPRs := []entity.PullRequest{
+		{ID: "1", AuthorID: "a1", Reviewers: mockReviewers, Reviews: []entity.Review{approved}},
+		{ID: "2", AuthorID: "a2", Reviewers: mockReviewers},
+	}
+
 	arbitraryErr := errors.New("unexpected failure")
 
 	tests := []struct {
@@ -145,6 +227,7 @@ func TestGetUserReviews(t *testing.T) {
 		setup func(
 			u *mocks.MockUserRepo,
 			p *mocks.MockPullReqeustRepo,
+			r *mocks.MockReviewRepo,
 			tx *mock_transactor.MockTransactor,
 		)
 		expectedPRs []entity.PullRequest
@@ -155,6 +238,7 @@ func TestGetUserReviews(t *testing.T) {
 			setup: func(
 				u *mocks.MockUserRepo,
 				p *mocks.MockPullReqeustRepo,
+				r *mocks.MockReviewRepo,
 				tx *mock_transactor.MockTransactor,
 			) {
 				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
@@ -167,9 +251,13 @@ func TestGetUserReviews(t *testing.T) {
 
 				p.EXPECT().
 					ListByReviewer(gomock.Any(), userID).
-					Return(mockPRs, nil)
+					Return(append([]entity.PullRequest{}, mockPRs...), nil)
+
+				r.EXPECT().
+					GetLatestByPRs(gomock.Any(), []string{"1", "2"}).
+					Return([]entity.Review{approved}, nil)
 			},
-			expectedPRs: mockPRs,
+			expectedPRs: reviewedPRs,
 			expectedErr: nil,
 		},
 		{
@@ -177,6 +265,7 @@ func TestGetUserReviews(t *testing.T) {
 			setup: func(
 				u *mocks.MockUserRepo,
 				p *mocks.MockPullReqeustRepo,
+				r *mocks.MockReviewRepo,
 				tx *mock_transactor.MockTransactor,
 			) {
 				tx.EXPECT().
@@ -197,6 +286,7 @@ func TestGetUserReviews(t *testing.T) {
 			setup: func(
 				u *mocks.MockUserRepo,
 				p *mocks.MockPullReqeustRepo,
+				r *mocks.MockReviewRepo,
 				tx *mock_transactor.MockTransactor,
 			) {
 				tx.EXPECT().
@@ -224,11 +314,12 @@ func TestGetUserReviews(t *testing.T) {
 
 			mockUserRepo := mocks.NewMockUserRepo(ctrl)
 			mockPRRepo := mocks.NewMockPullReqeustRepo(ctrl)
+			mockReviewRepo := mocks.NewMockReviewRepo(ctrl)
 			mockTx := mock_transactor.NewMockTransactor(ctrl)
 
-			tt.setup(mockUserRepo, mockPRRepo, mockTx)
+			tt.setup(mockUserRepo, mockPRRepo, mockReviewRepo, mockTx)
 
-			s := service.New(mockUserRepo, mockPRRepo, mockTx)
+			s := service.New(mockUserRepo, mockPRRepo, nil, nil, mockReviewRepo, nil, mockTx)
 
 			out, err := s.GetUserReviews(ctx, userID)
 
@@ -237,3 +328,287 @@ func TestGetUserReviews(t *testing.T) {
 		})
 	}
 }
+
+func TestSetReviewWeight(t *testing.T) {
+	ctx := context.Background()
+	userID := "u1"
+
+	tests := []struct {
+		name        string
+		weight      float64
+		setup       func(u *mocks.MockUserRepo)
+		expectedErr error
+	}{
+		{
+			name:        "non-positive weight",
+			weight:      0,
+			setup:       func(u *mocks.MockUserRepo) {},
+			expectedErr: service.ErrInvalidReviewWeight,
+		},
+		{
+			name:   "user not found",
+			weight: 2,
+			setup: func(u *mocks.MockUserRepo) {
+				u.EXPECT().SetReviewWeight(gomock.Any(), userID, 2.0).Return(repository.ErrUserNotFound)
+			},
+			expectedErr: service.ErrUserNotFound,
+		},
+		{
+			name:   "repo error",
+			weight: 2,
+			setup: func(u *mocks.MockUserRepo) {
+				u.EXPECT().SetReviewWeight(gomock.Any(), userID, 2.0).Return(errors.New("db"))
+			},
+			expectedErr: service.ErrCannotSetWeight,
+		},
+		{
+			name:   "success",
+			weight: 0.5,
+			setup: func(u *mocks.MockUserRepo) {
+				u.EXPECT().SetReviewWeight(gomock.Any(), userID, 0.5).Return(nil)
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			mockPRRepo := mocks.NewMockPullReqeustRepo(ctrl)
+
+			mockTx := mock_transactor.NewMockTransactor(ctrl)
+			mockTx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			).AnyTimes()
+
+			tt.setup(mockUserRepo)
+
+			s := service.New(mockUserRepo, mockPRRepo, nil, nil, nil, nil, mockTx)
+
+			err := s.SetReviewWeight(ctx, userID, tt.weight)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestAddUnavailability(t *testing.T) {
+	ctx := context.Background()
+	userID := "u1"
+	startsAt := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	endsAt := startsAt.Add(14 * 24 * time.Hour)
+
+	mockPeriod := entity.Unavailability{
+		ID:       uuid.New(),
+		UserID:   userID,
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+		Reason:   "vacation",
+	}
+
+	tests := []struct {
+		name        string
+		startsAt    time.Time
+		endsAt      time.Time
+		setup       func(u *mocks.MockUnavailabilityRepo)
+		expected    entity.Unavailability
+		expectedErr error
+	}{
+		{
+			name:        "ends before start",
+			startsAt:    endsAt,
+			endsAt:      startsAt,
+			setup:       func(u *mocks.MockUnavailabilityRepo) {},
+			expected:    entity.Unavailability{},
+			expectedErr: service.ErrInvalidUnavailabilityPeriod,
+		},
+		{
+			name:     "user not found",
+			startsAt: startsAt,
+			endsAt:   endsAt,
+			setup: func(u *mocks.MockUnavailabilityRepo) {
+				u.EXPECT().Create(gomock.Any(), userID, startsAt, endsAt, "vacation").
+					Return(entity.Unavailability{}, repository.ErrUserNotFound)
+			},
+			expected:    entity.Unavailability{},
+			expectedErr: service.ErrUserNotFound,
+		},
+		{
+			name:     "repo error",
+			startsAt: startsAt,
+			endsAt:   endsAt,
+			setup: func(u *mocks.MockUnavailabilityRepo) {
+				u.EXPECT().Create(gomock.Any(), userID, startsAt, endsAt, "vacation").
+					Return(entity.Unavailability{}, errors.New("db"))
+			},
+			expected:    entity.Unavailability{},
+			expectedErr: service.ErrCannotAddUnavailability,
+		},
+		{
+			name:     "success",
+			startsAt: startsAt,
+			endsAt:   endsAt,
+			setup: func(u *mocks.MockUnavailabilityRepo) {
+				u.EXPECT().Create(gomock.Any(), userID, startsAt, endsAt, "vacation").
+					Return(mockPeriod, nil)
+			},
+			expected:    mockPeriod,
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUnavailabilityRepo := mocks.NewMockUnavailabilityRepo(ctrl)
+
+			mockTx := mock_transactor.NewMockTransactor(ctrl)
+			mockTx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			).AnyTimes()
+
+			tt.setup(mockUnavailabilityRepo)
+
+			s := service.New(nil, nil, mockUnavailabilityRepo, nil, nil, nil, mockTx)
+
+			out, err := s.AddUnavailability(ctx, userID, tt.startsAt, tt.endsAt, "vacation")
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestDeleteUnavailability(t *testing.T) {
+	ctx := context.Background()
+	ID := uuid.New()
+
+	tests := []struct {
+		name        string
+		repoErr     error
+		expectedErr error
+	}{
+		{
+			name:        "success",
+			repoErr:     nil,
+			expectedErr: nil,
+		},
+		{
+			name:        "not found",
+			repoErr:     repository.ErrUnavailabilityNotFound,
+			expectedErr: service.ErrUnavailabilityNotFound,
+		},
+		{
+			name:        "repo error",
+			repoErr:     errors.New("db"),
+			expectedErr: service.ErrCannotDeleteUnavailability,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUnavailabilityRepo := mocks.NewMockUnavailabilityRepo(ctrl)
+			mockTx := mock_transactor.NewMockTransactor(ctrl)
+			mockTx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+			)
+			mockUnavailabilityRepo.EXPECT().Delete(gomock.Any(), ID).Return(tt.repoErr)
+
+			s := service.New(nil, nil, mockUnavailabilityRepo, nil, nil, nil, mockTx)
+
+			err := s.DeleteUnavailability(ctx, ID)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestAddTags(t *testing.T) {
+	ctx := context.Background()
+	userID := "u1"
+
+	tests := []struct {
+		name        string
+		tags        []string
+		setup       func(u *mocks.MockUserRepo, tx *mock_transactor.MockTransactor)
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name:        "only blank tags",
+			tags:        []string{" ", ""},
+			setup:       func(u *mocks.MockUserRepo, tx *mock_transactor.MockTransactor) {},
+			expected:    nil,
+			expectedErr: service.ErrEmptyTags,
+		},
+		{
+			name: "user not found",
+			tags: []string{"go"},
+			setup: func(u *mocks.MockUserRepo, tx *mock_transactor.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				u.EXPECT().GetByID(gomock.Any(), userID).Return(entity.User{}, repository.ErrUserNotFound)
+			},
+			expected:    nil,
+			expectedErr: service.ErrUserNotFound,
+		},
+		{
+			name: "tags are normalized",
+			tags: []string{"Go", " postgres ", "go"},
+			setup: func(u *mocks.MockUserRepo, tx *mock_transactor.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				u.EXPECT().GetByID(gomock.Any(), userID).Return(entity.User{ID: userID}, nil)
+				u.EXPECT().AddTags(gomock.Any(), userID, []string{"go", "postgres"}).Return(nil)
+				u.EXPECT().GetTags(gomock.Any(), userID).Return([]string{"frontend", "go", "postgres"}, nil)
+			},
+			expected:    []string{"frontend", "go", "postgres"},
+			expectedErr: nil,
+		},
+		{
+			name: "repo error",
+			tags: []string{"go"},
+			setup: func(u *mocks.MockUserRepo, tx *mock_transactor.MockTransactor) {
+				tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				u.EXPECT().GetByID(gomock.Any(), userID).Return(entity.User{ID: userID}, nil)
+				u.EXPECT().AddTags(gomock.Any(), userID, []string{"go"}).Return(errors.New("db"))
+			},
+			expected:    nil,
+			expectedErr: service.ErrCannotUpdateTags,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockUserRepo(ctrl)
+			mockTx := mock_transactor.NewMockTransactor(ctrl)
+
+			tt.setup(mockUserRepo, mockTx)
+
+			s := service.New(mockUserRepo, nil, nil, nil, nil, nil, mockTx)
+
+			out, err := s.AddTags(ctx, userID, tt.tags)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, out)
+		})
+	}
+}
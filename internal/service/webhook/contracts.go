@@ -0,0 +1,30 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+
+type WebhookRepo interface {
+	CreateSubscription(ctx context.Context, url, secret string, eventTypes []entity.WebhookEventType) (entity.WebhookSubscription, error)
+	GetSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, ID uuid.UUID) error
+	Enqueue(ctx context.Context, events []entity.WebhookEvent) error
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, ID uuid.UUID, attempts int) error
+	MarkFailed(ctx context.Context, ID uuid.UUID, attempts int, nextAttemptAt *time.Time, lastError string) error
+	DeleteDelivered(ctx context.Context, before time.Time) (int, error)
+	GetDeadDeliveries(ctx context.Context, limit int) ([]entity.WebhookDelivery, error)
+	RetryDelivery(ctx context.Context, ID uuid.UUID) error
+}
+
+// Sender posts signed webhook body to subscriber. Any non-2xx response is an error
+type Sender interface {
+	Send(ctx context.Context, url string, header http.Header, body []byte) error
+}
@@ -0,0 +1,158 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/pkg/backoff"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderSignature = "X-Webhook-Signature-256"
+
+	// Added to the time the claimed batch may take to be sent, deliveries are hidden from other dispatchers until then
+	deliveryLeaseMargin = 30 * time.Second
+
+	baseBackoff = 30 * time.Second
+	maxBackoff  = 6 * time.Hour
+)
+
+// Body posted to subscribers
+type envelope struct {
+	ID        string                  `json:"id"`
+	Type      entity.WebhookEventType `json:"type"`
+	CreatedAt time.Time               `json:"created_at"`
+	Data      json.RawMessage         `json:"data"`
+}
+
+// Sends up to limit due deliveries. Failed ones are retried with exponential backoff
+// and go to the dead-letter list after maxAttempts. Returns number of delivered.
+// Each delivery is limited by sendTimeout, so the batch is sent before its lease expires
+func (s *Service) DispatchPending(ctx context.Context, limit int) (int, error) {
+	lease := time.Duration(limit)*s.sendTimeout + deliveryLeaseMargin
+
+	deliveries, err := s.webhookRepo.ClaimDueDeliveries(ctx, limit, lease)
+	if err != nil {
+		logrus.Errorf("WebhookService.DispatchPending: failed to claim deliveries: %v", err)
+		return 0, ErrCannotDispatchDeliveries
+	}
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		attempts := delivery.Attempts + 1
+
+		if sendErr := s.send(ctx, delivery); sendErr != nil {
+			var nextAttemptAt *time.Time
+			if attempts < s.maxAttempts {
+				next := time.Now().Add(Backoff(attempts))
+				nextAttemptAt = &next
+			}
+
+			logrus.Warnf("WebhookService.DispatchPending: delivery %s to %s failed (attempt %d): %v", delivery.ID, delivery.URL, attempts, sendErr)
+			if err := s.webhookRepo.MarkFailed(ctx, delivery.ID, attempts, nextAttemptAt, sendErr.Error()); err != nil {
+				return delivered, ErrCannotDispatchDeliveries
+			}
+			continue
+		}
+
+		if err := s.webhookRepo.MarkDelivered(ctx, delivery.ID, attempts); err != nil {
+			return delivered, ErrCannotDispatchDeliveries
+		}
+		delivered++
+	}
+
+	logrus.Infof("WebhookService.DispatchPending: delivered %d of %d", delivered, len(deliveries))
+	return delivered, nil
+}
+
+// DeleteDelivered removes deliveries delivered more than retention ago and their events,
+// returns the number of removed deliveries. Dead-letter list is kept for manual retry
+func (s *Service) DeleteDelivered(ctx context.Context, retention time.Duration) (int, error) {
+	count, err := s.webhookRepo.DeleteDelivered(ctx, time.Now().Add(-retention))
+	if err != nil {
+		logrus.Errorf("WebhookService.DeleteDelivered: %v", err)
+		return 0, ErrCannotDeleteDeliveries
+	}
+	if count > 0 {
+		logrus.Infof("WebhookService.DeleteDelivered: deleted %d delivered deliveries", count)
+	}
+	return count, nil
+}
+
+func (s *Service) send(ctx context.Context, delivery entity.WebhookDelivery) error {
+	ctx, cancel := context.WithTimeout(ctx, s.sendTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(envelope{
+		ID:        delivery.EventID.String(),
+		Type:      delivery.EventType,
+		CreatedAt: delivery.EventCreatedAt,
+		Data:      delivery.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set(HeaderEvent, string(delivery.EventType))
+	header.Set(HeaderDelivery, delivery.ID.String())
+	header.Set(HeaderSignature, Sign(delivery.Secret, body))
+
+	return s.sender.Send(ctx, delivery.URL, header, body)
+}
+
+// Sign returns "sha256=" + hex HMAC-SHA256 of body. Subscribers compare it with X-Webhook-Signature-256
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns delay before the next attempt: 30s, 1m, 2m, ... up to 6h
+func Backoff(attempts int) time.Duration {
+	return backoff.Exponential(attempts, baseBackoff, maxBackoff)
+}
+
+type HTTPSender struct {
+	client *http.Client
+}
+
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return &HTTPSender{client: &http.Client{Timeout: timeout}}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, url string, header http.Header, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header = header
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package webhook
+
+import "errors"
+
+var (
+	ErrInvalidWebhookURL        = errors.New("webhook url must be an absolute http(s) url")
+	ErrInvalidEventType         = errors.New("unknown webhook event type")
+	ErrWebhookNotFound          = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound         = errors.New("dead webhook delivery not found")
+	ErrCannotCreateWebhook      = errors.New("cannot create webhook subscription")
+	ErrCannotFetchWebhooks      = errors.New("cannot fetch webhook subscriptions")
+	ErrCannotDeleteWebhook      = errors.New("cannot delete webhook subscription")
+	ErrCannotFetchDeliveries    = errors.New("cannot fetch webhook deliveries")
+	ErrCannotRetryDelivery      = errors.New("cannot retry webhook delivery")
+	ErrCannotDispatchDeliveries = errors.New("cannot dispatch webhook deliveries")
+	ErrCannotDeleteDeliveries   = errors.New("cannot delete webhook deliveries")
+)
@@ -0,0 +1,228 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contracts.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contracts.go -destination=mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/4udiwe/avito-pr-service/internal/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookRepo is a mock of WebhookRepo interface.
+type MockWebhookRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookRepoMockRecorder is the mock recorder for MockWebhookRepo.
+type MockWebhookRepoMockRecorder struct {
+	mock *MockWebhookRepo
+}
+
+// NewMockWebhookRepo creates a new mock instance.
+func NewMockWebhookRepo(ctrl *gomock.Controller) *MockWebhookRepo {
+	mock := &MockWebhookRepo{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepo) EXPECT() *MockWebhookRepoMockRecorder {
+	return m.recorder
+}
+
+// ClaimDueDeliveries mocks base method.
+func (m *MockWebhookRepo) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueDeliveries", ctx, limit, lease)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueDeliveries indicates an expected call of ClaimDueDeliveries.
+func (mr *MockWebhookRepoMockRecorder) ClaimDueDeliveries(ctx, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueDeliveries", reflect.TypeOf((*MockWebhookRepo)(nil).ClaimDueDeliveries), ctx, limit, lease)
+}
+
+// CreateSubscription mocks base method.
+func (m *MockWebhookRepo) CreateSubscription(ctx context.Context, url, secret string, eventTypes []entity.WebhookEventType) (entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, url, secret, eventTypes)
+	ret0, _ := ret[0].(entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockWebhookRepoMockRecorder) CreateSubscription(ctx, url, secret, eventTypes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockWebhookRepo)(nil).CreateSubscription), ctx, url, secret, eventTypes)
+}
+
+// DeleteDelivered mocks base method.
+func (m *MockWebhookRepo) DeleteDelivered(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDelivered", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDelivered indicates an expected call of DeleteDelivered.
+func (mr *MockWebhookRepoMockRecorder) DeleteDelivered(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDelivered", reflect.TypeOf((*MockWebhookRepo)(nil).DeleteDelivered), ctx, before)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockWebhookRepo) DeleteSubscription(ctx context.Context, ID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockWebhookRepoMockRecorder) DeleteSubscription(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockWebhookRepo)(nil).DeleteSubscription), ctx, ID)
+}
+
+// Enqueue mocks base method.
+func (m *MockWebhookRepo) Enqueue(ctx context.Context, events []entity.WebhookEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockWebhookRepoMockRecorder) Enqueue(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockWebhookRepo)(nil).Enqueue), ctx, events)
+}
+
+// GetDeadDeliveries mocks base method.
+func (m *MockWebhookRepo) GetDeadDeliveries(ctx context.Context, limit int) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadDeliveries", ctx, limit)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadDeliveries indicates an expected call of GetDeadDeliveries.
+func (mr *MockWebhookRepoMockRecorder) GetDeadDeliveries(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadDeliveries", reflect.TypeOf((*MockWebhookRepo)(nil).GetDeadDeliveries), ctx, limit)
+}
+
+// GetSubscriptions mocks base method.
+func (m *MockWebhookRepo) GetSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptions", ctx)
+	ret0, _ := ret[0].([]entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptions indicates an expected call of GetSubscriptions.
+func (mr *MockWebhookRepoMockRecorder) GetSubscriptions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptions", reflect.TypeOf((*MockWebhookRepo)(nil).GetSubscriptions), ctx)
+}
+
+// MarkDelivered mocks base method.
+func (m *MockWebhookRepo) MarkDelivered(ctx context.Context, ID uuid.UUID, attempts int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelivered", ctx, ID, attempts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDelivered indicates an expected call of MarkDelivered.
+func (mr *MockWebhookRepoMockRecorder) MarkDelivered(ctx, ID, attempts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelivered", reflect.TypeOf((*MockWebhookRepo)(nil).MarkDelivered), ctx, ID, attempts)
+}
+
+// MarkFailed mocks base method.
+func (m *MockWebhookRepo) MarkFailed(ctx context.Context, ID uuid.UUID, attempts int, nextAttemptAt *time.Time, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, ID, attempts, nextAttemptAt, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockWebhookRepoMockRecorder) MarkFailed(ctx, ID, attempts, nextAttemptAt, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockWebhookRepo)(nil).MarkFailed), ctx, ID, attempts, nextAttemptAt, lastError)
+}
+
+// RetryDelivery mocks base method.
+func (m *MockWebhookRepo) RetryDelivery(ctx context.Context, ID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryDelivery", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryDelivery indicates an expected call of RetryDelivery.
+func (mr *MockWebhookRepoMockRecorder) RetryDelivery(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryDelivery", reflect.TypeOf((*MockWebhookRepo)(nil).RetryDelivery), ctx, ID)
+}
+
+// MockSender is a mock of Sender interface.
+type MockSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockSenderMockRecorder is the mock recorder for MockSender.
+type MockSenderMockRecorder struct {
+	mock *MockSender
+}
+
+// NewMockSender creates a new mock instance.
+func NewMockSender(ctrl *gomock.Controller) *MockSender {
+	mock := &MockSender{ctrl: ctrl}
+	mock.recorder = &MockSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSender) EXPECT() *MockSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockSender) Send(ctx context.Context, url string, header http.Header, body []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, url, header, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockSenderMockRecorder) Send(ctx, url, header, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockSender)(nil).Send), ctx, url, header, body)
+}
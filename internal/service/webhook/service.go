@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultMaxAttempts = 8
+	defaultSendTimeout = 5 * time.Second
+	deadLettersLimit   = 100
+)
+
+type Service struct {
+	webhookRepo WebhookRepo
+	sender      Sender
+
+	// Attempts before delivery goes to the dead-letter list
+	maxAttempts int
+	// Time limit of one delivery, bounds the lease of a claimed batch
+	sendTimeout time.Duration
+}
+
+func New(webhookRepo WebhookRepo, sender Sender, maxAttempts int, sendTimeout time.Duration) *Service {
+	if maxAttempts < 1 {
+		maxAttempts = defaultMaxAttempts
+	}
+	if sendTimeout <= 0 {
+		sendTimeout = defaultSendTimeout
+	}
+	return &Service{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+		maxAttempts: maxAttempts,
+		sendTimeout: sendTimeout,
+	}
+}
+
+// Subscribes url to the given event types, all events when empty. Random secret is generated when not provided
+func (s *Service) Subscribe(ctx context.Context, rawURL, secret string, eventTypes []entity.WebhookEventType) (entity.WebhookSubscription, error) {
+	logrus.Infof("WebhookService.Subscribe: subscribing %s to %v", rawURL, eventTypes)
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return entity.WebhookSubscription{}, ErrInvalidWebhookURL
+	}
+	for _, t := range eventTypes {
+		if !t.IsValid() {
+			return entity.WebhookSubscription{}, ErrInvalidEventType
+		}
+	}
+
+	if secret == "" {
+		secret, err = generateSecret()
+		if err != nil {
+			logrus.Errorf("WebhookService.Subscribe: failed to generate secret: %v", err)
+			return entity.WebhookSubscription{}, ErrCannotCreateWebhook
+		}
+	}
+
+	subscription, err := s.webhookRepo.CreateSubscription(ctx, rawURL, secret, lo.Uniq(eventTypes))
+	if err != nil {
+		logrus.Errorf("WebhookService.Subscribe: failed to create subscription for %s: %v", rawURL, err)
+		return entity.WebhookSubscription{}, ErrCannotCreateWebhook
+	}
+
+	logrus.Infof("WebhookService.Subscribe: subscription %s created", subscription.ID)
+	return subscription, nil
+}
+
+func (s *Service) GetSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	logrus.Info("WebhookService.GetSubscriptions: fetching subscriptions")
+
+	subscriptions, err := s.webhookRepo.GetSubscriptions(ctx)
+	if err != nil {
+		logrus.Errorf("WebhookService.GetSubscriptions: failed to fetch subscriptions: %v", err)
+		return nil, ErrCannotFetchWebhooks
+	}
+
+	return subscriptions, nil
+}
+
+func (s *Service) Unsubscribe(ctx context.Context, ID uuid.UUID) error {
+	logrus.Infof("WebhookService.Unsubscribe: deleting subscription %s", ID)
+
+	err := s.webhookRepo.DeleteSubscription(ctx, ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrWebhookNotFound) {
+			return ErrWebhookNotFound
+		}
+		logrus.Errorf("WebhookService.Unsubscribe: failed to delete subscription %s: %v", ID, err)
+		return ErrCannotDeleteWebhook
+	}
+
+	return nil
+}
+
+// Returns the latest deliveries which ran out of attempts
+func (s *Service) GetDeadLetters(ctx context.Context) ([]entity.WebhookDelivery, error) {
+	logrus.Info("WebhookService.GetDeadLetters: fetching dead deliveries")
+
+	deliveries, err := s.webhookRepo.GetDeadDeliveries(ctx, deadLettersLimit)
+	if err != nil {
+		logrus.Errorf("WebhookService.GetDeadLetters: failed to fetch dead deliveries: %v", err)
+		return nil, ErrCannotFetchDeliveries
+	}
+
+	return deliveries, nil
+}
+
+// Puts dead delivery back to the queue with attempts reset
+func (s *Service) RetryDeadLetter(ctx context.Context, ID uuid.UUID) error {
+	logrus.Infof("WebhookService.RetryDeadLetter: retrying delivery %s", ID)
+
+	err := s.webhookRepo.RetryDelivery(ctx, ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrWebhookDeliveryNotFound) {
+			return ErrDeliveryNotFound
+		}
+		logrus.Errorf("WebhookService.RetryDeadLetter: failed to retry delivery %s: %v", ID, err)
+		return ErrCannotRetryDelivery
+	}
+
+	return nil
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
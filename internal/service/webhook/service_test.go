@@ -0,0 +1,244 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/internal/repository"
+	service "github.com/4udiwe/avito-pr-service/internal/service/webhook"
+	"github.com/4udiwe/avito-pr-service/internal/service/webhook/mocks"
+	"github.com/4udiwe/avito-pr-service/pkg/outbox"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSubscribe(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		url         string
+		secret      string
+		eventTypes  []entity.WebhookEventType
+		setup       func(r *mocks.MockWebhookRepo)
+		expectedErr error
+	}{
+		{
+			name:        "relative url",
+			url:         "/hooks",
+			setup:       func(r *mocks.MockWebhookRepo) {},
+			expectedErr: service.ErrInvalidWebhookURL,
+		},
+		{
+			name:        "unsupported scheme",
+			url:         "ftp://example.com/hooks",
+			setup:       func(r *mocks.MockWebhookRepo) {},
+			expectedErr: service.ErrInvalidWebhookURL,
+		},
+		{
+			name:        "unknown event type",
+			url:         "https://example.com/hooks",
+			eventTypes:  []entity.WebhookEventType{"pr.created"},
+			setup:       func(r *mocks.MockWebhookRepo) {},
+			expectedErr: service.ErrInvalidEventType,
+		},
+		{
+			name:       "secret is generated",
+			url:        "https://example.com/hooks",
+			eventTypes: []entity.WebhookEventType{entity.WebhookPRMerged, entity.WebhookPRMerged},
+			setup: func(r *mocks.MockWebhookRepo) {
+				r.EXPECT().CreateSubscription(gomock.Any(), "https://example.com/hooks", gomock.Len(64), []entity.WebhookEventType{entity.WebhookPRMerged}).
+					Return(entity.WebhookSubscription{ID: uuid.New()}, nil)
+			},
+		},
+		{
+			name:   "repo error",
+			url:    "http://example.com/hooks",
+			secret: "s3cret",
+			setup: func(r *mocks.MockWebhookRepo) {
+				r.EXPECT().CreateSubscription(gomock.Any(), "http://example.com/hooks", "s3cret", gomock.Any()).
+					Return(entity.WebhookSubscription{}, errors.New("db"))
+			},
+			expectedErr: service.ErrCannotCreateWebhook,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockWebhookRepo(ctrl)
+			tt.setup(repo)
+
+			svc := service.New(repo, mocks.NewMockSender(ctrl), 3, time.Second)
+
+			_, err := svc.Subscribe(ctx, tt.url, tt.secret, tt.eventTypes)
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestUnsubscribe_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockWebhookRepo(ctrl)
+	repo.EXPECT().DeleteSubscription(gomock.Any(), gomock.Any()).Return(repository.ErrWebhookNotFound)
+
+	svc := service.New(repo, mocks.NewMockSender(ctrl), 3, time.Second)
+
+	err := svc.Unsubscribe(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, service.ErrWebhookNotFound)
+}
+
+func TestDispatchPending(t *testing.T) {
+	ctx := context.Background()
+
+	delivery := entity.WebhookDelivery{
+		ID:        uuid.New(),
+		EventID:   uuid.New(),
+		EventType: entity.WebhookPRMerged,
+		Payload:   json.RawMessage(`{"pull_request_id":"pr1"}`),
+		URL:       "https://example.com/hooks",
+		Secret:    "s3cret",
+	}
+
+	tests := []struct {
+		name              string
+		attempts          int
+		setup             func(r *mocks.MockWebhookRepo, s *mocks.MockSender)
+		expectedDelivered int
+		expectedErr       error
+	}{
+		{
+			name: "delivered with signature",
+			setup: func(r *mocks.MockWebhookRepo, s *mocks.MockSender) {
+				s.EXPECT().Send(gomock.Any(), delivery.URL, gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, header http.Header, body []byte) error {
+						assert.Equal(t, service.Sign("s3cret", body), header.Get(service.HeaderSignature))
+						assert.Equal(t, string(entity.WebhookPRMerged), header.Get(service.HeaderEvent))
+						assert.Equal(t, delivery.ID.String(), header.Get(service.HeaderDelivery))
+						return nil
+					},
+				)
+				r.EXPECT().MarkDelivered(gomock.Any(), delivery.ID, 1).Return(nil)
+			},
+			expectedDelivered: 1,
+		},
+		{
+			name: "failed attempt is retried later",
+			setup: func(r *mocks.MockWebhookRepo, s *mocks.MockSender) {
+				s.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("unexpected status 500"))
+				r.EXPECT().MarkFailed(gomock.Any(), delivery.ID, 1, gomock.Not(gomock.Nil()), "unexpected status 500").Return(nil)
+			},
+		},
+		{
+			name:     "last attempt goes to dead letters",
+			attempts: 2,
+			setup: func(r *mocks.MockWebhookRepo, s *mocks.MockSender) {
+				s.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("timeout"))
+				r.EXPECT().MarkFailed(gomock.Any(), delivery.ID, 3, (*time.Time)(nil), "timeout").Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockWebhookRepo(ctrl)
+			sender := mocks.NewMockSender(ctrl)
+
+			d := delivery
+			d.Attempts = tt.attempts
+			repo.EXPECT().ClaimDueDeliveries(gomock.Any(), 10, 10*time.Second+30*time.Second).Return([]entity.WebhookDelivery{d}, nil)
+			tt.setup(repo, sender)
+
+			svc := service.New(repo, sender, 3, time.Second)
+
+			delivered, err := svc.DispatchPending(ctx, 10)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedDelivered, delivered)
+		})
+	}
+}
+
+func TestDeleteDelivered(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deliveries older than retention are deleted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockWebhookRepo(ctrl)
+		repo.EXPECT().DeleteDelivered(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, before time.Time) (int, error) {
+				assert.WithinDuration(t, time.Now().Add(-24*time.Hour), before, time.Minute)
+				return 3, nil
+			},
+		)
+
+		count, err := service.New(repo, nil, 0, 0).DeleteDelivered(ctx, 24*time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockWebhookRepo(ctrl)
+		repo.EXPECT().DeleteDelivered(gomock.Any(), gomock.Any()).Return(0, errors.New("db down"))
+
+		_, err := service.New(repo, nil, 0, 0).DeleteDelivered(ctx, 24*time.Hour)
+		assert.ErrorIs(t, err, service.ErrCannotDeleteDeliveries)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, service.Backoff(1))
+	assert.Equal(t, time.Minute, service.Backoff(2))
+	assert.Equal(t, 8*time.Minute, service.Backoff(5))
+	assert.Equal(t, 6*time.Hour, service.Backoff(30))
+}
+
+func TestPublish(t *testing.T) {
+	ctx := context.Background()
+	payload := json.RawMessage(`{"pull_request_id":"pr1"}`)
+
+	t.Run("webhook topic is enqueued with outbox ID and creation time", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockWebhookRepo(ctrl)
+		msg := outbox.Message{ID: uuid.New(), Topic: entity.EventPRMerged, Key: "pr1", Payload: payload, CreatedAt: time.Now().Add(-time.Minute)}
+
+		repo.EXPECT().Enqueue(gomock.Any(), []entity.WebhookEvent{{
+			ID:        msg.ID,
+			Type:      entity.WebhookPRMerged,
+			Payload:   payload,
+			CreatedAt: msg.CreatedAt,
+		}}).Return(nil)
+
+		s := service.New(repo, nil, 0, 0)
+
+		assert.NoError(t, s.Publish(ctx, msg))
+	})
+
+	t.Run("other topics are skipped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s := service.New(mocks.NewMockWebhookRepo(ctrl), nil, 0, 0)
+
+		assert.NoError(t, s.Publish(ctx, outbox.Message{ID: uuid.New(), Topic: entity.EventPRCreated, Payload: payload}))
+	})
+}
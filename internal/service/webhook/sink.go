@@ -0,0 +1,28 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/4udiwe/avito-pr-service/internal/entity"
+	"github.com/4udiwe/avito-pr-service/pkg/outbox"
+)
+
+// Name of the service as an outbox sink
+func (s *Service) Name() string { return "webhooks" }
+
+// Publish turns outbox message into webhook event with deliveries for the subscribers.
+// Topics that are not webhook event types are skipped
+func (s *Service) Publish(ctx context.Context, msg outbox.Message) error {
+	eventType := entity.WebhookEventType(msg.Topic)
+	if !eventType.IsValid() {
+		return nil
+	}
+
+	return s.webhookRepo.Enqueue(ctx, []entity.WebhookEvent{{
+		ID:        msg.ID,
+		Type:      eventType,
+		Payload:   json.RawMessage(msg.Payload),
+		CreatedAt: msg.CreatedAt,
+	}})
+}
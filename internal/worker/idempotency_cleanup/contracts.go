@@ -0,0 +1,7 @@
+package idempotency_cleanup
+
+import "context"
+
+type IdempotencyService interface {
+	DeleteExpired(ctx context.Context) (int, error)
+}
@@ -0,0 +1,27 @@
+package idempotency_cleanup
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/pkg/periodic"
+)
+
+// Worker deletes idempotency keys whose responses are no longer replayed.
+// Deletion is idempotent, so several replicas can run it at the same time
+type Worker struct {
+	*periodic.Runner
+
+	s IdempotencyService
+}
+
+func New(idempotencyService IdempotencyService, interval time.Duration) *Worker {
+	w := &Worker{s: idempotencyService}
+	w.Runner = periodic.New("IdempotencyCleanupWorker", interval, w.tick)
+	return w
+}
+
+func (w *Worker) tick(ctx context.Context) error {
+	_, err := w.s.DeleteExpired(ctx)
+	return err
+}
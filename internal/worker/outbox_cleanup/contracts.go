@@ -0,0 +1,10 @@
+package outbox_cleanup
+
+import (
+	"context"
+	"time"
+)
+
+type Store interface {
+	DeleteOutboxPublished(ctx context.Context, before time.Time) (int, error)
+}
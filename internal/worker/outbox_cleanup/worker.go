@@ -0,0 +1,38 @@
+package outbox_cleanup
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/pkg/periodic"
+	"github.com/sirupsen/logrus"
+)
+
+// Worker deletes outbox messages published longer than retention ago. Dead messages are kept.
+// Deletion is idempotent, so several replicas can run it at the same time
+type Worker struct {
+	*periodic.Runner
+
+	store     Store
+	retention time.Duration
+}
+
+func New(store Store, interval, retention time.Duration) *Worker {
+	w := &Worker{
+		store:     store,
+		retention: retention,
+	}
+	w.Runner = periodic.New("OutboxCleanupWorker", interval, w.tick)
+	return w
+}
+
+func (w *Worker) tick(ctx context.Context) error {
+	count, err := w.store.DeleteOutboxPublished(ctx, time.Now().Add(-w.retention))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		logrus.Infof("OutboxCleanupWorker: deleted %d published messages", count)
+	}
+	return nil
+}
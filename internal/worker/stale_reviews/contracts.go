@@ -0,0 +1,14 @@
+package stale_reviews
+
+import (
+	"context"
+	"time"
+)
+
+type PRService interface {
+	ReassignStaleReviews(ctx context.Context, threshold, retryAfter time.Duration, limit int) (int, error)
+}
+
+type Locker interface {
+	WithAdvisoryLock(ctx context.Context, key int64, fn func(context.Context) error) (bool, error)
+}
@@ -0,0 +1,50 @@
+package stale_reviews
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/pkg/periodic"
+	"github.com/sirupsen/logrus"
+)
+
+// Arbitrary advisory lock key shared by all replicas
+const lockKey int64 = 4815162342
+
+// Worker periodically reassigns reviews without reviewer activity. On several replicas
+// only the one holding the advisory lock does the pass
+type Worker struct {
+	*periodic.Runner
+
+	s          PRService
+	locker     Locker
+	threshold  time.Duration
+	retryAfter time.Duration
+	batchSize  int
+}
+
+func New(prService PRService, locker Locker, interval, threshold, retryAfter time.Duration, batchSize int) *Worker {
+	w := &Worker{
+		s:          prService,
+		locker:     locker,
+		threshold:  threshold,
+		retryAfter: retryAfter,
+		batchSize:  batchSize,
+	}
+	w.Runner = periodic.New("StaleReviewsWorker", interval, w.tick)
+	return w
+}
+
+func (w *Worker) tick(ctx context.Context) error {
+	locked, err := w.locker.WithAdvisoryLock(ctx, lockKey, func(ctx context.Context) error {
+		_, err := w.s.ReassignStaleReviews(ctx, w.threshold, w.retryAfter, w.batchSize)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if !locked {
+		logrus.Debug("StaleReviewsWorker: pass is running on another replica")
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package webhooks
+
+import "context"
+
+type WebhookService interface {
+	DispatchPending(ctx context.Context, limit int) (int, error)
+}
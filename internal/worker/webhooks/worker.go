@@ -0,0 +1,31 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/pkg/periodic"
+)
+
+// Worker delivers webhook outbox. Deliveries are claimed with SKIP LOCKED,
+// so several replicas can run it at the same time
+type Worker struct {
+	*periodic.Runner
+
+	s         WebhookService
+	batchSize int
+}
+
+func New(webhookService WebhookService, interval time.Duration, batchSize int) *Worker {
+	w := &Worker{
+		s:         webhookService,
+		batchSize: batchSize,
+	}
+	w.Runner = periodic.New("WebhooksWorker", interval, w.tick)
+	return w
+}
+
+func (w *Worker) tick(ctx context.Context) error {
+	_, err := w.s.DispatchPending(ctx, w.batchSize)
+	return err
+}
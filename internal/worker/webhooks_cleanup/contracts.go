@@ -0,0 +1,10 @@
+package webhooks_cleanup
+
+import (
+	"context"
+	"time"
+)
+
+type WebhookService interface {
+	DeleteDelivered(ctx context.Context, retention time.Duration) (int, error)
+}
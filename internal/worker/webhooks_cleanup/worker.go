@@ -0,0 +1,31 @@
+package webhooks_cleanup
+
+import (
+	"context"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/pkg/periodic"
+)
+
+// Worker deletes webhook deliveries delivered longer than retention ago together with their events.
+// Deletion is idempotent, so several replicas can run it at the same time
+type Worker struct {
+	*periodic.Runner
+
+	s         WebhookService
+	retention time.Duration
+}
+
+func New(webhookService WebhookService, interval, retention time.Duration) *Worker {
+	w := &Worker{
+		s:         webhookService,
+		retention: retention,
+	}
+	w.Runner = periodic.New("WebhooksCleanupWorker", interval, w.tick)
+	return w
+}
+
+func (w *Worker) tick(ctx context.Context) error {
+	_, err := w.s.DeleteDelivered(ctx, w.retention)
+	return err
+}
@@ -0,0 +1,21 @@
+package actor
+
+import "context"
+
+// System is used for changes that are not initiated by a caller
+const System = "system"
+
+type ctxKey struct{}
+
+// WithActor stores the name of whoever initiated the request
+func WithActor(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, name)
+}
+
+// FromContext returns the request initiator, or System when it is unknown
+func FromContext(ctx context.Context) string {
+	if name, ok := ctx.Value(ctxKey{}).(string); ok && name != "" {
+		return name
+	}
+	return System
+}
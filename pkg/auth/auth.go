@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"slices"
+)
+
+type Role string
+
+const (
+	RoleAdmin    Role = "admin"
+	RoleTeamLead Role = "team_lead"
+	RoleMember   Role = "member"
+)
+
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleAdmin, RoleTeamLead, RoleMember:
+		return true
+	}
+	return false
+}
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// Principal is the authenticated caller. Subject is the user ID, Team is required for team_lead
+type Principal struct {
+	Subject string
+	Role    Role
+	Team    string
+}
+
+// HasRole reports whether principal has one of the roles
+func (p Principal) HasRole(roles ...Role) bool {
+	return slices.Contains(roles, p.Role)
+}
+
+type ctxKey struct{}
+
+// WithPrincipal stores the authenticated caller
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, ctxKey{}, p)
+}
+
+// FromContext returns the authenticated caller. False means the request was not authenticated,
+// which happens only when authentication is disabled or for background jobs
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(ctxKey{}).(Principal)
+	return p, ok
+}
+
+// Authenticator resolves bearer tokens: static API tokens first, then HS256 JWT.
+// JWT without exp is rejected unless AllowJWTWithoutExp is set
+type Authenticator struct {
+	tokens     map[string]Principal
+	jwtSecret  []byte
+	allowNoExp bool
+}
+
+func New(opts ...Option) *Authenticator {
+	a := &Authenticator{
+		tokens: map[string]Principal{},
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+func (a *Authenticator) Authenticate(token string) (Principal, error) {
+	if token == "" {
+		return Principal{}, ErrInvalidToken
+	}
+
+	for staticToken, p := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(staticToken), []byte(token)) == 1 {
+			return p, nil
+		}
+	}
+
+	if len(a.jwtSecret) == 0 {
+		return Principal{}, ErrInvalidToken
+	}
+	return parseJWT(a.jwtSecret, token, !a.allowNoExp)
+}
@@ -0,0 +1,181 @@
+package auth_test
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "test-secret"
+
+func signed(t *testing.T, claims auth.Claims) string {
+	t.Helper()
+	token, err := auth.SignJWT(secret, claims)
+	require.NoError(t, err)
+	return token
+}
+
+func segment(s string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	now := time.Now()
+	exp := now.Add(time.Hour).Unix()
+	lead := auth.Principal{Subject: "u2", Role: auth.RoleTeamLead, Team: "backend"}
+
+	valid := signed(t, auth.Claims{Subject: "u2", Role: auth.RoleTeamLead, Team: "backend", Exp: exp})
+	parts := strings.Split(valid, ".")
+	payload := segment(`{"sub":"u2","role":"admin","exp":` + strconv.FormatInt(exp, 10) + `}`)
+
+	tests := []struct {
+		name          string
+		opts          []auth.Option
+		token         string
+		expected      auth.Principal
+		expectedError bool
+	}{
+		{
+			name:     "static token",
+			opts:     []auth.Option{auth.StaticToken("api-token", lead), auth.JWTSecret(secret)},
+			token:    "api-token",
+			expected: lead,
+		},
+		{
+			name:          "unknown static token without jwt",
+			opts:          []auth.Option{auth.StaticToken("api-token", lead)},
+			token:         "other-token",
+			expectedError: true,
+		},
+		{
+			name:          "empty token",
+			opts:          []auth.Option{auth.StaticToken("", lead), auth.JWTSecret(secret)},
+			token:         "",
+			expectedError: true,
+		},
+		{
+			name:     "valid jwt",
+			opts:     []auth.Option{auth.JWTSecret(secret)},
+			token:    valid,
+			expected: lead,
+		},
+		{
+			name:          "jwt signed with another secret",
+			opts:          []auth.Option{auth.JWTSecret("another-secret")},
+			token:         valid,
+			expectedError: true,
+		},
+		{
+			name:          "tampered signature",
+			opts:          []auth.Option{auth.JWTSecret(secret)},
+			token:         parts[0] + "." + parts[1] + "." + segment("forged"),
+			expectedError: true,
+		},
+		{
+			name:          "tampered payload",
+			opts:          []auth.Option{auth.JWTSecret(secret)},
+			token:         parts[0] + "." + payload + "." + parts[2],
+			expectedError: true,
+		},
+		{
+			name:          "alg none",
+			opts:          []auth.Option{auth.JWTSecret(secret)},
+			token:         segment(`{"alg":"none","typ":"JWT"}`) + "." + parts[1] + ".",
+			expectedError: true,
+		},
+		{
+			name:          "alg HS512",
+			opts:          []auth.Option{auth.JWTSecret(secret)},
+			token:         segment(`{"alg":"HS512","typ":"JWT"}`) + "." + parts[1] + "." + parts[2],
+			expectedError: true,
+		},
+		{
+			name:          "malformed token",
+			opts:          []auth.Option{auth.JWTSecret(secret)},
+			token:         parts[0] + "." + parts[1],
+			expectedError: true,
+		},
+		{
+			name:          "expired",
+			opts:          []auth.Option{auth.JWTSecret(secret)},
+			token:         signed(t, auth.Claims{Subject: "u2", Role: auth.RoleMember, Exp: now.Add(-time.Minute).Unix()}),
+			expectedError: true,
+		},
+		{
+			name:          "not valid yet",
+			opts:          []auth.Option{auth.JWTSecret(secret)},
+			token:         signed(t, auth.Claims{Subject: "u2", Role: auth.RoleMember, Exp: exp, Nbf: now.Add(time.Minute).Unix()}),
+			expectedError: true,
+		},
+		{
+			name:     "nbf in the past",
+			opts:     []auth.Option{auth.JWTSecret(secret)},
+			token:    signed(t, auth.Claims{Subject: "u2", Role: auth.RoleMember, Exp: exp, Nbf: now.Add(-time.Minute).Unix()}),
+			expected: auth.Principal{Subject: "u2", Role: auth.RoleMember},
+		},
+		{
+			name:          "invalid role",
+			opts:          []auth.Option{auth.JWTSecret(secret)},
+			token:         signed(t, auth.Claims{Subject: "u2", Role: "root", Exp: exp}),
+			expectedError: true,
+		},
+		{
+			name:          "team lead without team",
+			opts:          []auth.Option{auth.JWTSecret(secret)},
+			token:         signed(t, auth.Claims{Subject: "u2", Role: auth.RoleTeamLead, Exp: exp}),
+			expectedError: true,
+		},
+		{
+			name:          "missing subject",
+			opts:          []auth.Option{auth.JWTSecret(secret)},
+			token:         signed(t, auth.Claims{Role: auth.RoleAdmin, Exp: exp}),
+			expectedError: true,
+		},
+		{
+			name:          "missing exp",
+			opts:          []auth.Option{auth.JWTSecret(secret)},
+			token:         signed(t, auth.Claims{Subject: "u2", Role: auth.RoleAdmin}),
+			expectedError: true,
+		},
+		{
+			name:     "missing exp allowed",
+			opts:     []auth.Option{auth.JWTSecret(secret), auth.AllowJWTWithoutExp()},
+			token:    signed(t, auth.Claims{Subject: "u2", Role: auth.RoleAdmin}),
+			expected: auth.Principal{Subject: "u2", Role: auth.RoleAdmin},
+		},
+		{
+			name:          "expired with exp not required",
+			opts:          []auth.Option{auth.JWTSecret(secret), auth.AllowJWTWithoutExp()},
+			token:         signed(t, auth.Claims{Subject: "u2", Role: auth.RoleAdmin, Exp: now.Add(-time.Minute).Unix()}),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := auth.New(tt.opts...).Authenticate(tt.token)
+
+			if tt.expectedError {
+				assert.ErrorIs(t, err, auth.ErrInvalidToken)
+				assert.Empty(t, principal)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, principal)
+		})
+	}
+}
+
+func TestPrincipal_HasRole(t *testing.T) {
+	lead := auth.Principal{Subject: "u2", Role: auth.RoleTeamLead}
+
+	assert.True(t, lead.HasRole(auth.RoleAdmin, auth.RoleTeamLead))
+	assert.False(t, lead.HasRole(auth.RoleAdmin))
+	assert.False(t, lead.HasRole())
+}
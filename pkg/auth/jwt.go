@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Claims of the JWT. Role has to be one of the known roles, Team is required for team_lead.
+// Exp and Nbf are unix seconds, Exp is required unless the authenticator allows tokens without it
+type Claims struct {
+	Subject string `json:"sub"`
+	Role    Role   `json:"role"`
+	Team    string `json:"team,omitempty"`
+	Exp     int64  `json:"exp,omitempty"`
+	Nbf     int64  `json:"nbf,omitempty"`
+	Iat     int64  `json:"iat,omitempty"`
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// SignJWT issues HS256 token with the claims
+func SignJWT(secret string, claims Claims) (string, error) {
+	header, err := json.Marshal(jwtHeader{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodeSegment(header) + "." + encodeSegment(payload)
+	return signingInput + "." + encodeSegment(sign([]byte(secret), signingInput)), nil
+}
+
+func parseJWT(secret []byte, token string, requireExp bool) (Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Principal{}, ErrInvalidToken
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return Principal{}, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, sign(secret, parts[0]+"."+parts[1])) {
+		return Principal{}, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return Principal{}, ErrInvalidToken
+	}
+
+	if claims.Exp == 0 && requireExp {
+		return Principal{}, fmt.Errorf("%w: exp is required", ErrInvalidToken)
+	}
+
+	now := time.Now().Unix()
+	if claims.Exp != 0 && now >= claims.Exp {
+		return Principal{}, fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+	if claims.Nbf != 0 && now < claims.Nbf {
+		return Principal{}, fmt.Errorf("%w: not valid yet", ErrInvalidToken)
+	}
+	if claims.Subject == "" || !claims.Role.IsValid() {
+		return Principal{}, ErrInvalidToken
+	}
+	if claims.Role == RoleTeamLead && claims.Team == "" {
+		return Principal{}, fmt.Errorf("%w: team is required for %s", ErrInvalidToken, RoleTeamLead)
+	}
+
+	return Principal{Subject: claims.Subject, Role: claims.Role, Team: claims.Team}, nil
+}
+
+func sign(secret []byte, signingInput string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeSegment(segment string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
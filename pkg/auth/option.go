@@ -0,0 +1,24 @@
+package auth
+
+type Option func(*Authenticator)
+
+// StaticToken registers API token of the principal
+func StaticToken(token string, p Principal) Option {
+	return func(a *Authenticator) {
+		a.tokens[token] = p
+	}
+}
+
+// JWTSecret enables HS256 JWT validated with the secret
+func JWTSecret(secret string) Option {
+	return func(a *Authenticator) {
+		a.jwtSecret = []byte(secret)
+	}
+}
+
+// AllowJWTWithoutExp accepts JWT without exp claim, such tokens never expire
+func AllowJWTWithoutExp() Option {
+	return func(a *Authenticator) {
+		a.allowNoExp = true
+	}
+}
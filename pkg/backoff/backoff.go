@@ -0,0 +1,15 @@
+package backoff
+
+import "time"
+
+// Exponential returns delay before the next attempt: base doubled after every attempt, up to max
+func Exponential(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
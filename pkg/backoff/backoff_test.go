@@ -0,0 +1,31 @@
+package backoff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/pkg/backoff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponential(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		base     time.Duration
+		max      time.Duration
+		want     time.Duration
+	}{
+		{name: "first attempt", attempts: 1, base: 30 * time.Second, max: 6 * time.Hour, want: 30 * time.Second},
+		{name: "second attempt", attempts: 2, base: 30 * time.Second, max: 6 * time.Hour, want: time.Minute},
+		{name: "fifth attempt", attempts: 5, base: 30 * time.Second, max: 6 * time.Hour, want: 8 * time.Minute},
+		{name: "capped", attempts: 30, base: 30 * time.Second, max: 6 * time.Hour, want: 6 * time.Hour},
+		{name: "zero attempts", attempts: 0, base: time.Second, max: time.Minute, want: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, backoff.Exponential(tt.attempts, tt.base, tt.max))
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/pkg/backoff"
+	"github.com/4udiwe/avito-pr-service/pkg/periodic"
+	"github.com/4udiwe/avito-pr-service/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type Message = postgres.OutboxMessage
+
+// Sink receives outbox messages. A sink which accepted the message does not get it again on retry
+// after another sink failed, but delivery is still at-least-once (e.g. relay crashed before recording it),
+// so sinks must tolerate duplicates by message ID
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, msg Message) error
+}
+
+// Store is the outbox table, implemented by *postgres.Postgres
+type Store interface {
+	ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]Message, error)
+	MarkOutboxPublished(ctx context.Context, ID uuid.UUID) error
+	MarkOutboxFailed(ctx context.Context, ID uuid.UUID, attempts int, nextAttemptAt *time.Time, publishedSinks []string, lastError string) error
+}
+
+const (
+	// Time for which a claimed message is hidden from other relays
+	claimLease = time.Minute
+
+	defaultMaxAttempts = 20
+
+	backoffBase = time.Second
+	backoffMax  = 10 * time.Minute
+)
+
+// Relay delivers outbox messages to sinks. Messages are claimed with SKIP LOCKED,
+// so several replicas can run it at the same time
+type Relay struct {
+	*periodic.Runner
+
+	store     Store
+	sinks     []Sink
+	batchSize int
+
+	// Attempts before message is marked dead and no longer relayed
+	maxAttempts int
+}
+
+func NewRelay(store Store, interval time.Duration, batchSize, maxAttempts int, sinks ...Sink) *Relay {
+	if maxAttempts < 1 {
+		maxAttempts = defaultMaxAttempts
+	}
+	r := &Relay{
+		store:       store,
+		sinks:       sinks,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+	}
+	r.Runner = periodic.New("OutboxRelay", interval, func(ctx context.Context) error {
+		_, err := r.RelayPending(ctx)
+		return err
+	})
+	return r
+}
+
+// RelayPending delivers one batch of due messages to every sink. A message is published
+// only when all sinks accepted it, otherwise it is retried later with backoff for the failed sinks
+// and marked dead after maxAttempts. Returns the number of published messages
+func (r *Relay) RelayPending(ctx context.Context) (int, error) {
+	messages, err := r.store.ClaimOutbox(ctx, r.batchSize, claimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, msg := range messages {
+		publishedSinks, err := r.deliver(ctx, msg)
+		if err != nil {
+			attempts := msg.Attempts + 1
+
+			var nextAttemptAt *time.Time
+			if attempts < r.maxAttempts {
+				next := time.Now().Add(Backoff(attempts))
+				nextAttemptAt = &next
+				logrus.Warnf("OutboxRelay: message %s (%s) attempt %d failed: %v", msg.ID, msg.Topic, attempts, err)
+			} else {
+				logrus.Errorf("OutboxRelay: message %s (%s) is dead after %d attempts: %v", msg.ID, msg.Topic, attempts, err)
+			}
+
+			if err := r.store.MarkOutboxFailed(ctx, msg.ID, attempts, nextAttemptAt, publishedSinks, err.Error()); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		if err := r.store.MarkOutboxPublished(ctx, msg.ID); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	if len(messages) > 0 {
+		logrus.Infof("OutboxRelay: published %d of %d messages", published, len(messages))
+	}
+	return published, nil
+}
+
+// Publishes msg to the sinks which have not accepted it yet. Returns names of all sinks which have the message
+func (r *Relay) deliver(ctx context.Context, msg Message) ([]string, error) {
+	// Not nil, published_sinks column is NOT NULL
+	publishedSinks := append([]string{}, msg.PublishedSinks...)
+
+	var errs []error
+	for _, sink := range r.sinks {
+		if slices.Contains(msg.PublishedSinks, sink.Name()) {
+			continue
+		}
+		if err := sink.Publish(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+			continue
+		}
+		publishedSinks = append(publishedSinks, sink.Name())
+	}
+	return publishedSinks, errors.Join(errs...)
+}
+
+// Backoff returns delay before the next attempt: 1s doubled after every attempt, up to 10m
+func Backoff(attempts int) time.Duration {
+	return backoff.Exponential(attempts, backoffBase, backoffMax)
+}
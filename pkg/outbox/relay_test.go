@@ -0,0 +1,170 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/4udiwe/avito-pr-service/pkg/outbox"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failedMark struct {
+	attempts       int
+	nextAttemptAt  *time.Time
+	publishedSinks []string
+	lastError      string
+}
+
+type fakeStore struct {
+	messages  []outbox.Message
+	claimErr  error
+	published []uuid.UUID
+	failed    map[uuid.UUID]failedMark
+}
+
+func (s *fakeStore) ClaimOutbox(_ context.Context, limit int, _ time.Duration) ([]outbox.Message, error) {
+	if s.claimErr != nil {
+		return nil, s.claimErr
+	}
+	return s.messages[:min(limit, len(s.messages))], nil
+}
+
+func (s *fakeStore) MarkOutboxPublished(_ context.Context, ID uuid.UUID) error {
+	s.published = append(s.published, ID)
+	return nil
+}
+
+func (s *fakeStore) MarkOutboxFailed(_ context.Context, ID uuid.UUID, attempts int, nextAttemptAt *time.Time, publishedSinks []string, lastError string) error {
+	if s.failed == nil {
+		s.failed = map[uuid.UUID]failedMark{}
+	}
+	s.failed[ID] = failedMark{attempts: attempts, nextAttemptAt: nextAttemptAt, publishedSinks: publishedSinks, lastError: lastError}
+	return nil
+}
+
+type fakeSink struct {
+	name     string
+	err      error
+	received []uuid.UUID
+}
+
+func (s *fakeSink) Name() string { return s.name }
+
+func (s *fakeSink) Publish(_ context.Context, msg outbox.Message) error {
+	s.received = append(s.received, msg.ID)
+	return s.err
+}
+
+func TestRelay_RelayPending(t *testing.T) {
+	ctx := context.Background()
+	msg1 := outbox.Message{ID: uuid.New(), Topic: "pr.created", Key: "pr1"}
+	msg2 := outbox.Message{ID: uuid.New(), Topic: "pr.merged", Key: "pr2", Attempts: 2}
+	sinkErr := errors.New("sink is down")
+
+	t.Run("message accepted by all sinks is marked published", func(t *testing.T) {
+		store := &fakeStore{messages: []outbox.Message{msg1, msg2}}
+		log, file := &fakeSink{name: "log"}, &fakeSink{name: "file"}
+
+		published, err := outbox.NewRelay(store, time.Second, 10, 0, log, file).RelayPending(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, published)
+		assert.Equal(t, []uuid.UUID{msg1.ID, msg2.ID}, store.published)
+		assert.Empty(t, store.failed)
+		assert.Equal(t, []uuid.UUID{msg1.ID, msg2.ID}, log.received)
+		assert.Equal(t, []uuid.UUID{msg1.ID, msg2.ID}, file.received)
+	})
+
+	t.Run("partial sink failure keeps accepted sinks and backs off", func(t *testing.T) {
+		store := &fakeStore{messages: []outbox.Message{msg2}}
+		log, http := &fakeSink{name: "log"}, &fakeSink{name: "http", err: sinkErr}
+
+		before := time.Now()
+		published, err := outbox.NewRelay(store, time.Second, 10, 0, log, http).RelayPending(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, published)
+		assert.Empty(t, store.published)
+
+		mark := store.failed[msg2.ID]
+		assert.Equal(t, 3, mark.attempts)
+		assert.Equal(t, []string{"log"}, mark.publishedSinks)
+		assert.Contains(t, mark.lastError, "http: sink is down")
+		require.NotNil(t, mark.nextAttemptAt)
+		assert.WithinRange(t, *mark.nextAttemptAt, before.Add(outbox.Backoff(3)), time.Now().Add(outbox.Backoff(3)))
+	})
+
+	t.Run("message is dead after max attempts", func(t *testing.T) {
+		store := &fakeStore{messages: []outbox.Message{msg2}}
+		log, http := &fakeSink{name: "log"}, &fakeSink{name: "http", err: sinkErr}
+
+		published, err := outbox.NewRelay(store, time.Second, 10, 3, log, http).RelayPending(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, published)
+
+		mark := store.failed[msg2.ID]
+		assert.Equal(t, 3, mark.attempts)
+		assert.Nil(t, mark.nextAttemptAt)
+		assert.Equal(t, []string{"log"}, mark.publishedSinks)
+	})
+
+	t.Run("retry skips sinks which already accepted the message", func(t *testing.T) {
+		retried := msg1
+		retried.Attempts = 1
+		retried.PublishedSinks = []string{"log"}
+		store := &fakeStore{messages: []outbox.Message{retried}}
+		log, http := &fakeSink{name: "log"}, &fakeSink{name: "http"}
+
+		published, err := outbox.NewRelay(store, time.Second, 10, 0, log, http).RelayPending(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, published)
+		assert.Equal(t, []uuid.UUID{msg1.ID}, store.published)
+		assert.Empty(t, log.received)
+		assert.Equal(t, []uuid.UUID{msg1.ID}, http.received)
+	})
+
+	t.Run("failed sink does not stop the batch", func(t *testing.T) {
+		store := &fakeStore{messages: []outbox.Message{msg1, msg2}}
+		http := &fakeSink{name: "http", err: sinkErr}
+
+		published, err := outbox.NewRelay(store, time.Second, 10, 0, http).RelayPending(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, published)
+		assert.Len(t, store.failed, 2)
+		assert.NotNil(t, store.failed[msg1.ID].publishedSinks)
+		assert.Empty(t, store.failed[msg1.ID].publishedSinks)
+	})
+
+	t.Run("batch size limits claim", func(t *testing.T) {
+		store := &fakeStore{messages: []outbox.Message{msg1, msg2}}
+
+		published, err := outbox.NewRelay(store, time.Second, 1, 0, &fakeSink{name: "log"}).RelayPending(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, published)
+		assert.Equal(t, []uuid.UUID{msg1.ID}, store.published)
+	})
+
+	t.Run("claim error", func(t *testing.T) {
+		store := &fakeStore{claimErr: errors.New("db is down")}
+
+		published, err := outbox.NewRelay(store, time.Second, 10, 0, &fakeSink{name: "log"}).RelayPending(ctx)
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, published)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, outbox.Backoff(1))
+	assert.Equal(t, 2*time.Second, outbox.Backoff(2))
+	assert.Equal(t, 16*time.Second, outbox.Backoff(5))
+	assert.Equal(t, 10*time.Minute, outbox.Backoff(30))
+}
@@ -0,0 +1,126 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Envelope is the JSON representation of a message written by HTTP and file sinks
+type Envelope struct {
+	ID        string          `json:"id"`
+	Topic     string          `json:"topic"`
+	Key       string          `json:"key"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func NewEnvelope(msg Message) Envelope {
+	return Envelope{
+		ID:        msg.ID.String(),
+		Topic:     msg.Topic,
+		Key:       msg.Key,
+		CreatedAt: msg.CreatedAt,
+		Data:      msg.Payload,
+	}
+}
+
+// LogSink writes messages to the application log
+type LogSink struct{}
+
+func NewLogSink() *LogSink {
+	return &LogSink{}
+}
+
+func (s *LogSink) Name() string { return "log" }
+
+func (s *LogSink) Publish(_ context.Context, msg Message) error {
+	logrus.WithFields(logrus.Fields{
+		"id":    msg.ID,
+		"topic": msg.Topic,
+		"key":   msg.Key,
+	}).Infof("OutboxEvent: %s", msg.Payload)
+	return nil
+}
+
+// HTTPSink posts every message as JSON envelope to the URL. Any non-2xx response is a failure
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPSink(url string, timeout time.Duration) *HTTPSink {
+	return &HTTPSink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *HTTPSink) Name() string { return "http" }
+
+func (s *HTTPSink) Publish(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(NewEnvelope(msg))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Topic", msg.Topic)
+	req.Header.Set("X-Event-ID", msg.ID.String())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// FileSink appends messages as JSON lines to a local file. Stand-in for a message broker:
+// topic plays the role of the subject, consumers can tail the file
+type FileSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+func (s *FileSink) Name() string { return "file" }
+
+func (s *FileSink) Publish(_ context.Context, msg Message) error {
+	line, err := json.Marshal(NewEnvelope(msg))
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,57 @@
+package periodic
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Runner calls fn every interval in background. Errors of fn are logged, the next tick runs anyway
+type Runner struct {
+	name     string
+	interval time.Duration
+	fn       func(ctx context.Context) error
+
+	done chan struct{}
+}
+
+func New(name string, interval time.Duration, fn func(ctx context.Context) error) *Runner {
+	return &Runner{
+		name:     name,
+		interval: interval,
+		fn:       fn,
+		done:     make(chan struct{}),
+	}
+}
+
+// Start runs fn in background until ctx is cancelled
+func (r *Runner) Start(ctx context.Context) {
+	go r.run(ctx)
+}
+
+// Done is closed after the runner has stopped
+func (r *Runner) Done() <-chan struct{} {
+	return r.done
+}
+
+func (r *Runner) run(ctx context.Context) {
+	defer close(r.done)
+
+	logrus.Infof("%s: started, interval %v", r.name, r.interval)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Infof("%s: stopped", r.name)
+			return
+		case <-ticker.C:
+			if err := r.fn(ctx); err != nil {
+				logrus.Errorf("%s: pass failed: %v", r.name, err)
+			}
+		}
+	}
+}
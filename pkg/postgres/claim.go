@@ -0,0 +1,24 @@
+package postgres
+
+import "fmt"
+
+// ClaimQuery returns WITH clause which takes up to $1 rows of table matching where in orderBy order,
+// locked with SKIP LOCKED, and postpones their next_attempt_at by $2 seconds, so that concurrent
+// callers never get the same row. Claimed rows are available to the following statement as "claimed".
+// RETURNING does not keep the order of the lock, so the statement has to order rows itself
+func ClaimQuery(table, where, orderBy string) string {
+	return fmt.Sprintf(`
+		WITH due AS (
+			SELECT id FROM %[1]s
+			WHERE %[2]s
+			ORDER BY %[3]s
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), claimed AS (
+			UPDATE %[1]s AS t
+			SET next_attempt_at = now() + make_interval(secs => $2::float8)
+			FROM due
+			WHERE t.id = due.id
+			RETURNING t.*
+		)`, table, where, orderBy)
+}
@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Outbox table is created by the service migrations:
+//
+//	outbox(id, topic, key, payload JSONB, attempts, next_attempt_at, last_error, published_sinks TEXT[], published_at, dead_at, created_at)
+const outboxTable = "outbox"
+
+// OutboxMessage is an event stored in the outbox. Key identifies the entity the event is about,
+// PublishedSinks are names of the sinks which already accepted the message on previous attempts
+type OutboxMessage struct {
+	ID             uuid.UUID       `db:"id"`
+	Topic          string          `db:"topic"`
+	Key            string          `db:"key"`
+	Payload        json.RawMessage `db:"payload"`
+	Attempts       int             `db:"attempts"`
+	PublishedSinks []string        `db:"published_sinks"`
+	CreatedAt      time.Time       `db:"created_at"`
+}
+
+// EnqueueOutbox writes event to the outbox through the transaction from ctx, so the event
+// is committed or rolled back together with the business change. Payload is marshalled to JSON
+func (pg *Postgres) EnqueueOutbox(ctx context.Context, topic, key string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("postgres - Marshal %s outbox payload: %w", topic, err)
+	}
+
+	query, args, _ := pg.Builder.Insert(outboxTable).
+		Columns("topic", "key", "payload").
+		Values(topic, key, body).
+		ToSql()
+
+	if _, err := pg.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("postgres - Enqueue %s outbox message: %w", topic, err)
+	}
+	return nil
+}
+
+// ClaimOutbox takes up to limit due unpublished messages in creation order and postpones them
+// by lease, so that other relays skip them meanwhile. Concurrent callers never get the same message
+func (pg *Postgres) ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]OutboxMessage, error) {
+	query := ClaimQuery(outboxTable, "published_at IS NULL AND dead_at IS NULL AND next_attempt_at <= now()", "created_at") + `
+		SELECT id, topic, key, payload, attempts, published_sinks, created_at
+		FROM claimed
+		ORDER BY created_at;
+	`
+
+	rows, err := pg.GetTxManager(ctx).Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("postgres - Claim outbox messages: %w", err)
+	}
+
+	messages, err := pgx.CollectRows(rows, pgx.RowToStructByName[OutboxMessage])
+	if err != nil {
+		return nil, fmt.Errorf("postgres - Scan outbox messages: %w", err)
+	}
+	return messages, nil
+}
+
+// MarkOutboxPublished records that message was delivered to all sinks
+func (pg *Postgres) MarkOutboxPublished(ctx context.Context, ID uuid.UUID) error {
+	query, args, _ := pg.Builder.Update(outboxTable).
+		Set("published_at", squirrel.Expr("now()")).
+		Set("last_error", "").
+		Where("id = ?", ID).
+		ToSql()
+
+	if _, err := pg.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("postgres - Mark outbox message %s published: %w", ID, err)
+	}
+	return nil
+}
+
+// MarkOutboxFailed records failed attempt, message is retried at nextAttemptAt only for sinks
+// which are not in publishedSinks. Nil nextAttemptAt marks the message dead, it is never retried
+func (pg *Postgres) MarkOutboxFailed(ctx context.Context, ID uuid.UUID, attempts int, nextAttemptAt *time.Time, publishedSinks []string, lastError string) error {
+	builder := pg.Builder.Update(outboxTable).
+		Set("attempts", attempts).
+		Set("published_sinks", publishedSinks).
+		Set("last_error", lastError).
+		Where("id = ?", ID)
+
+	if nextAttemptAt != nil {
+		builder = builder.Set("next_attempt_at", *nextAttemptAt)
+	} else {
+		builder = builder.Set("dead_at", squirrel.Expr("now()"))
+	}
+
+	query, args, _ := builder.ToSql()
+
+	if _, err := pg.GetTxManager(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("postgres - Mark outbox message %s failed: %w", ID, err)
+	}
+	return nil
+}
+
+// DeleteOutboxPublished removes messages published before the given moment, returns the number of removed.
+// Dead messages are kept
+func (pg *Postgres) DeleteOutboxPublished(ctx context.Context, before time.Time) (int, error) {
+	query, args, _ := pg.Builder.Delete(outboxTable).
+		Where("published_at < ?", before).
+		ToSql()
+
+	tag, err := pg.GetTxManager(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("postgres - Delete published outbox messages: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTx records statements run through GetTxManager and returns rows for queries
+type fakeTx struct {
+	pgx.Tx
+
+	sql  []string
+	args [][]any
+	rows *fakeRows
+}
+
+func (tx *fakeTx) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	tx.sql = append(tx.sql, sql)
+	tx.args = append(tx.args, args)
+	return pgconn.CommandTag{}, nil
+}
+
+func (tx *fakeTx) Query(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+	tx.sql = append(tx.sql, sql)
+	tx.args = append(tx.args, args)
+	return tx.rows, nil
+}
+
+type fakeRows struct {
+	pgx.Rows
+
+	columns []string
+	values  [][]any
+	current int
+}
+
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription {
+	fields := make([]pgconn.FieldDescription, len(r.columns))
+	for i, name := range r.columns {
+		fields[i] = pgconn.FieldDescription{Name: name}
+	}
+	return fields
+}
+
+func (r *fakeRows) Next() bool {
+	r.current++
+	return r.current <= len(r.values)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(r.values[r.current-1][i]))
+	}
+	return nil
+}
+
+func (r *fakeRows) Err() error                    { return nil }
+func (r *fakeRows) Close()                        {}
+func (r *fakeRows) CommandTag() pgconn.CommandTag { return pgconn.CommandTag{} }
+
+func newTestPostgres(tx *fakeTx) (*Postgres, context.Context) {
+	pg := &Postgres{Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)}
+	return pg, injectTx(context.Background(), tx)
+}
+
+func TestEnqueueOutbox(t *testing.T) {
+	tx := &fakeTx{}
+	pg, ctx := newTestPostgres(tx)
+
+	err := pg.EnqueueOutbox(ctx, "pr.created", "pr1", map[string]string{"pull_request_id": "pr1"})
+
+	require.NoError(t, err)
+	require.Len(t, tx.sql, 1)
+	assert.Equal(t, "INSERT INTO outbox (topic,key,payload) VALUES ($1,$2,$3)", tx.sql[0])
+	assert.Equal(t, []any{"pr.created", "pr1", []byte(`{"pull_request_id":"pr1"}`)}, tx.args[0])
+}
+
+func TestClaimOutbox(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	created := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	tx := &fakeTx{rows: &fakeRows{
+		columns: []string{"id", "topic", "key", "payload", "attempts", "published_sinks", "created_at"},
+		values: [][]any{
+			{first, "pr.created", "pr1", json.RawMessage(`{}`), 0, []string{}, created},
+			{second, "pr.merged", "pr2", json.RawMessage(`{}`), 2, []string{"log"}, created.Add(time.Second)},
+		},
+	}}
+	pg, ctx := newTestPostgres(tx)
+
+	messages, err := pg.ClaimOutbox(ctx, 10, time.Minute)
+
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, first, messages[0].ID)
+	assert.Equal(t, []string{"log"}, messages[1].PublishedSinks)
+	assert.Equal(t, 2, messages[1].Attempts)
+
+	assert.Equal(t, []any{10, 60.0}, tx.args[0])
+	assert.Contains(t, tx.sql[0], "FOR UPDATE SKIP LOCKED")
+	assert.Contains(t, tx.sql[0], "WHERE published_at IS NULL AND dead_at IS NULL AND next_attempt_at <= now()")
+	assert.True(t, strings.HasSuffix(strings.TrimSpace(tx.sql[0]), "ORDER BY created_at;"))
+}
+
+func TestMarkOutboxPublished(t *testing.T) {
+	tx := &fakeTx{}
+	pg, ctx := newTestPostgres(tx)
+	id := uuid.New()
+
+	require.NoError(t, pg.MarkOutboxPublished(ctx, id))
+
+	assert.Equal(t, "UPDATE outbox SET published_at = now(), last_error = $1 WHERE id = $2", tx.sql[0])
+	assert.Equal(t, []any{"", id}, tx.args[0])
+}
+
+func TestMarkOutboxFailed(t *testing.T) {
+	t.Run("retried later", func(t *testing.T) {
+		tx := &fakeTx{}
+		pg, ctx := newTestPostgres(tx)
+		id := uuid.New()
+		next := time.Now().Add(time.Minute)
+
+		require.NoError(t, pg.MarkOutboxFailed(ctx, id, 3, &next, []string{"log"}, "http: timeout"))
+
+		assert.Equal(t, "UPDATE outbox SET attempts = $1, published_sinks = $2, last_error = $3, next_attempt_at = $4 WHERE id = $5", tx.sql[0])
+		assert.Equal(t, []any{3, []string{"log"}, "http: timeout", next, id}, tx.args[0])
+	})
+
+	t.Run("dead", func(t *testing.T) {
+		tx := &fakeTx{}
+		pg, ctx := newTestPostgres(tx)
+		id := uuid.New()
+
+		require.NoError(t, pg.MarkOutboxFailed(ctx, id, 20, nil, []string{}, "http: timeout"))
+
+		assert.Equal(t, "UPDATE outbox SET attempts = $1, published_sinks = $2, last_error = $3, dead_at = now() WHERE id = $4", tx.sql[0])
+		assert.Equal(t, []any{20, []string{}, "http: timeout", id}, tx.args[0])
+	})
+}
+
+func TestDeleteOutboxPublished(t *testing.T) {
+	tx := &fakeTx{}
+	pg, ctx := newTestPostgres(tx)
+	before := time.Now().Add(-24 * time.Hour)
+
+	_, err := pg.DeleteOutboxPublished(ctx, before)
+
+	require.NoError(t, err)
+	assert.Equal(t, "DELETE FROM outbox WHERE published_at < $1", tx.sql[0])
+	assert.Equal(t, []any{before}, tx.args[0])
+}
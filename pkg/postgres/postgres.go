@@ -99,12 +99,39 @@ func (pg *Postgres) GetTxManager(ctx context.Context) TxManager {
 	return pg.Pool
 }
 
+// WithinTransaction runs fn in a transaction. Inside another transaction fn joins it,
+// so the changes are committed or rolled back together with the outer transaction
 func (pg *Postgres) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := extractTx(ctx); ok {
+		return fn(ctx)
+	}
+
 	tx, err := pg.Pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("postgres - Begin transaction: %w", err)
 	}
 
+	return runTx(ctx, tx, fn)
+}
+
+// WithinSavepoint runs fn in a savepoint of the transaction from ctx, so that failure of fn rolls back
+// only its own changes and the outer transaction can go on. Without a transaction it is WithinTransaction
+func (pg *Postgres) WithinSavepoint(ctx context.Context, fn func(context.Context) error) error {
+	outer, ok := extractTx(ctx)
+	if !ok {
+		return pg.WithinTransaction(ctx, fn)
+	}
+
+	tx, err := outer.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("postgres - Begin savepoint: %w", err)
+	}
+
+	return runTx(ctx, tx, fn)
+}
+
+// Commits tx if fn succeeded, rolls it back otherwise
+func runTx(ctx context.Context, tx pgx.Tx, fn func(context.Context) error) error {
 	ctxTx := injectTx(ctx, tx)
 
 	if err := fn(ctxTx); err != nil {
@@ -114,3 +141,32 @@ func (pg *Postgres) WithinTransaction(ctx context.Context, fn func(context.Conte
 
 	return tx.Commit(ctx)
 }
+
+// WithAdvisoryLock runs fn only if session advisory lock with the key is free, so that
+// only one replica does the job at a time. Returns false when the lock is held by someone else
+func (pg *Postgres) WithAdvisoryLock(ctx context.Context, key int64, fn func(context.Context) error) (bool, error) {
+	conn, err := pg.Pool.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("postgres - Acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	var locked bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked); err != nil {
+		return false, fmt.Errorf("postgres - Try advisory lock: %w", err)
+	}
+	if !locked {
+		return false, nil
+	}
+
+	defer func() {
+		// Unlock even if ctx is cancelled, otherwise the lock stays with the pooled connection
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", key); err != nil {
+			log.Errorf("postgres - Advisory unlock: %v", err)
+			// Closed connection is dropped by the pool and releases the lock
+			_ = conn.Conn().Close(context.Background())
+		}
+	}()
+
+	return true, fn(ctx)
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Savepoints begun on the fake transaction, with their outcome
+type savepoint struct {
+	*fakeTx
+
+	committed  bool
+	rolledBack bool
+}
+
+func (s *savepoint) Commit(context.Context) error {
+	s.committed = true
+	return nil
+}
+
+func (s *savepoint) Rollback(context.Context) error {
+	s.rolledBack = true
+	return nil
+}
+
+type outerTx struct {
+	*fakeTx
+
+	savepoints []*savepoint
+}
+
+func (tx *outerTx) Begin(context.Context) (pgx.Tx, error) {
+	sp := &savepoint{fakeTx: &fakeTx{}}
+	tx.savepoints = append(tx.savepoints, sp)
+	return sp, nil
+}
+
+func TestWithinTransaction_JoinsOuterTransaction(t *testing.T) {
+	pg := &Postgres{}
+	outer := &outerTx{fakeTx: &fakeTx{}}
+	ctx := injectTx(context.Background(), outer)
+
+	fnErr := errors.New("fn failed")
+	err := pg.WithinTransaction(ctx, func(ctx context.Context) error {
+		tx, ok := extractTx(ctx)
+		require.True(t, ok)
+		assert.Same(t, outer, tx)
+		return fnErr
+	})
+
+	assert.ErrorIs(t, err, fnErr)
+	assert.Empty(t, outer.savepoints)
+}
+
+func TestWithinSavepoint(t *testing.T) {
+	fnErr := errors.New("fn failed")
+
+	tests := []struct {
+		name               string
+		fnErr              error
+		expectedCommitted  bool
+		expectedRolledBack bool
+	}{
+		{name: "success commits savepoint", expectedCommitted: true},
+		{name: "failure rolls back only savepoint", fnErr: fnErr, expectedRolledBack: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg := &Postgres{}
+			outer := &outerTx{fakeTx: &fakeTx{}}
+			ctx := injectTx(context.Background(), outer)
+
+			err := pg.WithinSavepoint(ctx, func(ctx context.Context) error {
+				// Nested transaction joins the savepoint
+				return pg.WithinTransaction(ctx, func(ctx context.Context) error {
+					tx, _ := extractTx(ctx)
+					assert.Same(t, outer.savepoints[0], tx)
+					return tt.fnErr
+				})
+			})
+
+			assert.ErrorIs(t, err, tt.fnErr)
+			require.Len(t, outer.savepoints, 1)
+			assert.Equal(t, tt.expectedCommitted, outer.savepoints[0].committed)
+			assert.Equal(t, tt.expectedRolledBack, outer.savepoints[0].rolledBack)
+		})
+	}
+}
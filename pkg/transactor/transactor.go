@@ -4,5 +4,8 @@ import "context"
 
 //go:generate go tool mockgen -source=transactor.go -destination=../../internal/mocks/mock_transactor.go
 type Transactor interface {
+	// Runs fn in a transaction, nested calls join the outer one
 	WithinTransaction(ctx context.Context, fn func(context.Context) error) error
+	// Runs fn in a savepoint of the outer transaction, its failure doesn't abort the outer one
+	WithinSavepoint(ctx context.Context, fn func(context.Context) error) error
 }